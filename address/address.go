@@ -0,0 +1,199 @@
+package address
+
+import (
+	"fmt"
+
+	"github.com/goat-systems/go-tezos/v3/b58"
+	"github.com/pkg/errors"
+)
+
+// Kind is the kind of a Tezos address.
+type Kind string
+
+const (
+	// Tz1 is an implicit account with an Ed25519 key.
+	Tz1 Kind = "tz1"
+	// Tz2 is an implicit account with a Secp256k1 key.
+	Tz2 Kind = "tz2"
+	// Tz3 is an implicit account with a NistP256 key.
+	Tz3 Kind = "tz3"
+	// Tz4 is an implicit account with a BLS12-381 key.
+	Tz4 Kind = "tz4"
+	// KT1 is an originated smart contract.
+	KT1 Kind = "KT1"
+	// Sr1 is a smart rollup.
+	Sr1 Kind = "sr1"
+)
+
+// HashLength is the length of the hash of every kind of address.
+const HashLength = 20
+
+type kindInfo struct {
+	prefix b58.Prefix
+	// tag of the public key hash binary encoding (implicit accounts only)
+	pkhTag byte
+	// tag of the contract id binary encoding
+	contractTag byte
+}
+
+var kinds = map[Kind]kindInfo{
+	Tz1: {prefix: b58.Ed25519PublicKeyHash, pkhTag: 0, contractTag: 0},
+	Tz2: {prefix: b58.Secp256k1PublicKeyHash, pkhTag: 1, contractTag: 0},
+	Tz3: {prefix: b58.P256PublicKeyHash, pkhTag: 2, contractTag: 0},
+	Tz4: {prefix: b58.BLS12_381PublicKeyHash, pkhTag: 3, contractTag: 0},
+	KT1: {prefix: b58.ContractHash, contractTag: 1},
+	Sr1: {prefix: b58.SmartRollupHash, contractTag: 3},
+}
+
+// Address is a validated Tezos address.
+type Address struct {
+	kind Kind
+	hash []byte
+}
+
+/*
+Parse validates a base58 encoded address (tz1, tz2, tz3, tz4, KT1 or sr1) and its checksum.
+
+Parameters:
+
+	address:
+		The base58 encoded address, e.g. tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo.
+*/
+func Parse(address string) (Address, error) {
+	if len(address) < 3 {
+		return Address{}, fmt.Errorf("invalid address '%s'", address)
+	}
+
+	info, ok := kinds[Kind(address[:3])]
+	if !ok {
+		return Address{}, fmt.Errorf("invalid address '%s': unknown prefix '%s'", address, address[:3])
+	}
+
+	v, err := b58.DecodeAs(address, info.prefix)
+	if err != nil {
+		return Address{}, errors.Wrapf(err, "invalid address '%s'", address)
+	}
+
+	return Address{
+		kind: Kind(address[:3]),
+		hash: v,
+	}, nil
+}
+
+/*
+New returns an Address from its kind and raw hash.
+
+Parameters:
+
+	kind:
+		The kind of address.
+
+	hash:
+		The 20 byte hash of the address.
+*/
+func New(kind Kind, hash []byte) (Address, error) {
+	if _, ok := kinds[kind]; !ok {
+		return Address{}, fmt.Errorf("invalid address kind '%s'", kind)
+	}
+
+	if len(hash) != HashLength {
+		return Address{}, fmt.Errorf("invalid address hash length %d", len(hash))
+	}
+
+	return Address{
+		kind: kind,
+		hash: append([]byte{}, hash...),
+	}, nil
+}
+
+/*
+FromPublicKeyHashBytes decodes the 21 byte binary encoding of a public key hash (a curve tag followed by the hash),
+as used for sources and delegates when forging.
+*/
+func FromPublicKeyHashBytes(v []byte) (Address, error) {
+	if len(v) != 1+HashLength {
+		return Address{}, fmt.Errorf("invalid public key hash length %d", len(v))
+	}
+
+	for _, kind := range []Kind{Tz1, Tz2, Tz3, Tz4} {
+		if kinds[kind].pkhTag == v[0] {
+			return New(kind, v[1:])
+		}
+	}
+
+	return Address{}, fmt.Errorf("invalid public key hash tag %d", v[0])
+}
+
+/*
+FromContractBytes decodes the 22 byte binary encoding of a contract id, as used for destinations when forging
+and for addresses in Micheline.
+*/
+func FromContractBytes(v []byte) (Address, error) {
+	if len(v) != 2+HashLength {
+		return Address{}, fmt.Errorf("invalid contract length %d", len(v))
+	}
+
+	if v[0] == 0 {
+		return FromPublicKeyHashBytes(v[1:])
+	}
+
+	for _, kind := range []Kind{KT1, Sr1} {
+		if kinds[kind].contractTag == v[0] {
+			if v[len(v)-1] != 0 {
+				return Address{}, fmt.Errorf("invalid contract padding %d", v[len(v)-1])
+			}
+			return New(kind, v[1:len(v)-1])
+		}
+	}
+
+	return Address{}, fmt.Errorf("invalid contract tag %d", v[0])
+}
+
+// Kind returns the kind of the address.
+func (a Address) Kind() Kind {
+	return a.kind
+}
+
+// Hash returns the raw 20 byte hash of the address.
+func (a Address) Hash() []byte {
+	return append([]byte{}, a.hash...)
+}
+
+// IsImplicit reports whether the address is an implicit account (tz1, tz2, tz3 or tz4).
+func (a Address) IsImplicit() bool {
+	switch a.kind {
+	case Tz1, Tz2, Tz3, Tz4:
+		return true
+	}
+	return false
+}
+
+// String returns the base58 encoded address.
+func (a Address) String() string {
+	info, ok := kinds[a.kind]
+	if !ok {
+		return ""
+	}
+
+	return b58.MustEncode(info.prefix, a.hash)
+}
+
+// PublicKeyHashBytes returns the 21 byte binary encoding of an implicit account.
+func (a Address) PublicKeyHashBytes() ([]byte, error) {
+	if !a.IsImplicit() {
+		return []byte{}, fmt.Errorf("address '%s' is not an implicit account", a.String())
+	}
+
+	return append([]byte{kinds[a.kind].pkhTag}, a.hash...), nil
+}
+
+// ContractBytes returns the 22 byte binary encoding of the address as a contract id.
+func (a Address) ContractBytes() []byte {
+	info := kinds[a.kind]
+	if a.IsImplicit() {
+		return append([]byte{0, info.pkhTag}, a.hash...)
+	}
+
+	v := append([]byte{info.contractTag}, a.hash...)
+	return append(v, 0)
+}
@@ -0,0 +1,164 @@
+package address
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v3/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Parse(t *testing.T) {
+	type want struct {
+		wantErr     bool
+		containsErr string
+		kind        Kind
+		implicit    bool
+		contract    string
+	}
+
+	cases := []struct {
+		name    string
+		address string
+		want    want
+	}{
+		{
+			"is successful with tz1",
+			"tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e",
+			want{false, "", Tz1, true, "00001fb7d0a599ddca61b88dc203eeefbac341422cdf"},
+		},
+		{
+			"is successful with tz2",
+			"tz2Ch1abG7FNiibmV26Uzgdsnfni9XGrk5wD",
+			want{false, "", Tz2, true, "00012ffebbf1560632ca767bc960ccdb84669d284c2c"},
+		},
+		{
+			"is successful with tz3",
+			"tz3Lfm6CyfSTZ7EgMckptZZGiPxzs9GK59At",
+			want{false, "", Tz3, true, "000203ba8658b6e5c7f5bc18df8b192f8733d511c6b5"},
+		},
+		{
+			"is successful with tz4",
+			"tz4HVR6aty9KwsQFHh81C1G7gBdhxT8kuytm",
+			want{false, "", Tz4, true, "00035d1497f39b87599983fe8f29599b679564be822d"},
+		},
+		{
+			"is successful with KT1",
+			"KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9",
+			want{false, "", KT1, false, "016498b7494a18a572c1d24484038545662c0454ed00"},
+		},
+		{
+			"is successful with sr1",
+			"sr1UNDWPUYVeomgG15wn5jSw689EJ4RNnVQa",
+			want{false, "", Sr1, false, "03f4e47cb3c43a68b0d48e3094092ca42d713addb500"},
+		},
+		{
+			"handles invalid checksum",
+			"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfop",
+			want{true, "invalid address 'tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfop'", "", false, ""},
+		},
+		{
+			"handles unknown prefix",
+			"edpkvEoAbkdaGALxi2FfeefB8hUkMZ4J1UVwkzyumx2GvbVpkYUHnm",
+			want{true, "unknown prefix 'edp'", "", false, ""},
+		},
+		{
+			"handles invalid length",
+			"tz1",
+			want{true, "invalid address 'tz1'", "", false, ""},
+		},
+		{
+			"handles empty address",
+			"",
+			want{true, "invalid address ''", "", false, ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := Parse(tt.address)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			if !tt.want.wantErr {
+				assert.Equal(t, tt.want.kind, addr.Kind())
+				assert.Equal(t, tt.want.implicit, addr.IsImplicit())
+				assert.Equal(t, tt.address, addr.String())
+				assert.Len(t, addr.Hash(), HashLength)
+				assert.Equal(t, tt.want.contract, hex.EncodeToString(addr.ContractBytes()))
+
+				decoded, err := FromContractBytes(addr.ContractBytes())
+				assert.Nil(t, err)
+				assert.Equal(t, addr, decoded)
+
+				pkh, err := addr.PublicKeyHashBytes()
+				if tt.want.implicit {
+					assert.Nil(t, err)
+					assert.Equal(t, tt.want.contract[2:], hex.EncodeToString(pkh))
+
+					decoded, err := FromPublicKeyHashBytes(pkh)
+					assert.Nil(t, err)
+					assert.Equal(t, addr, decoded)
+				} else {
+					testutils.CheckErr(t, true, "is not an implicit account", err)
+				}
+			}
+		})
+	}
+}
+
+func Test_FromContractBytes(t *testing.T) {
+	type want struct {
+		wantErr     bool
+		containsErr string
+		address     string
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want  want
+	}{
+		{
+			"is successful with implicit account",
+			"00012ffebbf1560632ca767bc960ccdb84669d284c2c",
+			want{false, "", "tz2Ch1abG7FNiibmV26Uzgdsnfni9XGrk5wD"},
+		},
+		{
+			"is successful with originated contract",
+			"016498b7494a18a572c1d24484038545662c0454ed00",
+			want{false, "", "KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9"},
+		},
+		{
+			"handles invalid length",
+			"016498b7494a18a572c1d24484038545662c0454ed",
+			want{true, "invalid contract length 21", ""},
+		},
+		{
+			"handles invalid tag",
+			"026498b7494a18a572c1d24484038545662c0454ed00",
+			want{true, "invalid contract tag 2", ""},
+		},
+		{
+			"handles invalid public key hash tag",
+			"00092ffebbf1560632ca767bc960ccdb84669d284c2c",
+			want{true, "invalid public key hash tag 9", ""},
+		},
+		{
+			"handles invalid padding",
+			"016498b7494a18a572c1d24484038545662c0454ed01",
+			want{true, "invalid contract padding 1", ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := hex.DecodeString(tt.input)
+			assert.Nil(t, err)
+
+			addr, err := FromContractBytes(v)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			if !tt.want.wantErr {
+				assert.Equal(t, tt.want.address, addr.String())
+			}
+		})
+	}
+}
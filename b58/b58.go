@@ -0,0 +1,290 @@
+package b58
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var (
+	// ErrInvalidEncoding is returned when a string contains characters outside of the base58 alphabet.
+	ErrInvalidEncoding = errors.New("invalid base58 encoding")
+	// ErrInvalidChecksum is returned when the base58check checksum does not match the payload.
+	ErrInvalidChecksum = errors.New("invalid base58 checksum")
+	// ErrUnknownPrefix is returned when the decoded bytes don't start with a known prefix.
+	ErrUnknownPrefix = errors.New("unknown base58 prefix")
+	// ErrInvalidLength is returned when the payload length doesn't match the length of its prefix.
+	ErrInvalidLength = errors.New("invalid payload length")
+)
+
+/*
+Prefix is a Tezos base58check prefix.
+
+Note:
+	Name is the human readable prefix of the encoded string (e.g. "tz1"), Bytes is the binary prefix
+	prepended to the payload before encoding, and Length is the length of the payload in bytes.
+*/
+type Prefix struct {
+	Name   string
+	Bytes  []byte
+	Length int
+}
+
+// Prefixes of the hashes, keys, signatures and addresses used by Tezos.
+var (
+	BlockHash                   = Prefix{"B", []byte{1, 52}, 32}
+	OperationHash               = Prefix{"o", []byte{5, 116}, 32}
+	OperationListHash           = Prefix{"Lo", []byte{133, 233}, 32}
+	OperationListListHash       = Prefix{"LLo", []byte{29, 159, 109}, 32}
+	ProtocolHash                = Prefix{"P", []byte{2, 170}, 32}
+	ContextHash                 = Prefix{"Co", []byte{79, 199}, 32}
+	BlockMetadataHash           = Prefix{"bm", []byte{234, 249}, 32}
+	BlockPayloadHash            = Prefix{"vh", []byte{1, 106, 242}, 32}
+	OperationMetadataHash       = Prefix{"r", []byte{5, 183}, 32}
+	OperationMetadataListHash   = Prefix{"Lr", []byte{134, 39}, 32}
+	OperationMetadataListsHash  = Prefix{"LLr", []byte{29, 159, 182}, 32}
+	ChainID                     = Prefix{"Net", []byte{87, 82, 0}, 4}
+	ScriptExpr                  = Prefix{"expr", []byte{13, 44, 64, 27}, 32}
+	NonceHash                   = Prefix{"nce", []byte{69, 220, 169}, 32}
+	SmartRollupStateHash        = Prefix{"srs1", []byte{17, 165, 235, 240}, 32}
+	SmartRollupCommitmentHash   = Prefix{"src1", []byte{17, 165, 134, 138}, 32}
+	Ed25519PublicKeyHash        = Prefix{"tz1", []byte{6, 161, 159}, 20}
+	Secp256k1PublicKeyHash      = Prefix{"tz2", []byte{6, 161, 161}, 20}
+	P256PublicKeyHash           = Prefix{"tz3", []byte{6, 161, 164}, 20}
+	BLS12_381PublicKeyHash      = Prefix{"tz4", []byte{6, 161, 166}, 20}
+	ContractHash                = Prefix{"KT1", []byte{2, 90, 121}, 20}
+	SmartRollupHash             = Prefix{"sr1", []byte{6, 124, 117}, 20}
+	BlindedPublicKeyHash        = Prefix{"btz1", []byte{1, 2, 49, 223}, 20}
+	Ed25519PublicKey            = Prefix{"edpk", []byte{13, 15, 37, 217}, 32}
+	Secp256k1PublicKey          = Prefix{"sppk", []byte{3, 254, 226, 86}, 33}
+	P256PublicKey               = Prefix{"p2pk", []byte{3, 178, 139, 127}, 33}
+	BLS12_381PublicKey          = Prefix{"BLpk", []byte{6, 149, 135, 204}, 48}
+	Ed25519Seed                 = Prefix{"edsk", []byte{13, 15, 58, 7}, 32}
+	Ed25519SecretKey            = Prefix{"edsk", []byte{43, 246, 78, 7}, 64}
+	Secp256k1SecretKey          = Prefix{"spsk", []byte{17, 162, 224, 201}, 32}
+	P256SecretKey               = Prefix{"p2sk", []byte{16, 81, 238, 189}, 32}
+	BLS12_381SecretKey          = Prefix{"BLsk", []byte{3, 150, 192, 40}, 32}
+	Ed25519EncryptedSeed        = Prefix{"edesk", []byte{7, 90, 60, 179, 41}, 56}
+	Secp256k1EncryptedSecretKey = Prefix{"spesk", []byte{9, 237, 241, 174, 150}, 56}
+	P256EncryptedSecretKey      = Prefix{"p2esk", []byte{9, 48, 57, 115, 171}, 56}
+	BLS12_381EncryptedSecretKey = Prefix{"BLesk", []byte{2, 5, 30, 53, 25}, 56}
+	Ed25519Signature            = Prefix{"edsig", []byte{9, 245, 205, 134, 18}, 64}
+	Secp256k1Signature          = Prefix{"spsig1", []byte{13, 115, 101, 19, 63}, 64}
+	P256Signature               = Prefix{"p2sig", []byte{54, 240, 44, 52}, 64}
+	BLS12_381Signature          = Prefix{"BLsig", []byte{40, 171, 64, 207}, 96}
+	GenericSignature            = Prefix{"sig", []byte{4, 130, 43}, 64}
+)
+
+// Prefixes is the registry of all known prefixes, used by Decode to identify the prefix of a string.
+var Prefixes = []Prefix{
+	BlockHash,
+	OperationHash,
+	OperationListHash,
+	OperationListListHash,
+	ProtocolHash,
+	ContextHash,
+	BlockMetadataHash,
+	BlockPayloadHash,
+	OperationMetadataHash,
+	OperationMetadataListHash,
+	OperationMetadataListsHash,
+	ChainID,
+	ScriptExpr,
+	NonceHash,
+	SmartRollupStateHash,
+	SmartRollupCommitmentHash,
+	Ed25519PublicKeyHash,
+	Secp256k1PublicKeyHash,
+	P256PublicKeyHash,
+	BLS12_381PublicKeyHash,
+	ContractHash,
+	SmartRollupHash,
+	BlindedPublicKeyHash,
+	Ed25519PublicKey,
+	Secp256k1PublicKey,
+	P256PublicKey,
+	BLS12_381PublicKey,
+	Ed25519Seed,
+	Ed25519SecretKey,
+	Secp256k1SecretKey,
+	P256SecretKey,
+	BLS12_381SecretKey,
+	Ed25519EncryptedSeed,
+	Secp256k1EncryptedSecretKey,
+	P256EncryptedSecretKey,
+	BLS12_381EncryptedSecretKey,
+	Ed25519Signature,
+	Secp256k1Signature,
+	P256Signature,
+	BLS12_381Signature,
+	GenericSignature,
+}
+
+/*
+Decode decodes a base58check string, identifies its prefix from the registry and returns the prefix and payload.
+
+Note:
+	The returned error wraps ErrInvalidEncoding, ErrInvalidChecksum, ErrUnknownPrefix or ErrInvalidLength,
+	which can be checked with errors.Is.
+
+Parameters:
+
+	s:
+		The base58check encoded string, e.g. BLockGenesisGenesisGenesisGenesisGenesisf79b5d1CoW2.
+*/
+func Decode(s string) (Prefix, []byte, error) {
+	return DecodePrefix(s, Prefixes...)
+}
+
+/*
+DecodePrefix decodes a base58check string that must be encoded with one of the prefixes passed and returns
+the prefix and payload.
+
+Parameters:
+
+	s:
+		The base58check encoded string.
+
+	prefixes:
+		The prefixes allowed, e.g. b58.Ed25519PublicKeyHash, b58.Secp256k1PublicKeyHash.
+*/
+func DecodePrefix(s string, prefixes ...Prefix) (Prefix, []byte, error) {
+	v, err := DecodeCheck(s)
+	if err != nil {
+		return Prefix{}, nil, err
+	}
+
+	var matched bool
+	for _, p := range prefixes {
+		if !bytes.HasPrefix(v, p.Bytes) {
+			continue
+		}
+
+		if len(v) == len(p.Bytes)+p.Length {
+			return p, v[len(p.Bytes):], nil
+		}
+		matched = true
+	}
+
+	if matched {
+		return Prefix{}, nil, errors.Wrapf(ErrInvalidLength, "failed to decode '%s'", s)
+	}
+
+	return Prefix{}, nil, errors.Wrapf(ErrUnknownPrefix, "failed to decode '%s'", s)
+}
+
+/*
+DecodeAs decodes a base58check string that must be encoded with the prefix passed and returns the payload.
+
+Parameters:
+
+	s:
+		The base58check encoded string.
+
+	prefix:
+		The expected prefix, e.g. b58.BlockHash.
+*/
+func DecodeAs(s string, prefix Prefix) ([]byte, error) {
+	_, v, err := DecodePrefix(s, prefix)
+	return v, err
+}
+
+/*
+Encode encodes a payload with the prefix passed.
+
+Parameters:
+
+	prefix:
+		The prefix to encode with, e.g. b58.BlockHash.
+
+	payload:
+		The payload, which must be prefix.Length bytes long.
+*/
+func Encode(prefix Prefix, payload []byte) (string, error) {
+	if len(payload) != prefix.Length {
+		return "", errors.Wrapf(ErrInvalidLength, "failed to encode %d bytes with prefix '%s'", len(payload), prefix.Name)
+	}
+
+	return EncodeCheck(append(append([]byte{}, prefix.Bytes...), payload...)), nil
+}
+
+// MustEncode is like Encode but panics if the payload length doesn't match the prefix.
+func MustEncode(prefix Prefix, payload []byte) string {
+	s, err := Encode(prefix, payload)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// EncodeCheck encodes bytes to base58 with a 4 byte double sha256 checksum.
+func EncodeCheck(v []byte) string {
+	v = append(append([]byte{}, v...), checksum(v)...)
+
+	n := new(big.Int).SetBytes(v)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, alphabet[mod.Int64()])
+	}
+
+	// leading zero bytes are encoded as '1'
+	for _, b := range v {
+		if b != 0 {
+			break
+		}
+		out = append(out, alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return string(out)
+}
+
+// DecodeCheck decodes a base58 string and validates and strips its 4 byte double sha256 checksum.
+func DecodeCheck(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+
+	var zeros int
+	for zeros < len(s) && s[zeros] == alphabet[0] {
+		zeros++
+	}
+
+	for i := 0; i < len(s); i++ {
+		pos := bytes.IndexByte([]byte(alphabet), s[i])
+		if pos == -1 {
+			return nil, errors.Wrapf(ErrInvalidEncoding, "failed to decode '%s'", s)
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(pos)))
+	}
+
+	v := append(make([]byte, zeros), n.Bytes()...)
+	if len(v) < 4 {
+		return nil, errors.Wrapf(ErrInvalidChecksum, "failed to decode '%s'", s)
+	}
+
+	data, sum := v[:len(v)-4], v[len(v)-4:]
+	if !bytes.Equal(sum, checksum(data)) {
+		return nil, errors.Wrapf(ErrInvalidChecksum, "failed to decode '%s'", s)
+	}
+
+	return data, nil
+}
+
+func checksum(v []byte) []byte {
+	h := sha256.Sum256(v)
+	h = sha256.Sum256(h[:])
+	return h[:4]
+}
@@ -0,0 +1,128 @@
+package b58
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v3/internal/testutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Decode(t *testing.T) {
+	type want struct {
+		wantErr     bool
+		containsErr string
+		err         error
+		prefix      Prefix
+		payload     string
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want  want
+	}{
+		{
+			"is successful with block hash",
+			"BLockGenesisGenesisGenesisGenesisGenesisf79b5d1CoW2",
+			want{false, "", nil, BlockHash, ""},
+		},
+		{
+			"is successful with chain id",
+			"NetXdQprcVkpaWU",
+			want{false, "", nil, ChainID, "7a06a770"},
+		},
+		{
+			"is successful with protocol hash",
+			"PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA",
+			want{false, "", nil, ProtocolHash, ""},
+		},
+		{
+			"is successful with ed25519 public key hash",
+			"tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e",
+			want{false, "", nil, Ed25519PublicKeyHash, "1fb7d0a599ddca61b88dc203eeefbac341422cdf"},
+		},
+		{
+			"is successful with secp256k1 public key",
+			"sppk7aqSksZan1AGXuKtCz9UBLZZ77e3ZWGpFxR7ig1Z17GneEhSSbH",
+			want{false, "", nil, Secp256k1PublicKey, ""},
+		},
+		{
+			"is successful with ed25519 seed",
+			"edsk3QoqBuvdamxouPhin7swCvkQNgq4jP5KZPbwWNnwdZpSpJiEbq",
+			want{false, "", nil, Ed25519Seed, ""},
+		},
+		{
+			"handles invalid checksum",
+			"tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8f",
+			want{true, "invalid base58 checksum", ErrInvalidChecksum, Prefix{}, ""},
+		},
+		{
+			"handles invalid encoding",
+			"tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk80",
+			want{true, "invalid base58 encoding", ErrInvalidEncoding, Prefix{}, ""},
+		},
+		{
+			"handles unknown prefix",
+			EncodeCheck([]byte{1, 2, 3, 4, 5}),
+			want{true, "unknown base58 prefix", ErrUnknownPrefix, Prefix{}, ""},
+		},
+		{
+			"handles invalid length",
+			EncodeCheck(append([]byte{6, 161, 159}, make([]byte, 19)...)),
+			want{true, "invalid payload length", ErrInvalidLength, Prefix{}, ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, payload, err := Decode(tt.input)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			if tt.want.wantErr {
+				assert.True(t, errors.Is(err, tt.want.err))
+				return
+			}
+
+			assert.Equal(t, tt.want.prefix, prefix)
+			assert.Len(t, payload, prefix.Length)
+			if tt.want.payload != "" {
+				assert.Equal(t, tt.want.payload, hex.EncodeToString(payload))
+			}
+
+			encoded, err := Encode(prefix, payload)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.input, encoded)
+		})
+	}
+}
+
+func Test_DecodeAs(t *testing.T) {
+	_, err := DecodeAs("tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e", ContractHash)
+	testutils.CheckErr(t, true, "unknown base58 prefix", err)
+
+	v, err := DecodeAs("KT1SkmB19o8nfhRvG9LL7TjDfX2Bm1nCuYoY", ContractHash)
+	testutils.CheckErr(t, false, "", err)
+	assert.Len(t, v, 20)
+}
+
+func Test_Encode(t *testing.T) {
+	_, err := Encode(BlockHash, make([]byte, 31))
+	testutils.CheckErr(t, true, "failed to encode 31 bytes with prefix 'B'", err)
+	assert.True(t, errors.Is(err, ErrInvalidLength))
+
+	v, err := Encode(ChainID, []byte{0x7a, 0x06, 0xa7, 0x70})
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, "NetXdQprcVkpaWU", v)
+}
+
+func Test_Prefixes(t *testing.T) {
+	for _, p := range Prefixes {
+		v := MustEncode(p, make([]byte, p.Length))
+		assert.Equal(t, p.Name, v[:len(p.Name)], p.Name)
+
+		decoded, _, err := Decode(v)
+		assert.Nil(t, err, p.Name)
+		assert.Equal(t, p, decoded)
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/hex"
 	"fmt"
 	"math/big"
 	"os"
@@ -55,22 +54,15 @@ func main() {
 		Destination: "<some_dest>",
 	}
 
-	op, err := forge.Encode(head.Hash, transaction.ToContent())
+	// key can be swapped for any keys.Signer, such as a signer.Remote
+	op, err := forge.EncodeAndSign(&key, head.Hash, transaction.ToContent())
 	if err != nil {
-		fmt.Printf("failed to forge transaction: %s\n", err.Error())
-		os.Exit(1)
-	}
-
-	signature, err := key.Sign(keys.SignInput{
-		Message: op,
-	})
-	if err != nil {
-		fmt.Printf("failed to sign operation: %s\n", err.Error())
+		fmt.Printf("failed to forge and sign transaction: %s\n", err.Error())
 		os.Exit(1)
 	}
 
 	ophash, err := client.InjectionOperation(rpc.InjectionOperationInput{
-		Operation: fmt.Sprintf("%s%s", op, hex.EncodeToString(signature.Bytes)),
+		Operation: op,
 	})
 	if err != nil {
 		fmt.Printf("failed to inject: %s\n", err.Error())
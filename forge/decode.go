@@ -0,0 +1,1196 @@
+package forge
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/goat-systems/go-tezos/v3/address"
+	"github.com/goat-systems/go-tezos/v3/b58"
+	"github.com/goat-systems/go-tezos/v3/rpc"
+	"github.com/pkg/errors"
+)
+
+/*
+Decode unforges an unsigned operation locally, e.g. one returned by Encode. GoTezos does not use the RPC or
+a trusted source to unforge operations. Every kind supported by Encode is supported. Operations are unforged
+for DefaultProtocol.
+
+Parameters:
+
+	operation:
+		The hex encoded operation, without a signature.
+*/
+func Decode(operation string) (string, rpc.Contents, error) {
+	return DefaultProtocol().Decode(operation)
+}
+
+/*
+DecodeSigned unforges a signed operation locally, e.g. one returned by EncodeAndSign. Operations are unforged
+for DefaultProtocol.
+
+Note:
+	The length of the signature depends on the curve of the key signing the operation, which is read from
+	the source of its first contents (the consensus key of a drain_delegate). Operations of tz4 accounts have
+	96 byte BLS signatures returned as BLsig..., the others 64 byte signatures returned with the generic prefix
+	(sig...) as the curve of the signature isn't part of the operation bytes.
+
+Parameters:
+
+	operation:
+		The hex encoded operation, with its signature.
+*/
+func DecodeSigned(operation string) (string, rpc.Contents, string, error) {
+	return DefaultProtocol().DecodeSigned(operation)
+}
+
+/*
+Decode unforges an unsigned operation locally for the protocol.
+
+Parameters:
+
+	operation:
+		The hex encoded operation, without a signature.
+*/
+func (p *Protocol) Decode(operation string) (string, rpc.Contents, error) {
+	branch, v, err := unforgeBranch(operation)
+	if err != nil {
+		return "", nil, err
+	}
+
+	contents, err := p.unforgeContents(v)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to unforge operation")
+	}
+
+	return branch, contents, nil
+}
+
+/*
+DecodeSigned unforges a signed operation locally for the protocol like DecodeSigned.
+
+Parameters:
+
+	operation:
+		The hex encoded operation, with its signature.
+*/
+func (p *Protocol) DecodeSigned(operation string) (string, rpc.Contents, string, error) {
+	branch, v, err := unforgeBranch(operation)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	first, err := p.unforgeContent(&decoder{v: v, p: p})
+	if err != nil {
+		return "", nil, "", errors.Wrap(err, "failed to unforge operation")
+	}
+
+	prefix := signaturePrefix(first)
+	if len(v) < prefix.Length {
+		return "", nil, "", errors.New("failed to unforge operation: missing signature")
+	}
+
+	contents, err := p.unforgeContents(v[:len(v)-prefix.Length])
+	if err != nil {
+		return "", nil, "", errors.Wrap(err, "failed to unforge operation")
+	}
+
+	return branch, contents, b58.MustEncode(prefix, v[len(v)-prefix.Length:]), nil
+}
+
+// unforgeBranch decodes a hex encoded operation and returns its branch and the bytes after it.
+func unforgeBranch(operation string) (string, []byte, error) {
+	v, err := hex.DecodeString(operation)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to unforge operation")
+	}
+
+	if len(v) < b58.BlockHash.Length {
+		return "", nil, errors.New("failed to unforge operation: missing branch")
+	}
+
+	return b58.MustEncode(b58.BlockHash, v[:b58.BlockHash.Length]), v[b58.BlockHash.Length:], nil
+}
+
+// signaturePrefix returns the prefix of the signature of an operation from the key signing its first contents.
+func signaturePrefix(content rpc.Content) b58.Prefix {
+	signer := content.Source
+	if content.Kind == rpc.DRAINDELEGATE {
+		signer = content.ConsensusKey
+	}
+
+	if a, err := address.Parse(signer); err == nil && a.Kind() == address.Tz4 {
+		return b58.BLS12_381Signature
+	}
+
+	return b58.GenericSignature
+}
+
+func (p *Protocol) unforgeContents(v []byte) (rpc.Contents, error) {
+	d := &decoder{v: v, p: p}
+
+	var contents rpc.Contents
+	for !d.empty() {
+		content, err := p.unforgeContent(d)
+		if err != nil {
+			return nil, err
+		}
+
+		contents = append(contents, content)
+	}
+
+	if len(contents) == 0 {
+		return nil, errors.New("operation has no contents")
+	}
+
+	return contents, nil
+}
+
+func (p *Protocol) unforgeContent(d *decoder) (rpc.Content, error) {
+	tag, err := d.byte()
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	kind, ok := p.operationKinds[tag]
+	if !ok {
+		return rpc.Content{}, fmt.Errorf("unsupported operation tag %d", tag)
+	}
+
+	var content rpc.Content
+	switch kind {
+	case rpc.ENDORSEMENT:
+		content, err = unforgeEndorsement(d)
+	case rpc.PREENDORSEMENT, rpc.ATTESTATION, rpc.PREATTESTATION:
+		content, err = unforgeConsensus(d)
+	case rpc.SEEDNONCEREVELATION:
+		content, err = unforgeSeedNonceRevelation(d)
+	case rpc.DOUBLEENDORSEMENTEVIDENCE:
+		content, err = unforgeDoubleEndorsementEvidence(d)
+	case rpc.DOUBLEBAKINGEVIDENCE:
+		content, err = unforgeDoubleBakingEvidence(d)
+	case rpc.ACTIVATEACCOUNT:
+		content, err = unforgeAccountActivation(d)
+	case rpc.PROPOSALS:
+		content, err = unforgeProposals(d)
+	case rpc.BALLOT:
+		content, err = unforgeBallot(d)
+	case rpc.REVEAL:
+		content, err = unforgeReveal(d)
+	case rpc.TRANSACTION:
+		content, err = unforgeTransaction(d)
+	case rpc.ORIGINATION:
+		content, err = unforgeOrigination(d)
+	case rpc.DELEGATION:
+		content, err = unforgeDelegation(d)
+	case rpc.REGISTERGLOBALCONSTANT:
+		content, err = unforgeRegisterGlobalConstant(d)
+	case rpc.SETDEPOSITSLIMIT:
+		content, err = unforgeSetDepositsLimit(d)
+	case rpc.INCREASEPAIDSTORAGE:
+		content, err = unforgeIncreasePaidStorage(d)
+	case rpc.UPDATECONSENSUSKEY:
+		content, err = unforgeUpdateConsensusKey(d)
+	case rpc.DRAINDELEGATE:
+		content, err = unforgeDrainDelegate(d)
+	case rpc.TRANSFERTICKET:
+		content, err = unforgeTransferTicket(d)
+	case rpc.SMARTROLLUPORIGINATE:
+		content, err = unforgeSmartRollupOriginate(d)
+	case rpc.SMARTROLLUPADDMESSAGES:
+		content, err = unforgeSmartRollupAddMessages(d)
+	case rpc.SMARTROLLUPCEMENT:
+		content, err = unforgeSmartRollupCement(d)
+	case rpc.SMARTROLLUPPUBLISH:
+		content, err = unforgeSmartRollupPublish(d)
+	case rpc.SMARTROLLUPEXECUTEOUTBOXMESSAGE:
+		content, err = unforgeSmartRollupExecuteOutboxMessage(d)
+	default:
+		err = errors.New("unsupported kind")
+	}
+	if err != nil {
+		return rpc.Content{}, errors.Wrapf(err, "failed to unforge %s", kind)
+	}
+
+	content.Kind = kind
+	return content, nil
+}
+
+func unforgeEndorsement(d *decoder) (rpc.Content, error) {
+	if d.p.tenderbake {
+		return unforgeConsensus(d)
+	}
+
+	level, err := d.int(4)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge level")
+	}
+
+	return rpc.Content{Level: level}, nil
+}
+
+func unforgeConsensus(d *decoder) (rpc.Content, error) {
+	slot, err := d.int(2)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge slot")
+	}
+
+	level, err := d.int(4)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge level")
+	}
+
+	round, err := d.int(4)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge round")
+	}
+
+	blockPayloadHash, err := d.base58(b58.BlockPayloadHash)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge block_payload_hash")
+	}
+
+	return rpc.Content{
+		Slot:             slot,
+		Level:            level,
+		Round:            round,
+		BlockPayloadHash: blockPayloadHash,
+	}, nil
+}
+
+func unforgeSeedNonceRevelation(d *decoder) (rpc.Content, error) {
+	level, err := d.int(4)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge level")
+	}
+
+	nonce, err := d.next(32)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge nonce")
+	}
+
+	return rpc.Content{
+		Level: level,
+		Nonce: hex.EncodeToString(nonce),
+	}, nil
+}
+
+func unforgeDoubleEndorsementEvidence(d *decoder) (rpc.Content, error) {
+	op1, err := unforgeInlinedEndorsement(d)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge op1")
+	}
+
+	op2, err := unforgeInlinedEndorsement(d)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge op2")
+	}
+
+	var slot int
+	if d.p.endorsementSlot {
+		if slot, err = d.int(2); err != nil {
+			return rpc.Content{}, errors.Wrap(err, "failed to unforge slot")
+		}
+	}
+
+	return rpc.Content{
+		Op1:  op1,
+		Op2:  op2,
+		Slot: slot,
+	}, nil
+}
+
+func unforgeInlinedEndorsement(d *decoder) (*rpc.InlinedEndorsement, error) {
+	v, err := d.array()
+	if err != nil {
+		return nil, err
+	}
+	d = &decoder{v: v, p: d.p}
+
+	branch, err := d.base58(b58.BlockHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge branch")
+	}
+
+	tag, err := d.byte()
+	if err != nil || d.p.operationKinds[tag] != rpc.ENDORSEMENT {
+		return nil, errors.New("failed to unforge operations kind")
+	}
+
+	operations, err := unforgeEndorsement(d)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge operations")
+	}
+
+	signature, err := d.signature()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge signature")
+	}
+
+	if !d.empty() {
+		return nil, errors.New("unexpected trailing bytes")
+	}
+
+	return &rpc.InlinedEndorsement{
+		Branch: branch,
+		Operations: &rpc.InlinedEndorsementOperations{
+			Kind:             string(rpc.ENDORSEMENT),
+			Slot:             operations.Slot,
+			Level:            operations.Level,
+			Round:            operations.Round,
+			BlockPayloadHash: operations.BlockPayloadHash,
+		},
+		Signature: signature,
+	}, nil
+}
+
+func unforgeDoubleBakingEvidence(d *decoder) (rpc.Content, error) {
+	bh1, err := unforgeBlockHeader(d)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge bh1")
+	}
+
+	bh2, err := unforgeBlockHeader(d)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge bh2")
+	}
+
+	return rpc.Content{
+		Bh1: bh1,
+		Bh2: bh2,
+	}, nil
+}
+
+func unforgeBlockHeader(d *decoder) (*rpc.BlockHeader, error) {
+	v, err := d.array()
+	if err != nil {
+		return nil, err
+	}
+	d = &decoder{v: v, p: d.p}
+
+	var header rpc.BlockHeader
+	if header.Level, err = d.int(4); err != nil {
+		return nil, errors.Wrap(err, "failed to unforge level")
+	}
+
+	if header.Proto, err = d.int(1); err != nil {
+		return nil, errors.Wrap(err, "failed to unforge proto")
+	}
+
+	if header.Predecessor, err = d.base58(b58.BlockHash); err != nil {
+		return nil, errors.Wrap(err, "failed to unforge predecessor")
+	}
+
+	timestamp, err := d.int(8)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge timestamp")
+	}
+	header.Timestamp = time.Unix(int64(timestamp), 0).UTC()
+
+	if header.ValidationPass, err = d.int(1); err != nil {
+		return nil, errors.Wrap(err, "failed to unforge validation_pass")
+	}
+
+	if header.OperationsHash, err = d.base58(b58.OperationListListHash); err != nil {
+		return nil, errors.Wrap(err, "failed to unforge operations_hash")
+	}
+
+	fitness, err := d.array()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge fitness")
+	}
+	for f := (&decoder{v: fitness}); !f.empty(); {
+		v, err := f.array()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unforge fitness")
+		}
+		header.Fitness = append(header.Fitness, hex.EncodeToString(v))
+	}
+
+	if header.Context, err = d.base58(b58.ContextHash); err != nil {
+		return nil, errors.Wrap(err, "failed to unforge context")
+	}
+
+	if d.p.tenderbake {
+		if header.PayloadHash, err = d.base58(b58.BlockPayloadHash); err != nil {
+			return nil, errors.Wrap(err, "failed to unforge payload_hash")
+		}
+
+		if header.PayloadRound, err = d.int(4); err != nil {
+			return nil, errors.Wrap(err, "failed to unforge payload_round")
+		}
+	} else if header.Priority, err = d.int(2); err != nil {
+		return nil, errors.Wrap(err, "failed to unforge priority")
+	}
+
+	nonce, err := d.next(8)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge proof_of_work_nonce")
+	}
+	header.ProofOfWorkNonce = hex.EncodeToString(nonce)
+
+	hasSeedNonceHash, err := d.bool()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge seed_nonce_hash")
+	}
+	if hasSeedNonceHash {
+		if header.SeedNonceHash, err = d.base58(b58.NonceHash); err != nil {
+			return nil, errors.Wrap(err, "failed to unforge seed_nonce_hash")
+		}
+	}
+
+	if d.p.escapeVote {
+		if header.LiquidityBakingEscapeVote, err = d.bool(); err != nil {
+			return nil, errors.Wrap(err, "failed to unforge liquidity_baking_escape_vote")
+		}
+	}
+
+	if d.p.toggleVote || d.p.perBlockVotes {
+		votes, err := d.byte()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unforge liquidity_baking_toggle_vote")
+		}
+
+		if header.LiquidityBakingToggleVote, err = perBlockVote(votes & 3); err != nil {
+			return nil, errors.Wrap(err, "failed to unforge liquidity_baking_toggle_vote")
+		}
+
+		if d.p.perBlockVotes {
+			if header.AdaptiveIssuanceVote, err = perBlockVote(votes >> 2); err != nil {
+				return nil, errors.Wrap(err, "failed to unforge adaptive_issuance_vote")
+			}
+		} else if votes > 2 {
+			return nil, fmt.Errorf("failed to unforge liquidity_baking_toggle_vote: invalid vote tag %d", votes)
+		}
+	}
+
+	if header.Signature, err = d.signature(); err != nil {
+		return nil, errors.Wrap(err, "failed to unforge signature")
+	}
+
+	if !d.empty() {
+		return nil, errors.New("unexpected trailing bytes")
+	}
+
+	return &header, nil
+}
+
+func perBlockVote(tag byte) (string, error) {
+	for vote, t := range perBlockVoteTags {
+		if t == tag {
+			return vote, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid vote tag %d", tag)
+}
+
+func unforgeAccountActivation(d *decoder) (rpc.Content, error) {
+	pkh, err := d.base58(b58.Ed25519PublicKeyHash)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge pkh")
+	}
+
+	secret, err := d.next(20)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge secret")
+	}
+
+	return rpc.Content{
+		Pkh:    pkh,
+		Secret: hex.EncodeToString(secret),
+	}, nil
+}
+
+func unforgeProposals(d *decoder) (rpc.Content, error) {
+	source, err := d.source()
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge source")
+	}
+
+	period, err := d.int(4)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge period")
+	}
+
+	v, err := d.array()
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge proposals")
+	}
+
+	var proposals []string
+	for p := (&decoder{v: v}); !p.empty(); {
+		proposal, err := p.base58(b58.ProtocolHash)
+		if err != nil {
+			return rpc.Content{}, errors.Wrap(err, "failed to unforge proposals")
+		}
+		proposals = append(proposals, proposal)
+	}
+
+	return rpc.Content{
+		Source:    source,
+		Period:    period,
+		Proposals: proposals,
+	}, nil
+}
+
+func unforgeBallot(d *decoder) (rpc.Content, error) {
+	source, err := d.source()
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge source")
+	}
+
+	period, err := d.int(4)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge period")
+	}
+
+	proposal, err := d.base58(b58.ProtocolHash)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge proposal")
+	}
+
+	tag, err := d.byte()
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge ballot")
+	}
+
+	for ballot, t := range ballotTags {
+		if t == tag {
+			return rpc.Content{
+				Source:   source,
+				Period:   period,
+				Proposal: proposal,
+				Ballot:   ballot,
+			}, nil
+		}
+	}
+
+	return rpc.Content{}, fmt.Errorf("failed to unforge ballot: invalid ballot tag %d", tag)
+}
+
+// unforgeManager unforges the fields common to all manager operations.
+func unforgeManager(d *decoder) (rpc.Content, error) {
+	var (
+		content rpc.Content
+		err     error
+	)
+
+	if content.Source, err = d.source(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge source")
+	}
+
+	if content.Fee, err = d.nat(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge fee")
+	}
+
+	if content.Counter, err = d.nat(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge counter")
+	}
+
+	if content.GasLimit, err = d.nat(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge gas_limit")
+	}
+
+	if content.StorageLimit, err = d.nat(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge storage_limit")
+	}
+
+	return content, nil
+}
+
+func unforgeReveal(d *decoder) (rpc.Content, error) {
+	content, err := unforgeManager(d)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	if content.PublicKey, err = d.publicKey(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge public_key")
+	}
+
+	return content, nil
+}
+
+func unforgeTransaction(d *decoder) (rpc.Content, error) {
+	content, err := unforgeManager(d)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	if content.Amount, err = d.nat(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge amount")
+	}
+
+	if content.Destination, err = d.address(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge destination")
+	}
+
+	hasParameters, err := d.bool()
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge parameters")
+	}
+
+	if hasParameters {
+		entrypoint, err := d.entrypoint()
+		if err != nil {
+			return rpc.Content{}, errors.Wrap(err, "failed to unforge entrypoint")
+		}
+
+		value, err := d.micheline()
+		if err != nil {
+			return rpc.Content{}, errors.Wrap(err, "failed to unforge parameters")
+		}
+
+		content.Parameters = &rpc.Parameters{
+			Entrypoint: entrypoint,
+			Value:      value,
+		}
+	}
+
+	return content, nil
+}
+
+func unforgeOrigination(d *decoder) (rpc.Content, error) {
+	content, err := unforgeManager(d)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	if content.Balance, err = d.nat(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge balance")
+	}
+
+	if content.Delegate, err = d.optionalSource(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge delegate")
+	}
+
+	if content.Script.Code, err = d.micheline(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge script code")
+	}
+
+	if content.Script.Storage, err = d.micheline(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge script storage")
+	}
+
+	return content, nil
+}
+
+func unforgeDelegation(d *decoder) (rpc.Content, error) {
+	content, err := unforgeManager(d)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	if content.Delegate, err = d.optionalSource(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge delegate")
+	}
+
+	return content, nil
+}
+
+func unforgeRegisterGlobalConstant(d *decoder) (rpc.Content, error) {
+	content, err := unforgeManager(d)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	if content.Value, err = d.micheline(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge value")
+	}
+
+	return content, nil
+}
+
+func unforgeSetDepositsLimit(d *decoder) (rpc.Content, error) {
+	content, err := unforgeManager(d)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	hasLimit, err := d.bool()
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge limit")
+	}
+
+	if hasLimit {
+		if content.Limit, err = d.nat(); err != nil {
+			return rpc.Content{}, errors.Wrap(err, "failed to unforge limit")
+		}
+	}
+
+	return content, nil
+}
+
+func unforgeIncreasePaidStorage(d *decoder) (rpc.Content, error) {
+	content, err := unforgeManager(d)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	if content.Amount, err = d.zarith(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge amount")
+	}
+
+	if content.Destination, err = d.originatedContract(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge destination")
+	}
+
+	return content, nil
+}
+
+func unforgeUpdateConsensusKey(d *decoder) (rpc.Content, error) {
+	content, err := unforgeManager(d)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	if content.Pk, err = d.publicKey(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge pk")
+	}
+
+	return content, nil
+}
+
+func unforgeDrainDelegate(d *decoder) (rpc.Content, error) {
+	var (
+		content rpc.Content
+		err     error
+	)
+
+	if content.ConsensusKey, err = d.source(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge consensus_key")
+	}
+
+	if content.Delegate, err = d.source(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge delegate")
+	}
+
+	if content.Destination, err = d.source(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge destination")
+	}
+
+	return content, nil
+}
+
+func unforgeTransferTicket(d *decoder) (rpc.Content, error) {
+	content, err := unforgeManager(d)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	if content.TicketContents, err = d.micheline(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge ticket_contents")
+	}
+
+	if content.TicketTy, err = d.micheline(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge ticket_ty")
+	}
+
+	if content.TicketTicketer, err = d.address(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge ticket_ticketer")
+	}
+
+	if content.TicketAmount, err = d.nat(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge ticket_amount")
+	}
+
+	if content.Destination, err = d.address(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge destination")
+	}
+
+	entrypoint, err := d.array()
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge entrypoint")
+	}
+	content.Entrypoint = string(entrypoint)
+
+	return content, nil
+}
+
+func unforgeSmartRollupOriginate(d *decoder) (rpc.Content, error) {
+	content, err := unforgeManager(d)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	tag, err := d.byte()
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge pvm_kind")
+	}
+
+	for pvmKind, t := range pvmKindTags {
+		if t == tag {
+			content.PvmKind = pvmKind
+		}
+	}
+	if content.PvmKind == "" {
+		return rpc.Content{}, fmt.Errorf("invalid pvm kind tag %d", tag)
+	}
+
+	kernel, err := d.array()
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge kernel")
+	}
+	content.Kernel = hex.EncodeToString(kernel)
+
+	if d.p.originationProof {
+		proof, err := d.array()
+		if err != nil {
+			return rpc.Content{}, errors.Wrap(err, "failed to unforge origination_proof")
+		}
+		content.OriginationProof = hex.EncodeToString(proof)
+	}
+
+	if content.ParametersTy, err = d.micheline(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge parameters_ty")
+	}
+
+	if d.p.rollupWhitelist {
+		hasWhitelist, err := d.bool()
+		if err != nil {
+			return rpc.Content{}, errors.Wrap(err, "failed to unforge whitelist")
+		}
+
+		if hasWhitelist {
+			v, err := d.array()
+			if err != nil {
+				return rpc.Content{}, errors.Wrap(err, "failed to unforge whitelist")
+			}
+
+			content.Whitelist = []string{}
+			for w := (&decoder{v: v, p: d.p}); !w.empty(); {
+				pkh, err := w.source()
+				if err != nil {
+					return rpc.Content{}, errors.Wrap(err, "failed to unforge whitelist")
+				}
+				content.Whitelist = append(content.Whitelist, pkh)
+			}
+		}
+	}
+
+	return content, nil
+}
+
+func unforgeSmartRollupAddMessages(d *decoder) (rpc.Content, error) {
+	content, err := unforgeManager(d)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	v, err := d.array()
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge message")
+	}
+
+	content.Message = []string{}
+	for m := (&decoder{v: v, p: d.p}); !m.empty(); {
+		message, err := m.array()
+		if err != nil {
+			return rpc.Content{}, errors.Wrap(err, "failed to unforge message")
+		}
+		content.Message = append(content.Message, hex.EncodeToString(message))
+	}
+
+	return content, nil
+}
+
+func unforgeSmartRollupCement(d *decoder) (rpc.Content, error) {
+	content, err := unforgeManager(d)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	if content.Rollup, err = d.base58(b58.SmartRollupHash); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge rollup")
+	}
+
+	if d.p.cementCommitment {
+		commitment, err := d.base58(b58.SmartRollupCommitmentHash)
+		if err != nil {
+			return rpc.Content{}, errors.Wrap(err, "failed to unforge commitment")
+		}
+		content.Commitment = &rpc.SmartRollupCommitment{Hash: commitment}
+	}
+
+	return content, nil
+}
+
+func unforgeSmartRollupPublish(d *decoder) (rpc.Content, error) {
+	content, err := unforgeManager(d)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	if content.Rollup, err = d.base58(b58.SmartRollupHash); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge rollup")
+	}
+
+	var commitment rpc.SmartRollupCommitment
+	if commitment.CompressedState, err = d.base58(b58.SmartRollupStateHash); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge compressed_state")
+	}
+
+	if commitment.InboxLevel, err = d.int(4); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge inbox_level")
+	}
+
+	if commitment.Predecessor, err = d.base58(b58.SmartRollupCommitmentHash); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge predecessor")
+	}
+
+	ticks, err := d.int(8)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge number_of_ticks")
+	}
+	commitment.NumberOfTicks = strconv.Itoa(ticks)
+	content.Commitment = &commitment
+
+	return content, nil
+}
+
+func unforgeSmartRollupExecuteOutboxMessage(d *decoder) (rpc.Content, error) {
+	content, err := unforgeManager(d)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	if content.Rollup, err = d.base58(b58.SmartRollupHash); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge rollup")
+	}
+
+	if content.CementedCommitment, err = d.base58(b58.SmartRollupCommitmentHash); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge cemented_commitment")
+	}
+
+	proof, err := d.array()
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge output_proof")
+	}
+	content.OutputProof = hex.EncodeToString(proof)
+
+	return content, nil
+}
+
+// decoder reads the binary encodings written by the forge functions.
+type decoder struct {
+	v []byte
+	p *Protocol
+}
+
+func (d *decoder) empty() bool {
+	return len(d.v) == 0
+}
+
+func (d *decoder) next(n int) ([]byte, error) {
+	if n < 0 || len(d.v) < n {
+		return nil, fmt.Errorf("unexpected end of bytes: expected %d bytes, got %d", n, len(d.v))
+	}
+
+	v := d.v[:n]
+	d.v = d.v[n:]
+	return v, nil
+}
+
+func (d *decoder) byte() (byte, error) {
+	v, err := d.next(1)
+	if err != nil {
+		return 0, err
+	}
+
+	return v[0], nil
+}
+
+// int reads a big endian integer of l bytes. 4 and 8 byte integers are signed.
+func (d *decoder) int(l int) (int, error) {
+	v, err := d.next(l)
+	if err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, 8)
+	copy(buf[8-l:], v)
+	u := binary.BigEndian.Uint64(buf)
+
+	switch l {
+	case 4:
+		return int(int32(u)), nil
+	case 8:
+		return int(int64(u)), nil
+	}
+
+	return int(u), nil
+}
+
+func (d *decoder) bool() (bool, error) {
+	v, err := d.byte()
+	if err != nil {
+		return false, err
+	}
+
+	switch v {
+	case 0:
+		return false, nil
+	case 255:
+		return true, nil
+	}
+
+	return false, fmt.Errorf("invalid bool %d", v)
+}
+
+// array reads bytes prefixed by their 4 byte length.
+func (d *decoder) array() ([]byte, error) {
+	l, err := d.next(4)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.next(int(binary.BigEndian.Uint32(l)))
+}
+
+// nat reads an unsigned zarith number.
+func (d *decoder) nat() (string, error) {
+	n := new(big.Int)
+	for shift := uint(0); ; shift += 7 {
+		b, err := d.byte()
+		if err != nil {
+			return "", err
+		}
+
+		n.Or(n, new(big.Int).Lsh(big.NewInt(int64(b&0x7f)), shift))
+		if b&0x80 == 0 {
+			if b == 0 && shift > 0 {
+				return "", errors.New("invalid zarith: trailing zero")
+			}
+			return n.String(), nil
+		}
+	}
+}
+
+func (d *decoder) base58(prefix b58.Prefix) (string, error) {
+	v, err := d.next(prefix.Length)
+	if err != nil {
+		return "", err
+	}
+
+	return b58.Encode(prefix, v)
+}
+
+func (d *decoder) signature() (string, error) {
+	return d.base58(b58.GenericSignature)
+}
+
+// source reads a 21 byte public key hash.
+func (d *decoder) source() (string, error) {
+	v, err := d.next(1 + address.HashLength)
+	if err != nil {
+		return "", err
+	}
+
+	addr, err := address.FromPublicKeyHashBytes(v)
+	if err != nil {
+		return "", err
+	}
+
+	return addr.String(), nil
+}
+
+func (d *decoder) optionalSource() (string, error) {
+	ok, err := d.bool()
+	if err != nil || !ok {
+		return "", err
+	}
+
+	return d.source()
+}
+
+// address reads a 22 byte contract id.
+func (d *decoder) address() (string, error) {
+	v, err := d.next(2 + address.HashLength)
+	if err != nil {
+		return "", err
+	}
+
+	addr, err := address.FromContractBytes(v)
+	if err != nil {
+		return "", err
+	}
+
+	return addr.String(), nil
+}
+
+// originatedContract reads the 21 byte encoding of a KT1 address.
+func (d *decoder) originatedContract() (string, error) {
+	v, err := d.next(address.HashLength + 1)
+	if err != nil {
+		return "", err
+	}
+
+	if v[address.HashLength] != 0 {
+		return "", fmt.Errorf("invalid contract padding %d", v[address.HashLength])
+	}
+
+	addr, err := address.New(address.KT1, v[:address.HashLength])
+	if err != nil {
+		return "", err
+	}
+
+	return addr.String(), nil
+}
+
+func (d *decoder) publicKey() (string, error) {
+	tag, err := d.byte()
+	if err != nil {
+		return "", err
+	}
+
+	prefixes := []b58.Prefix{b58.Ed25519PublicKey, b58.Secp256k1PublicKey, b58.P256PublicKey, b58.BLS12_381PublicKey}
+	if int(tag) >= len(prefixes) {
+		return "", fmt.Errorf("invalid public key tag %d", tag)
+	}
+
+	return d.base58(prefixes[tag])
+}
+
+func (d *decoder) entrypoint() (string, error) {
+	tag, err := d.byte()
+	if err != nil {
+		return "", err
+	}
+
+	if tag == 255 {
+		l, err := d.byte()
+		if err != nil {
+			return "", err
+		}
+
+		v, err := d.next(int(l))
+		if err != nil {
+			return "", err
+		}
+
+		return string(v), nil
+	}
+
+	for entrypoint, t := range entrypointTags {
+		if t == tag {
+			return entrypoint, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid entrypoint tag %d", tag)
+}
+
+// micheline reads a Micheline expression prefixed by its 4 byte length.
+func (d *decoder) micheline() (*json.RawMessage, error) {
+	v, err := d.array()
+	if err != nil {
+		return nil, err
+	}
+
+	return unforgeMicheline(v, d.p)
+}
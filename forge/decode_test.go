@@ -0,0 +1,224 @@
+package forge
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/goat-systems/go-tezos/v3/b58"
+	"github.com/goat-systems/go-tezos/v3/internal/testutils"
+	"github.com/goat-systems/go-tezos/v3/keys"
+	"github.com/goat-systems/go-tezos/v3/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Decode(t *testing.T) {
+	branch := "BLyvCRkxuTXkx1KeGvrcEXiPYj4p1tFxzvFDhoHE7SFKtmP1rbk"
+	protocol := "PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA"
+	signature := b58.MustEncode(b58.GenericSignature, make([]byte, 64))
+
+	code := json.RawMessage(`[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"int","annots":["%counter"]}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"PUSH","args":[{"prim":"int"},{"int":"-300"}]},{"prim":"ADD"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`)
+	storage := json.RawMessage(`{"int":"42"}`)
+	value := json.RawMessage(`{"prim":"Pair","args":[{"string":"tz1"},{"bytes":"00ff"},{"prim":"Unit"}]}`)
+
+	inlined := func(level int) *rpc.InlinedEndorsement {
+		return &rpc.InlinedEndorsement{
+			Branch:     branch,
+			Operations: &rpc.InlinedEndorsementOperations{Kind: "endorsement", Level: level},
+			Signature:  signature,
+		}
+	}
+
+	header := func(priority int) *rpc.BlockHeader {
+		return &rpc.BlockHeader{
+			Level:            1000,
+			Proto:            1,
+			Predecessor:      branch,
+			Timestamp:        time.Unix(1600000000, 0).UTC(),
+			ValidationPass:   4,
+			OperationsHash:   b58.MustEncode(b58.OperationListListHash, make([]byte, 32)),
+			Fitness:          []string{"01", "000000000000a0b1"},
+			Context:          b58.MustEncode(b58.ContextHash, make([]byte, 32)),
+			Priority:         priority,
+			ProofOfWorkNonce: "0102030405060708",
+			Signature:        signature,
+		}
+	}
+
+	type want struct {
+		wantErr     bool
+		containsErr string
+		contents    rpc.Contents
+	}
+
+	cases := []struct {
+		name     string
+		contents rpc.Contents
+		want     want
+	}{
+		{
+			"is successful with endorsement",
+			rpc.Contents{{Kind: rpc.ENDORSEMENT, Level: 1234}},
+			want{false, "", rpc.Contents{{Kind: rpc.ENDORSEMENT, Level: 1234}}},
+		},
+		{
+			"is successful with seed nonce revelation",
+			rpc.Contents{{Kind: rpc.SEEDNONCEREVELATION, Level: 64, Nonce: "6cd8d4bc6dd3d3f8e0b55bb0b5d3ab52bc6a1a1a1d71f7a8a3e5c4f4c4a9d8e7"}},
+			want{false, "", rpc.Contents{{Kind: rpc.SEEDNONCEREVELATION, Level: 64, Nonce: "6cd8d4bc6dd3d3f8e0b55bb0b5d3ab52bc6a1a1a1d71f7a8a3e5c4f4c4a9d8e7"}}},
+		},
+		{
+			"is successful with double endorsement evidence",
+			rpc.Contents{{Kind: rpc.DOUBLEENDORSEMENTEVIDENCE, Op1: inlined(10), Op2: inlined(11)}},
+			want{false, "", rpc.Contents{{Kind: rpc.DOUBLEENDORSEMENTEVIDENCE, Op1: inlined(10), Op2: inlined(11)}}},
+		},
+		{
+			"is successful with double baking evidence",
+			rpc.Contents{{Kind: rpc.DOUBLEBAKINGEVIDENCE, Bh1: header(0), Bh2: header(1)}},
+			want{false, "", rpc.Contents{{Kind: rpc.DOUBLEBAKINGEVIDENCE, Bh1: header(0), Bh2: header(1)}}},
+		},
+		{
+			"is successful with activate account",
+			rpc.Contents{{Kind: rpc.ACTIVATEACCOUNT, Pkh: "tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e", Secret: "41f98b15efc63fa893d61d7d6eee4a2ce9427ac4"}},
+			want{false, "", rpc.Contents{{Kind: rpc.ACTIVATEACCOUNT, Pkh: "tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e", Secret: "41f98b15efc63fa893d61d7d6eee4a2ce9427ac4"}}},
+		},
+		{
+			"is successful with proposals",
+			rpc.Contents{{Kind: rpc.PROPOSALS, Source: "tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e", Period: 25, Proposals: []string{protocol, protocol}}},
+			want{false, "", rpc.Contents{{Kind: rpc.PROPOSALS, Source: "tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e", Period: 25, Proposals: []string{protocol, protocol}}}},
+		},
+		{
+			"is successful with ballot",
+			rpc.Contents{{Kind: rpc.BALLOT, Source: "tz2Ch1abG7FNiibmV26Uzgdsnfni9XGrk5wD", Period: 25, Proposal: protocol, Ballot: "nay"}},
+			want{false, "", rpc.Contents{{Kind: rpc.BALLOT, Source: "tz2Ch1abG7FNiibmV26Uzgdsnfni9XGrk5wD", Period: 25, Proposal: protocol, Ballot: "nay"}}},
+		},
+		{
+			"is successful with manager operations",
+			rpc.Contents{
+				{Kind: rpc.REVEAL, Source: "tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e", Fee: "1257", Counter: "1", GasLimit: "10000", StorageLimit: "0", PublicKey: "edpkvEoAbkdaGALxi2FfeefB8hUkMZ4J1UVwkzyumx2GvbVpkYUHnm"},
+				{Kind: rpc.TRANSACTION, Source: "tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e", Fee: "1283", Counter: "2", GasLimit: "10307", StorageLimit: "0", Amount: "20000000000", Destination: "KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9", Parameters: &rpc.Parameters{Entrypoint: "transfer", Value: &value}},
+				{Kind: rpc.ORIGINATION, Source: "tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e", Fee: "1500", Counter: "3", GasLimit: "12000", StorageLimit: "500", Balance: "0", Delegate: "tz3Lfm6CyfSTZ7EgMckptZZGiPxzs9GK59At", Script: rpc.Script{Code: &code, Storage: &storage}},
+				{Kind: rpc.DELEGATION, Source: "tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e", Fee: "1200", Counter: "4", GasLimit: "10000", StorageLimit: "0"},
+			},
+			want{false, "", nil},
+		},
+	}
+
+	p := MustProtocol(carthage)
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			operation, err := p.Encode(branch, tt.contents...)
+			testutils.CheckErr(t, false, "", err)
+
+			decodedBranch, contents, err := p.Decode(operation)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			assert.Equal(t, branch, decodedBranch)
+			if tt.want.contents != nil {
+				assert.Equal(t, tt.want.contents, contents)
+			}
+
+			reencoded, err := p.Encode(decodedBranch, contents...)
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, operation, reencoded)
+		})
+	}
+}
+
+func Test_Decode_Signed(t *testing.T) {
+	key, err := keys.NewKey(keys.NewKeyInput{
+		EncodedString: "edskRxB2DmoyZSyvhsqaJmw5CK6zYT7dbkUfEVSiQeWU1gw3ZMnC99QMMXru3imsbUrLhvuHktrymvNqhMxkhz7Y4LJAtevW5V",
+		Kind:          keys.Ed25519,
+	})
+	testutils.CheckErr(t, false, "", err)
+
+	transaction := rpc.Transaction{
+		Kind:         rpc.TRANSACTION,
+		Source:       key.PubKey.GetPublicKeyHash(),
+		Fee:          "1283",
+		Counter:      "7",
+		GasLimit:     "10307",
+		StorageLimit: "0",
+		Amount:       "20000000000",
+		Destination:  "tz1aWXP237BLwNHJcCD4b3DutCevhqq2T1Z9",
+	}
+
+	signed, err := EncodeAndSign(&key, "BLyvCRkxuTXkx1KeGvrcEXiPYj4p1tFxzvFDhoHE7SFKtmP1rbk", transaction.ToContent())
+	testutils.CheckErr(t, false, "", err)
+
+	branch, contents, signature, err := DecodeSigned(signed)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, "BLyvCRkxuTXkx1KeGvrcEXiPYj4p1tFxzvFDhoHE7SFKtmP1rbk", branch)
+	assert.Equal(t, rpc.Contents{transaction.ToContent()}, contents)
+
+	sig, err := keys.ParseSignature(signature)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, signed[len(signed)-128:], sig.ToHex())
+
+	t.Run("handles a signature that is also valid contents", func(t *testing.T) {
+		p := MustProtocol(carthage)
+		endorsement := rpc.Content{Kind: rpc.ENDORSEMENT, Level: 1234}
+		operation, err := p.Encode("BLyvCRkxuTXkx1KeGvrcEXiPYj4p1tFxzvFDhoHE7SFKtmP1rbk", endorsement)
+		testutils.CheckErr(t, false, "", err)
+
+		// a ballot and an endorsement forge to 64 bytes, the length of the signature
+		signature, err := p.Encode("", rpc.Content{Kind: rpc.BALLOT, Source: "tz2Ch1abG7FNiibmV26Uzgdsnfni9XGrk5wD", Period: 25, Proposal: "PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA", Ballot: "yay"}, endorsement)
+		testutils.CheckErr(t, false, "", err)
+		assert.Len(t, signature, 128)
+
+		_, contents, sig, err := p.DecodeSigned(operation + signature)
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, rpc.Contents{endorsement}, contents)
+		assert.Equal(t, b58.MustEncode(b58.GenericSignature, mustDecodeHex(signature)), sig)
+	})
+
+	t.Run("is successful with a tz4 source", func(t *testing.T) {
+		transaction.Source = "tz4HVR6aty9KwsQFHh81C1G7gBdhxT8kuytm"
+		operation, err := Encode("BLyvCRkxuTXkx1KeGvrcEXiPYj4p1tFxzvFDhoHE7SFKtmP1rbk", transaction.ToContent())
+		testutils.CheckErr(t, false, "", err)
+
+		signature := make([]byte, 96)
+		signature[0] = 0xaa
+		_, contents, sig, err := DecodeSigned(operation + hex.EncodeToString(signature))
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, rpc.Contents{transaction.ToContent()}, contents)
+		assert.Equal(t, b58.MustEncode(b58.BLS12_381Signature, signature), sig)
+	})
+
+	t.Run("handles missing signature", func(t *testing.T) {
+		operation, err := Encode("BLyvCRkxuTXkx1KeGvrcEXiPYj4p1tFxzvFDhoHE7SFKtmP1rbk", transaction.ToContent())
+		testutils.CheckErr(t, false, "", err)
+
+		_, _, _, err = DecodeSigned(operation)
+		testutils.CheckErr(t, true, "failed to unforge operation", err)
+	})
+}
+
+func mustDecodeHex(v string) []byte {
+	b, err := hex.DecodeString(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func Test_Decode_Errors(t *testing.T) {
+	cases := []struct {
+		name        string
+		input       string
+		containsErr string
+	}{
+		{"handles invalid hex", "zz", "failed to unforge operation"},
+		{"handles missing branch", "00", "missing branch"},
+		{"handles missing contents", "a3a2eb9e7d3b1e8dd3b1e2e3c1b8b9e2f1d6e3c1b8b9e2f1d6e3c1b8b9e2f1d6", "operation has no contents"},
+		{"handles unknown tag", "a3a2eb9e7d3b1e8dd3b1e2e3c1b8b9e2f1d6e3c1b8b9e2f1d6e3c1b8b9e2f1d6ee", "unsupported operation tag 238"},
+		{"handles truncated contents", "a3a2eb9e7d3b1e8dd3b1e2e3c1b8b9e2f1d6e3c1b8b9e2f1d6e3c1b8b9e2f1d61500000001", "failed to unforge attestation"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Decode(tt.input)
+			testutils.CheckErr(t, true, tt.containsErr, err)
+		})
+	}
+}
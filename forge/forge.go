@@ -6,174 +6,223 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"math"
 	"math/big"
 	"strconv"
 	"strings"
-	"time"
 
-	"github.com/btcsuite/btcutil/base58"
 	validator "github.com/go-playground/validator/v10"
-	"github.com/goat-systems/go-tezos/v3/internal/crypto"
+	"github.com/goat-systems/go-tezos/v3/address"
+	"github.com/goat-systems/go-tezos/v3/b58"
+	"github.com/goat-systems/go-tezos/v3/keys"
 	"github.com/goat-systems/go-tezos/v3/rpc"
 	"github.com/pkg/errors"
 	"github.com/valyala/fastjson"
 )
 
-var (
-	branchPrefix    []byte = []byte{1, 52}
-	proposalPrefix  []byte = []byte{2, 170}
-	sigPrefix       []byte = []byte{4, 130, 43}
-	operationPrefix []byte = []byte{29, 159, 109}
-	contextPrefix   []byte = []byte{79, 179}
-	// scriptExpressionPrefix []byte = []byte{13, 44, 64, 27}
-)
+var ballotTags = map[string]byte{
+	"yay":  0,
+	"nay":  1,
+	"pass": 2,
+}
 
-func operationTags(kind string) string {
-	tags := map[string]string{
-		"endorsement":                 "0",
-		"proposals":                   "5",
-		"ballot":                      "6",
-		"seed_nonce_revelation":       "1",
-		"double_endorsement_evidence": "2",
-		"double_baking_evidence":      "3",
-		"activate_account":            "4",
-		"reveal":                      "107",
-		"transaction":                 "108",
-		"origination":                 "109",
-		"delegation":                  "110",
-	}
-
-	return tags[kind]
+var perBlockVoteTags = map[string]byte{
+	"on":   0,
+	"off":  1,
+	"pass": 2,
 }
 
-func primTags(prim string) byte {
-	tags := map[string]byte{
-		"parameter":        0x00,
-		"storage":          0x01,
-		"code":             0x02,
-		"False":            0x03,
-		"Elt":              0x04,
-		"Left":             0x05,
-		"None":             0x06,
-		"Pair":             0x07,
-		"Right":            0x08,
-		"Some":             0x09,
-		"True":             0x0A,
-		"Unit":             0x0B,
-		"PACK":             0x0C,
-		"UNPACK":           0x0D,
-		"BLAKE2B":          0x0E,
-		"SHA256":           0x0F,
-		"SHA512":           0x10,
-		"ABS":              0x11,
-		"ADD":              0x12,
-		"AMOUNT":           0x13,
-		"AND":              0x14,
-		"BALANCE":          0x15,
-		"CAR":              0x16,
-		"CDR":              0x17,
-		"CHECK_SIGNATURE":  0x18,
-		"COMPARE":          0x19,
-		"CONCAT":           0x1A,
-		"CONS":             0x1B,
-		"CREATE_ACCOUNT":   0x1C,
-		"CREATE_CONTRACT":  0x1D,
-		"IMPLICIT_ACCOUNT": 0x1E,
-		"DIP":              0x1F,
-		"DROP":             0x20,
-		"DUP":              0x21,
-		"EDIV":             0x22,
-		"EMPTY_MAP":        0x23,
-		"EMPTY_SET":        0x24,
-		"EQ":               0x25,
-		"EXEC":             0x26,
-		"FAILWITH":         0x27,
-		"GE":               0x28,
-		"GET":              0x29,
-		"GT":               0x2A,
-		"HASH_KEY":         0x2B,
-		"IF":               0x2C,
-		"IF_CONS":          0x2D,
-		"IF_LEFT":          0x2E,
-		"IF_NONE":          0x2F,
-		"INT":              0x30,
-		"LAMBDA":           0x31,
-		"LE":               0x32,
-		"LEFT":             0x33,
-		"LOOP":             0x34,
-		"LSL":              0x35,
-		"LSR":              0x36,
-		"LT":               0x37,
-		"MAP":              0x38,
-		"MEM":              0x39,
-		"MUL":              0x3A,
-		"NEG":              0x3B,
-		"NEQ":              0x3C,
-		"NIL":              0x3D,
-		"NONE":             0x3E,
-		"NOT":              0x3F,
-		"NOW":              0x40,
-		"OR":               0x41,
-		"PAIR":             0x42,
-		"PUSH":             0x43,
-		"RIGHT":            0x44,
-		"SIZE":             0x45,
-		"SOME":             0x46,
-		"SOURCE":           0x47,
-		"SENDER":           0x48,
-		"SELF":             0x49,
-		"STEPS_TO_QUOTA":   0x4A,
-		"SUB":              0x4B,
-		"SWAP":             0x4C,
-		"TRANSFER_TOKENS":  0x4D,
-		"SET_DELEGATE":     0x4E,
-		"UNIT":             0x4F,
-		"UPDATE":           0x50,
-		"XOR":              0x51,
-		"ITER":             0x52,
-		"LOOP_LEFT":        0x53,
-		"ADDRESS":          0x54,
-		"CONTRACT":         0x55,
-		"ISNAT":            0x56,
-		"CAST":             0x57,
-		"RENAME":           0x58,
-		"bool":             0x59,
-		"contract":         0x5A,
-		"int":              0x5B,
-		"key":              0x5C,
-		"key_hash":         0x5D,
-		"lambda":           0x5E,
-		"list":             0x5F,
-		"map":              0x60,
-		"big_map":          0x61,
-		"nat":              0x62,
-		"option":           0x63,
-		"or":               0x64,
-		"pair":             0x65,
-		"set":              0x66,
-		"signature":        0x67,
-		"string":           0x68,
-		"bytes":            0x69,
-		"mutez":            0x6A,
-		"timestamp":        0x6B,
-		"unit":             0x6C,
-		"operation":        0x6D,
-		"address":          0x6E,
-		"SLICE":            0x6F,
-		"DIG":              0x70,
-		"DUG":              0x71,
-		"EMPTY_BIG_MAP":    0x72,
-		"APPLY":            0x73,
-		"chain_id":         0x74,
-		"CHAIN_ID":         0x75,
-	}
-
-	return tags[prim]
+var pvmKindTags = map[string]byte{
+	"arith":      0,
+	"wasm_2_0_0": 1,
+	"riscv":      2,
+}
+
+var entrypointTags = map[string]byte{
+	"default":         0,
+	"root":            1,
+	"do":              2,
+	"set_delegate":    3,
+	"remove_delegate": 4,
+}
+
+var primitiveTags = map[string]byte{
+	"parameter":                      0x00,
+	"storage":                        0x01,
+	"code":                           0x02,
+	"False":                          0x03,
+	"Elt":                            0x04,
+	"Left":                           0x05,
+	"None":                           0x06,
+	"Pair":                           0x07,
+	"Right":                          0x08,
+	"Some":                           0x09,
+	"True":                           0x0A,
+	"Unit":                           0x0B,
+	"PACK":                           0x0C,
+	"UNPACK":                         0x0D,
+	"BLAKE2B":                        0x0E,
+	"SHA256":                         0x0F,
+	"SHA512":                         0x10,
+	"ABS":                            0x11,
+	"ADD":                            0x12,
+	"AMOUNT":                         0x13,
+	"AND":                            0x14,
+	"BALANCE":                        0x15,
+	"CAR":                            0x16,
+	"CDR":                            0x17,
+	"CHECK_SIGNATURE":                0x18,
+	"COMPARE":                        0x19,
+	"CONCAT":                         0x1A,
+	"CONS":                           0x1B,
+	"CREATE_ACCOUNT":                 0x1C,
+	"CREATE_CONTRACT":                0x1D,
+	"IMPLICIT_ACCOUNT":               0x1E,
+	"DIP":                            0x1F,
+	"DROP":                           0x20,
+	"DUP":                            0x21,
+	"EDIV":                           0x22,
+	"EMPTY_MAP":                      0x23,
+	"EMPTY_SET":                      0x24,
+	"EQ":                             0x25,
+	"EXEC":                           0x26,
+	"FAILWITH":                       0x27,
+	"GE":                             0x28,
+	"GET":                            0x29,
+	"GT":                             0x2A,
+	"HASH_KEY":                       0x2B,
+	"IF":                             0x2C,
+	"IF_CONS":                        0x2D,
+	"IF_LEFT":                        0x2E,
+	"IF_NONE":                        0x2F,
+	"INT":                            0x30,
+	"LAMBDA":                         0x31,
+	"LE":                             0x32,
+	"LEFT":                           0x33,
+	"LOOP":                           0x34,
+	"LSL":                            0x35,
+	"LSR":                            0x36,
+	"LT":                             0x37,
+	"MAP":                            0x38,
+	"MEM":                            0x39,
+	"MUL":                            0x3A,
+	"NEG":                            0x3B,
+	"NEQ":                            0x3C,
+	"NIL":                            0x3D,
+	"NONE":                           0x3E,
+	"NOT":                            0x3F,
+	"NOW":                            0x40,
+	"OR":                             0x41,
+	"PAIR":                           0x42,
+	"PUSH":                           0x43,
+	"RIGHT":                          0x44,
+	"SIZE":                           0x45,
+	"SOME":                           0x46,
+	"SOURCE":                         0x47,
+	"SENDER":                         0x48,
+	"SELF":                           0x49,
+	"STEPS_TO_QUOTA":                 0x4A,
+	"SUB":                            0x4B,
+	"SWAP":                           0x4C,
+	"TRANSFER_TOKENS":                0x4D,
+	"SET_DELEGATE":                   0x4E,
+	"UNIT":                           0x4F,
+	"UPDATE":                         0x50,
+	"XOR":                            0x51,
+	"ITER":                           0x52,
+	"LOOP_LEFT":                      0x53,
+	"ADDRESS":                        0x54,
+	"CONTRACT":                       0x55,
+	"ISNAT":                          0x56,
+	"CAST":                           0x57,
+	"RENAME":                         0x58,
+	"bool":                           0x59,
+	"contract":                       0x5A,
+	"int":                            0x5B,
+	"key":                            0x5C,
+	"key_hash":                       0x5D,
+	"lambda":                         0x5E,
+	"list":                           0x5F,
+	"map":                            0x60,
+	"big_map":                        0x61,
+	"nat":                            0x62,
+	"option":                         0x63,
+	"or":                             0x64,
+	"pair":                           0x65,
+	"set":                            0x66,
+	"signature":                      0x67,
+	"string":                         0x68,
+	"bytes":                          0x69,
+	"mutez":                          0x6A,
+	"timestamp":                      0x6B,
+	"unit":                           0x6C,
+	"operation":                      0x6D,
+	"address":                        0x6E,
+	"SLICE":                          0x6F,
+	"DIG":                            0x70,
+	"DUG":                            0x71,
+	"EMPTY_BIG_MAP":                  0x72,
+	"APPLY":                          0x73,
+	"chain_id":                       0x74,
+	"CHAIN_ID":                       0x75,
+	"LEVEL":                          0x76,
+	"SELF_ADDRESS":                   0x77,
+	"never":                          0x78,
+	"NEVER":                          0x79,
+	"UNPAIR":                         0x7A,
+	"VOTING_POWER":                   0x7B,
+	"TOTAL_VOTING_POWER":             0x7C,
+	"KECCAK":                         0x7D,
+	"SHA3":                           0x7E,
+	"PAIRING_CHECK":                  0x7F,
+	"bls12_381_g1":                   0x80,
+	"bls12_381_g2":                   0x81,
+	"bls12_381_fr":                   0x82,
+	"sapling_state":                  0x83,
+	"sapling_transaction_deprecated": 0x84,
+	"SAPLING_EMPTY_STATE":            0x85,
+	"SAPLING_VERIFY_UPDATE":          0x86,
+	"ticket":                         0x87,
+	"TICKET_DEPRECATED":              0x88,
+	"READ_TICKET":                    0x89,
+	"SPLIT_TICKET":                   0x8A,
+	"JOIN_TICKETS":                   0x8B,
+	"GET_AND_UPDATE":                 0x8C,
+	"chest":                          0x8D,
+	"chest_key":                      0x8E,
+	"OPEN_CHEST":                     0x8F,
+	"VIEW":                           0x90,
+	"view":                           0x91,
+	"constant":                       0x92,
+	"SUB_MUTEZ":                      0x93,
+	"tx_rollup_l2_address":           0x94,
+	"MIN_BLOCK_TIME":                 0x95,
+	"sapling_transaction":            0x96,
+	"EMIT":                           0x97,
+	"Lambda_rec":                     0x98,
+	"LAMBDA_REC":                     0x99,
+	"TICKET":                         0x9A,
+	"BYTES":                          0x9B,
+	"NAT":                            0x9C,
+	"Ticket":                         0x9D,
+}
+
+// michelineTag returns the node tag of a prim with the number of args and annotations passed.
+func michelineTag(args int, annots bool) byte {
+	if args >= 3 {
+		return 0x09
+	}
+
+	tag := 0x03 + byte(args)*2
+	if annots {
+		tag++
+	}
+
+	return tag
 }
 
 /*
 Encode forges an operation locally. GoTezos does not use the RPC or a trusted source to forge operations.
+Operations are forged for DefaultProtocol, use Protocol.Encode to forge for another protocol.
 All operations are supported:
 	- Endorsement
 	- Proposals
@@ -186,6 +235,17 @@ All operations are supported:
 	- Transaction
 	- Origination
 	- Delegation
+	- RegisterGlobalConstant
+	- SetDepositsLimit
+	- IncreasePaidStorage
+	- UpdateConsensusKey
+	- DrainDelegate
+	- TransferTicket
+	- SmartRollupOriginate
+	- SmartRollupAddMessages
+	- SmartRollupCement
+	- SmartRollupPublish
+	- SmartRollupExecuteOutboxMessage
 
 
 Parameters:
@@ -197,82 +257,182 @@ Parameters:
 		The operation contents to be formed.
 */
 func Encode(branch string, contents ...rpc.Content) (string, error) {
+	return DefaultProtocol().Encode(branch, contents...)
+}
+
+/*
+Encode forges an operation locally for the protocol. Kinds that don't exist in the protocol, or Micheline
+primitives it doesn't know, are rejected.
+
+Parameters:
+
+	branch:
+		The branch to forge the operation on.
+
+	contents:
+		The operation contents to be formed.
+*/
+func (p *Protocol) Encode(branch string, contents ...rpc.Content) (string, error) {
 	var buf *bytes.Buffer
 	if branch == "" {
 		buf = bytes.NewBuffer([]byte{})
 	} else {
-		branch, err := crypto.Decode(branch)
+		branch, err := b58.DecodeAs(branch, b58.BlockHash)
 		if err != nil {
 			return "", errors.Wrap(err, "failed to forge operation")
 		}
 
-		buf = bytes.NewBuffer(bytes.TrimPrefix(branch, branchPrefix))
+		buf = bytes.NewBuffer(branch)
 	}
 
 	for _, c := range contents {
 		switch c.Kind {
 		case rpc.ENDORSEMENT:
-			v, err := forgeEndorsement(c.ToEndorsement())
+			v, err := p.forgeEndorsement(c.ToEndorsement())
+			if err != nil {
+				return "", errors.Wrap(err, "failed to forge operation")
+			}
+			buf.Write(v)
+		case rpc.PREENDORSEMENT:
+			v, err := p.forgePreendorsement(c.ToPreendorsement())
+			if err != nil {
+				return "", errors.Wrap(err, "failed to forge operation")
+			}
+			buf.Write(v)
+		case rpc.ATTESTATION:
+			v, err := p.forgeAttestation(c.ToAttestation())
+			if err != nil {
+				return "", errors.Wrap(err, "failed to forge operation")
+			}
+			buf.Write(v)
+		case rpc.PREATTESTATION:
+			v, err := p.forgePreattestation(c.ToPreattestation())
 			if err != nil {
 				return "", errors.Wrap(err, "failed to forge operation")
 			}
 			buf.Write(v)
 		case rpc.PROPOSALS:
-			v, err := forgeProposal(c.ToProposal())
+			v, err := p.forgeProposal(c.ToProposal())
 			if err != nil {
 				return "", errors.Wrap(err, "failed to forge operation")
 			}
 			buf.Write(v)
 		case rpc.BALLOT:
-			v, err := forgeBallot(c.ToBallot())
+			v, err := p.forgeBallot(c.ToBallot())
 			if err != nil {
 				return "", errors.Wrap(err, "failed to forge operation")
 			}
 			buf.Write(v)
 		case rpc.SEEDNONCEREVELATION:
-			v, err := forgeSeedNonceRevelation(c.ToSeedNonceRevelations())
+			v, err := p.forgeSeedNonceRevelation(c.ToSeedNonceRevelations())
 			if err != nil {
 				return "", errors.Wrap(err, "failed to forge operation")
 			}
 			buf.Write(v)
 		case rpc.DOUBLEENDORSEMENTEVIDENCE:
-			v, err := forgeDoubleEndorsementEvidence(c.ToDoubleEndorsementEvidence())
+			v, err := p.forgeDoubleEndorsementEvidence(c.ToDoubleEndorsementEvidence())
 			if err != nil {
 				return "", errors.Wrap(err, "failed to forge operation")
 			}
 			buf.Write(v)
 		case rpc.DOUBLEBAKINGEVIDENCE:
-			v, err := forgeDoubleBakingEvidence(c.ToDoubleBakingEvidence())
+			v, err := p.forgeDoubleBakingEvidence(c.ToDoubleBakingEvidence())
 			if err != nil {
 				return "", errors.Wrap(err, "failed to forge operation")
 			}
 			buf.Write(v)
 		case rpc.ACTIVATEACCOUNT:
-			v, err := forgeAccountActivation(c.ToAccountActivation())
+			v, err := p.forgeAccountActivation(c.ToAccountActivation())
 			if err != nil {
 				return "", errors.Wrap(err, "failed to forge operation")
 			}
 			buf.Write(v)
 		case rpc.REVEAL:
-			v, err := forgeReveal(c.ToReveal())
+			v, err := p.forgeReveal(c.ToReveal())
 			if err != nil {
 				return "", errors.Wrap(err, "failed to forge operation")
 			}
 			buf.Write(v)
 		case rpc.TRANSACTION:
-			v, err := forgeTransaction(c.ToTransaction())
+			v, err := p.forgeTransaction(c.ToTransaction())
 			if err != nil {
 				return "", errors.Wrap(err, "failed to forge operation")
 			}
 			buf.Write(v)
 		case rpc.ORIGINATION:
-			v, err := forgeOrigination(c.ToOrigination())
+			v, err := p.forgeOrigination(c.ToOrigination())
 			if err != nil {
 				return "", errors.Wrap(err, "failed to forge operation")
 			}
 			buf.Write(v)
 		case rpc.DELEGATION:
-			v, err := forgeDelegation(c.ToDelegation())
+			v, err := p.forgeDelegation(c.ToDelegation())
+			if err != nil {
+				return "", errors.Wrap(err, "failed to forge operation")
+			}
+			buf.Write(v)
+		case rpc.REGISTERGLOBALCONSTANT:
+			v, err := p.forgeRegisterGlobalConstant(c.ToRegisterGlobalConstant())
+			if err != nil {
+				return "", errors.Wrap(err, "failed to forge operation")
+			}
+			buf.Write(v)
+		case rpc.SETDEPOSITSLIMIT:
+			v, err := p.forgeSetDepositsLimit(c.ToSetDepositsLimit())
+			if err != nil {
+				return "", errors.Wrap(err, "failed to forge operation")
+			}
+			buf.Write(v)
+		case rpc.INCREASEPAIDSTORAGE:
+			v, err := p.forgeIncreasePaidStorage(c.ToIncreasePaidStorage())
+			if err != nil {
+				return "", errors.Wrap(err, "failed to forge operation")
+			}
+			buf.Write(v)
+		case rpc.UPDATECONSENSUSKEY:
+			v, err := p.forgeUpdateConsensusKey(c.ToUpdateConsensusKey())
+			if err != nil {
+				return "", errors.Wrap(err, "failed to forge operation")
+			}
+			buf.Write(v)
+		case rpc.DRAINDELEGATE:
+			v, err := p.forgeDrainDelegate(c.ToDrainDelegate())
+			if err != nil {
+				return "", errors.Wrap(err, "failed to forge operation")
+			}
+			buf.Write(v)
+		case rpc.TRANSFERTICKET:
+			v, err := p.forgeTransferTicket(c.ToTransferTicket())
+			if err != nil {
+				return "", errors.Wrap(err, "failed to forge operation")
+			}
+			buf.Write(v)
+		case rpc.SMARTROLLUPORIGINATE:
+			v, err := p.forgeSmartRollupOriginate(c.ToSmartRollupOriginate())
+			if err != nil {
+				return "", errors.Wrap(err, "failed to forge operation")
+			}
+			buf.Write(v)
+		case rpc.SMARTROLLUPADDMESSAGES:
+			v, err := p.forgeSmartRollupAddMessages(c.ToSmartRollupAddMessages())
+			if err != nil {
+				return "", errors.Wrap(err, "failed to forge operation")
+			}
+			buf.Write(v)
+		case rpc.SMARTROLLUPCEMENT:
+			v, err := p.forgeSmartRollupCement(c.ToSmartRollupCement())
+			if err != nil {
+				return "", errors.Wrap(err, "failed to forge operation")
+			}
+			buf.Write(v)
+		case rpc.SMARTROLLUPPUBLISH:
+			v, err := p.forgeSmartRollupPublish(c.ToSmartRollupPublish())
+			if err != nil {
+				return "", errors.Wrap(err, "failed to forge operation")
+			}
+			buf.Write(v)
+		case rpc.SMARTROLLUPEXECUTEOUTBOXMESSAGE:
+			v, err := p.forgeSmartRollupExecuteOutboxMessage(c.ToSmartRollupExecuteOutboxMessage())
 			if err != nil {
 				return "", errors.Wrap(err, "failed to forge operation")
 			}
@@ -285,7 +445,65 @@ func Encode(branch string, contents ...rpc.Content) (string, error) {
 	return hex.EncodeToString(buf.Bytes()), nil
 }
 
-func forgeReveal(r rpc.Reveal) ([]byte, error) {
+/*
+EncodeAndSign forges the operation contents passed, signs them with the generic operation watermark (0x03)
+using signer and returns the hex encoded signed operation ready for injection. Operations are forged for
+DefaultProtocol.
+
+Parameters:
+
+	signer:
+		The signer of the operation, e.g. a keys.Key or a remote signer.
+
+	branch:
+		The branch to forge the operation on.
+
+	contents:
+		The operation contents to be formed.
+*/
+func EncodeAndSign(signer keys.Signer, branch string, contents ...rpc.Content) (string, error) {
+	return DefaultProtocol().EncodeAndSign(signer, branch, contents...)
+}
+
+/*
+EncodeAndSign forges the operation contents passed for the protocol and signs them like EncodeAndSign.
+
+Parameters:
+
+	signer:
+		The signer of the operation, e.g. a keys.Key or a remote signer.
+
+	branch:
+		The branch to forge the operation on.
+
+	contents:
+		The operation contents to be formed.
+*/
+func (p *Protocol) EncodeAndSign(signer keys.Signer, branch string, contents ...rpc.Content) (string, error) {
+	op, err := p.Encode(branch, contents...)
+	if err != nil {
+		return "", err
+	}
+
+	v, err := hex.DecodeString(op)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign operation")
+	}
+
+	v, err = keys.AddWatermark(v, keys.WatermarkGenericOperation, "")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign operation")
+	}
+
+	signature, err := signer.SignBytes(v)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign operation")
+	}
+
+	return fmt.Sprintf("%s%s", op, signature.ToHex()), nil
+}
+
+func (p *Protocol) forgeReveal(r rpc.Reveal) ([]byte, error) {
 	err := validator.New().Struct(r)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
@@ -293,7 +511,7 @@ func forgeReveal(r rpc.Reveal) ([]byte, error) {
 
 	result := bytes.NewBuffer([]byte{})
 
-	if kind, err := forgeNat(operationTags("reveal")); err == nil {
+	if kind, err := p.forgeTag(rpc.REVEAL); err == nil {
 		result.Write(kind)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge kind")
@@ -338,7 +556,7 @@ func forgeReveal(r rpc.Reveal) ([]byte, error) {
 	return result.Bytes(), nil
 }
 
-func forgeAccountActivation(a rpc.AccountActivation) ([]byte, error) {
+func (p *Protocol) forgeAccountActivation(a rpc.AccountActivation) ([]byte, error) {
 	err := validator.New().Struct(a)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
@@ -346,7 +564,7 @@ func forgeAccountActivation(a rpc.AccountActivation) ([]byte, error) {
 
 	result := bytes.NewBuffer([]byte{})
 
-	if kind, err := forgeNat(operationTags("activate_account")); err == nil {
+	if kind, err := p.forgeTag(rpc.ACTIVATEACCOUNT); err == nil {
 		result.Write(kind)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge kind")
@@ -367,7 +585,7 @@ func forgeAccountActivation(a rpc.AccountActivation) ([]byte, error) {
 	return result.Bytes(), nil
 }
 
-func forgeTransaction(t rpc.Transaction) ([]byte, error) {
+func (p *Protocol) forgeTransaction(t rpc.Transaction) ([]byte, error) {
 	err := validator.New().Struct(t)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
@@ -375,7 +593,7 @@ func forgeTransaction(t rpc.Transaction) ([]byte, error) {
 
 	result := bytes.NewBuffer([]byte{})
 
-	if kind, err := forgeNat(operationTags("transaction")); err == nil {
+	if kind, err := p.forgeTag(rpc.TRANSACTION); err == nil {
 		result.Write(kind)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge kind")
@@ -427,13 +645,13 @@ func forgeTransaction(t rpc.Transaction) ([]byte, error) {
 		result.Write(forgeBool(true))
 		result.Write(forgeEntrypoint(t.Parameters.Entrypoint))
 
-		var p fastjson.Parser
-		v, err := p.Parse(string(*t.Parameters.Value))
+		var parser fastjson.Parser
+		v, err := parser.Parse(string(*t.Parameters.Value))
 		if err != nil {
 			return []byte{}, errors.Wrap(err, "failed to represent parameters value as json blob")
 		}
 
-		if micheline, err := forgeMicheline(v); err == nil {
+		if micheline, err := p.forgeMicheline(v); err == nil {
 			result.Write(forgeArray(micheline, 4))
 		} else {
 			return []byte{}, errors.Wrap(err, "failed to forge parameters")
@@ -446,7 +664,7 @@ func forgeTransaction(t rpc.Transaction) ([]byte, error) {
 	return result.Bytes(), nil
 }
 
-func forgeOrigination(o rpc.Origination) ([]byte, error) {
+func (p *Protocol) forgeOrigination(o rpc.Origination) ([]byte, error) {
 	err := validator.New().Struct(o)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
@@ -454,7 +672,7 @@ func forgeOrigination(o rpc.Origination) ([]byte, error) {
 
 	result := bytes.NewBuffer([]byte{})
 
-	if kind, err := forgeNat(operationTags("origination")); err == nil {
+	if kind, err := p.forgeTag(rpc.ORIGINATION); err == nil {
 		result.Write(kind)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge kind")
@@ -507,7 +725,7 @@ func forgeOrigination(o rpc.Origination) ([]byte, error) {
 		result.Write(forgeBool(false))
 	}
 
-	if script, err := forgeScript(o.Script); err == nil {
+	if script, err := p.forgeScript(o.Script); err == nil {
 		result.Write(script)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge script")
@@ -516,7 +734,7 @@ func forgeOrigination(o rpc.Origination) ([]byte, error) {
 	return result.Bytes(), nil
 }
 
-func forgeDelegation(d rpc.Delegation) ([]byte, error) {
+func (p *Protocol) forgeDelegation(d rpc.Delegation) ([]byte, error) {
 	err := validator.New().Struct(d)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
@@ -524,7 +742,7 @@ func forgeDelegation(d rpc.Delegation) ([]byte, error) {
 
 	result := bytes.NewBuffer([]byte{})
 
-	if kind, err := forgeNat(operationTags("delegation")); err == nil {
+	if kind, err := p.forgeTag(rpc.DELEGATION); err == nil {
 		result.Write(kind)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge kind")
@@ -574,15 +792,19 @@ func forgeDelegation(d rpc.Delegation) ([]byte, error) {
 	return result.Bytes(), nil
 }
 
-func forgeEndorsement(e rpc.Endorsement) ([]byte, error) {
+func (p *Protocol) forgeEndorsement(e rpc.Endorsement) ([]byte, error) {
 	err := validator.New().Struct(e)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
 	}
 
+	if p.tenderbake {
+		return p.forgeConsensus(rpc.ENDORSEMENT, e.Slot, e.Level, e.Round, e.BlockPayloadHash)
+	}
+
 	result := bytes.NewBuffer([]byte{})
 
-	if kind, err := forgeNat(operationTags("endorsement")); err == nil {
+	if kind, err := p.forgeTag(rpc.ENDORSEMENT); err == nil {
 		result.Write(kind)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge kind")
@@ -592,7 +814,57 @@ func forgeEndorsement(e rpc.Endorsement) ([]byte, error) {
 	return result.Bytes(), nil
 }
 
-func forgeSeedNonceRevelation(s rpc.SeedNonceRevelation) ([]byte, error) {
+func (p *Protocol) forgePreendorsement(e rpc.Preendorsement) ([]byte, error) {
+	err := validator.New().Struct(e)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "invalid input")
+	}
+
+	return p.forgeConsensus(rpc.PREENDORSEMENT, e.Slot, e.Level, e.Round, e.BlockPayloadHash)
+}
+
+func (p *Protocol) forgeAttestation(a rpc.Attestation) ([]byte, error) {
+	err := validator.New().Struct(a)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "invalid input")
+	}
+
+	return p.forgeConsensus(rpc.ATTESTATION, a.Slot, a.Level, a.Round, a.BlockPayloadHash)
+}
+
+func (p *Protocol) forgePreattestation(a rpc.Preattestation) ([]byte, error) {
+	err := validator.New().Struct(a)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "invalid input")
+	}
+
+	return p.forgeConsensus(rpc.PREATTESTATION, a.Slot, a.Level, a.Round, a.BlockPayloadHash)
+}
+
+// forgeConsensus forges the Tenderbake consensus operations, which only differ by their tag.
+func (p *Protocol) forgeConsensus(kind rpc.Kind, slot, level, round int, blockPayloadHash string) ([]byte, error) {
+	result := bytes.NewBuffer([]byte{})
+
+	if tag, err := p.forgeTag(kind); err == nil {
+		result.Write(tag)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge kind")
+	}
+
+	result.Write(forgeInt32(slot, 2))
+	result.Write(forgeInt32(level, 4))
+	result.Write(forgeInt32(round, 4))
+
+	if payloadHash, err := forgeBase58(blockPayloadHash, b58.BlockPayloadHash); err == nil {
+		result.Write(payloadHash)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge block_payload_hash")
+	}
+
+	return result.Bytes(), nil
+}
+
+func (p *Protocol) forgeSeedNonceRevelation(s rpc.SeedNonceRevelation) ([]byte, error) {
 	err := validator.New().Struct(s)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
@@ -600,7 +872,7 @@ func forgeSeedNonceRevelation(s rpc.SeedNonceRevelation) ([]byte, error) {
 
 	result := bytes.NewBuffer([]byte{})
 
-	if kind, err := forgeNat(operationTags("seed_nonce_revelation")); err == nil {
+	if kind, err := p.forgeTag(rpc.SEEDNONCEREVELATION); err == nil {
 		result.Write(kind)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge kind")
@@ -617,32 +889,32 @@ func forgeSeedNonceRevelation(s rpc.SeedNonceRevelation) ([]byte, error) {
 	return result.Bytes(), nil
 }
 
-func forgeProposal(p rpc.Proposal) ([]byte, error) {
-	err := validator.New().Struct(p)
+func (p *Protocol) forgeProposal(proposal rpc.Proposal) ([]byte, error) {
+	err := validator.New().Struct(proposal)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
 	}
 
 	result := bytes.NewBuffer([]byte{})
 
-	if kind, err := forgeNat(operationTags("proposal")); err == nil {
+	if kind, err := p.forgeTag(rpc.PROPOSALS); err == nil {
 		result.Write(kind)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge kind")
 	}
 
-	if source, err := forgeSource(p.Source); err == nil {
+	if source, err := forgeSource(proposal.Source); err == nil {
 		result.Write(source)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge source")
 	}
 
-	result.Write(forgeInt32(p.Period, 4))
+	result.Write(forgeInt32(proposal.Period, 4))
 
 	buf := bytes.NewBuffer([]byte{})
-	for _, proposal := range p.Proposals {
-		if p, err := prefixAndBase58Encode(proposal, proposalPrefix); err == nil {
-			buf.Write([]byte(p))
+	for _, hash := range proposal.Proposals {
+		if v, err := forgeBase58(hash, b58.ProtocolHash); err == nil {
+			buf.Write(v)
 		} else {
 			return []byte{}, errors.Wrap(err, "failed to forge proposals")
 		}
@@ -652,7 +924,7 @@ func forgeProposal(p rpc.Proposal) ([]byte, error) {
 	return result.Bytes(), nil
 }
 
-func forgeBallot(b rpc.Ballot) ([]byte, error) {
+func (p *Protocol) forgeBallot(b rpc.Ballot) ([]byte, error) {
 	err := validator.New().Struct(b)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
@@ -660,7 +932,7 @@ func forgeBallot(b rpc.Ballot) ([]byte, error) {
 
 	result := bytes.NewBuffer([]byte{})
 
-	if kind, err := forgeNat(operationTags("ballot")); err == nil {
+	if kind, err := p.forgeTag(rpc.BALLOT); err == nil {
 		result.Write(kind)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge kind")
@@ -674,18 +946,22 @@ func forgeBallot(b rpc.Ballot) ([]byte, error) {
 
 	result.Write(forgeInt32(b.Period, 4))
 
-	if p, err := prefixAndBase58Encode(b.Proposal, proposalPrefix); err == nil {
-		result.Write([]byte(p))
+	if p, err := forgeBase58(b.Proposal, b58.ProtocolHash); err == nil {
+		result.Write(p)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge proposal")
 	}
 
-	result.Write([]byte(b.Ballot))
+	if ballot, ok := ballotTags[b.Ballot]; ok {
+		result.WriteByte(ballot)
+	} else {
+		return []byte{}, fmt.Errorf("failed to forge ballot: invalid ballot '%s'", b.Ballot)
+	}
 
 	return result.Bytes(), nil
 }
 
-func forgeDoubleEndorsementEvidence(d rpc.DoubleEndorsementEvidence) ([]byte, error) {
+func (p *Protocol) forgeDoubleEndorsementEvidence(d rpc.DoubleEndorsementEvidence) ([]byte, error) {
 	err := validator.New().Struct(d)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
@@ -693,28 +969,32 @@ func forgeDoubleEndorsementEvidence(d rpc.DoubleEndorsementEvidence) ([]byte, er
 
 	result := bytes.NewBuffer([]byte{})
 
-	if kind, err := forgeNat(operationTags("double_endorsement_evidence")); err == nil {
+	if kind, err := p.forgeTag(rpc.DOUBLEENDORSEMENTEVIDENCE); err == nil {
 		result.Write(kind)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge kind")
 	}
 
-	if op1, err := forgeInlinedEndorsement(*d.Op1); err == nil {
+	if op1, err := p.forgeInlinedEndorsement(*d.Op1); err == nil {
 		result.Write(op1)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge op1")
 	}
 
-	if op2, err := forgeInlinedEndorsement(*d.Op2); err == nil {
+	if op2, err := p.forgeInlinedEndorsement(*d.Op2); err == nil {
 		result.Write(op2)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge op2")
 	}
 
+	if p.endorsementSlot {
+		result.Write(forgeInt32(d.Slot, 2))
+	}
+
 	return result.Bytes(), nil
 }
 
-func forgeDoubleBakingEvidence(d rpc.DoubleBakingEvidence) ([]byte, error) {
+func (p *Protocol) forgeDoubleBakingEvidence(d rpc.DoubleBakingEvidence) ([]byte, error) {
 	err := validator.New().Struct(d)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
@@ -722,19 +1002,19 @@ func forgeDoubleBakingEvidence(d rpc.DoubleBakingEvidence) ([]byte, error) {
 
 	result := bytes.NewBuffer([]byte{})
 
-	if kind, err := forgeNat(operationTags("double_baking_evidence")); err == nil {
+	if kind, err := p.forgeTag(rpc.DOUBLEBAKINGEVIDENCE); err == nil {
 		result.Write(kind)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge kind")
 	}
 
-	if bh1, err := forgeBlockHeader(*d.Bh1); err == nil {
+	if bh1, err := p.forgeBlockHeader(*d.Bh1); err == nil {
 		result.Write(bh1)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge bh1")
 	}
 
-	if bh2, err := forgeBlockHeader(*d.Bh2); err == nil {
+	if bh2, err := p.forgeBlockHeader(*d.Bh2); err == nil {
 		result.Write(bh2)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge bh2")
@@ -743,24 +1023,33 @@ func forgeDoubleBakingEvidence(d rpc.DoubleBakingEvidence) ([]byte, error) {
 	return result.Bytes(), nil
 }
 
-func forgeInlinedEndorsement(i rpc.InlinedEndorsement) ([]byte, error) {
+func (p *Protocol) forgeInlinedEndorsement(i rpc.InlinedEndorsement) ([]byte, error) {
 	result := bytes.NewBuffer([]byte{})
-	if branch, err := prefixAndBase58Encode(i.Branch, branchPrefix); err == nil {
-		result.Write([]byte(branch))
+	if branch, err := forgeBase58(i.Branch, b58.BlockHash); err == nil {
+		result.Write(branch)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge branch")
 	}
 
-	if kind, err := forgeNat(operationTags(i.Operations.Kind)); err == nil {
-		result.Write(kind)
+	if p.tenderbake {
+		o := i.Operations
+		if operations, err := p.forgeConsensus(rpc.Kind(o.Kind), o.Slot, o.Level, o.Round, o.BlockPayloadHash); err == nil {
+			result.Write(operations)
+		} else {
+			return []byte{}, errors.Wrap(err, "failed to forge operations")
+		}
 	} else {
-		return []byte{}, errors.Wrap(err, "failed to forge operations kind")
-	}
+		if kind, err := p.forgeTag(rpc.Kind(i.Operations.Kind)); err == nil {
+			result.Write(kind)
+		} else {
+			return []byte{}, errors.Wrap(err, "failed to forge operations kind")
+		}
 
-	result.Write(forgeInt32(i.Operations.Level, 4))
+		result.Write(forgeInt32(i.Operations.Level, 4))
+	}
 
-	if signature, err := prefixAndBase58Encode(i.Signature, sigPrefix); err == nil {
-		result.Write([]byte(signature))
+	if signature, err := keys.ParseSignature(i.Signature); err == nil {
+		result.Write(signature.Bytes)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge signature")
 	}
@@ -768,23 +1057,22 @@ func forgeInlinedEndorsement(i rpc.InlinedEndorsement) ([]byte, error) {
 	return forgeArray(result.Bytes(), 4), nil
 }
 
-func forgeBlockHeader(b rpc.BlockHeader) ([]byte, error) {
+func (p *Protocol) forgeBlockHeader(b rpc.BlockHeader) ([]byte, error) {
 	result := bytes.NewBuffer([]byte{})
 	result.Write(forgeInt32(b.Level, 4))
 	result.Write(forgeInt32(b.Proto, 1))
 
-	if predecessor, err := prefixAndBase58Encode(b.Predecessor, branchPrefix); err == nil {
-		result.Write([]byte(predecessor))
+	if predecessor, err := forgeBase58(b.Predecessor, b58.BlockHash); err == nil {
+		result.Write(predecessor)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge predecessor")
 	}
 
-	ts := int(b.Timestamp.Sub(time.Date(1970, time.January, 1, 0, 0, 0, 0, nil)).Seconds())
-	result.Write(forgeInt32(ts, 8))
+	result.Write(forgeInt32(int(b.Timestamp.Unix()), 8))
 	result.Write(forgeInt32(b.ValidationPass, 1))
 
-	if operationHash, err := prefixAndBase58Encode(b.OperationsHash, operationPrefix); err == nil {
-		result.Write([]byte(operationHash))
+	if operationHash, err := forgeBase58(b.OperationsHash, b58.OperationListListHash); err == nil {
+		result.Write(operationHash)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge operation_hash")
 	}
@@ -799,268 +1087,670 @@ func forgeBlockHeader(b rpc.BlockHeader) ([]byte, error) {
 	}
 	result.Write(forgeArray(buf.Bytes(), 4))
 
-	if context, err := prefixAndBase58Encode(b.Context, contextPrefix); err == nil {
-		result.Write([]byte(context))
+	if context, err := forgeBase58(b.Context, b58.ContextHash); err == nil {
+		result.Write(context)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge context")
 	}
 
-	result.Write(forgeInt32(b.Priority, 2))
+	if p.tenderbake {
+		if payloadHash, err := forgeBase58(b.PayloadHash, b58.BlockPayloadHash); err == nil {
+			result.Write(payloadHash)
+		} else {
+			return []byte{}, errors.Wrap(err, "failed to forge payload_hash")
+		}
 
-	if proofOfWorkNonce, err := hex.DecodeString(b.ProofOfWorkNonce); err == nil {
-		buf.Write(proofOfWorkNonce)
+		result.Write(forgeInt32(b.PayloadRound, 4))
 	} else {
-		return []byte{}, errors.Wrap(err, "failed to forge proof_of_work_nonce")
+		result.Write(forgeInt32(b.Priority, 2))
 	}
 
-	if signature, err := forgeSignature(b.Signature); err == nil {
-		buf.Write(signature)
+	if proofOfWorkNonce, err := hex.DecodeString(b.ProofOfWorkNonce); err == nil && len(proofOfWorkNonce) == 8 {
+		result.Write(proofOfWorkNonce)
 	} else {
-		return []byte{}, errors.Wrap(err, "failed to forge signature")
+		return []byte{}, fmt.Errorf("failed to forge proof_of_work_nonce: invalid nonce '%s'", b.ProofOfWorkNonce)
 	}
 
-	return forgeArray(result.Bytes(), 4), nil
-}
-
-func forgeSignature(value string) ([]byte, error) {
-	var buf []byte
-	if signature, err := prefixAndBase58Encode(value, sigPrefix); err == nil {
-		buf = []byte(signature)
+	if b.SeedNonceHash != "" {
+		result.Write(forgeBool(true))
+		if seedNonceHash, err := forgeBase58(b.SeedNonceHash, b58.NonceHash); err == nil {
+			result.Write(seedNonceHash)
+		} else {
+			return []byte{}, errors.Wrap(err, "failed to forge seed_nonce_hash")
+		}
 	} else {
-		return []byte{}, errors.Wrap(err, "failed to forge context")
+		result.Write(forgeBool(false))
 	}
 
-	return append([]byte{0}, buf...), nil
-}
+	if p.escapeVote {
+		result.Write(forgeBool(b.LiquidityBakingEscapeVote))
+	}
 
-func forgeInt32(value int, l int) []byte {
-	return reverseBytes([]byte{byte(value)}[0:l])
-}
+	if p.toggleVote || p.perBlockVotes {
+		vote, ok := perBlockVoteTags[b.LiquidityBakingToggleVote]
+		if !ok {
+			return []byte{}, fmt.Errorf("failed to forge liquidity_baking_toggle_vote: invalid vote '%s'", b.LiquidityBakingToggleVote)
+		}
 
-func forgeNat(value string) ([]byte, error) {
-	var z big.Int
-	_, ok := z.SetString(string(value), 10)
-	if !ok {
-		return nil, fmt.Errorf("value (%s) has to be a number", value)
+		// Since Oxford both votes are packed in a byte, the adaptive issuance vote in bits 2 and 3.
+		if p.perBlockVotes {
+			adaptiveIssuanceVote, ok := perBlockVoteTags[b.AdaptiveIssuanceVote]
+			if !ok {
+				return []byte{}, fmt.Errorf("failed to forge adaptive_issuance_vote: invalid vote '%s'", b.AdaptiveIssuanceVote)
+			}
+			vote |= adaptiveIssuanceVote << 2
+		}
+
+		result.WriteByte(vote)
 	}
-	val := int(z.Int64())
 
-	if val < 0 {
-		return nil, fmt.Errorf("nat value (%s) cannot be negative", value)
+	if signature, err := keys.ParseSignature(b.Signature); err == nil {
+		result.Write(signature.Bytes)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge signature")
 	}
 
-	buf := bytes.NewBuffer([]byte{})
-	more := true
+	return forgeArray(result.Bytes(), 4), nil
+}
 
-	for more {
-		b := byte(val & 0x7f)
-		val >>= 7
-		if val > 0 {
-			b |= 0x80
-		} else {
-			more = false
-		}
+func (p *Protocol) forgeManager(kind rpc.Kind, source, fee, counter, gasLimit, storageLimit string) ([]byte, error) {
+	result := bytes.NewBuffer([]byte{})
 
-		buf.WriteByte(b)
+	if tag, err := p.forgeTag(kind); err == nil {
+		result.Write(tag)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge kind")
 	}
 
-	return buf.Bytes(), nil
-}
+	if source, err := forgeSource(source); err == nil {
+		result.Write(source)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge source")
+	}
 
-func forgeSource(source string) ([]byte, error) {
-	var prefix string
-	if len(source) != 36 {
-		return []byte{}, fmt.Errorf("invalid length (%d!=36) source address", len(source))
+	if fee, err := forgeNat(fee); err == nil {
+		result.Write(fee)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge fee")
 	}
-	prefix = source[0:(0 + 3)]
 
-	buf, err := crypto.Decode(source)
-	if err != nil {
-		return []byte{}, errors.Wrap(err, "failed to decode from base58")
+	if counter, err := forgeNat(counter); err == nil {
+		result.Write(counter)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge counter")
+	}
+
+	if gasLimit, err := forgeNat(gasLimit); err == nil {
+		result.Write(gasLimit)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge gas_limit")
 	}
-	buf = buf[3:]
 
-	switch prefix {
-	case "tz1":
-		buf = append([]byte{0}, buf...)
-	case "tz2":
-		buf = append([]byte{1}, buf...)
-	case "tz3":
-		buf = append([]byte{2}, buf...)
-	default:
-		return []byte{}, fmt.Errorf("invalid source prefix '%s'", prefix)
+	if storageLimit, err := forgeNat(storageLimit); err == nil {
+		result.Write(storageLimit)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge storage_limit")
 	}
 
-	return buf, nil
+	return result.Bytes(), nil
 }
 
-func forgeAddress(address string) ([]byte, error) {
-	if len(address) != 36 {
-		return []byte{}, fmt.Errorf("invalid length (%d!=36) source address", len(address))
-	}
-	prefix := address[0:(0 + 3)]
-	buf, err := crypto.Decode(address)
+func (p *Protocol) forgeRegisterGlobalConstant(r rpc.RegisterGlobalConstant) ([]byte, error) {
+	err := validator.New().Struct(r)
 	if err != nil {
-		return []byte{}, errors.Wrap(err, "failed to decode from base58")
+		return []byte{}, errors.Wrap(err, "invalid input")
 	}
-	buf = buf[3:]
 
-	switch prefix {
-	case "tz1":
-		buf = append([]byte{0, 0}, buf...)
-	case "tz2":
-		buf = append([]byte{0, 1}, buf...)
-	case "tz3":
-		buf = append([]byte{0, 2}, buf...)
-	case "KT1":
-		buf = append([]byte{1}, buf...)
-		buf = append(buf, byte(0))
-	default:
-		return []byte{}, fmt.Errorf("invalid address prefix '%s'", prefix)
-	}
+	result := bytes.NewBuffer([]byte{})
 
-	return buf, nil
-}
+	if manager, err := p.forgeManager(rpc.REGISTERGLOBALCONSTANT, r.Source, r.Fee, r.Counter, r.GasLimit, r.StorageLimit); err == nil {
+		result.Write(manager)
+	} else {
+		return []byte{}, err
+	}
 
-func forgeBool(value bool) []byte {
-	if value {
-		return []byte{255}
+	if value, err := p.forgeExpression(r.Value); err == nil {
+		result.Write(value)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge value")
 	}
 
-	return []byte{0}
+	return result.Bytes(), nil
 }
 
-func forgeEntrypoint(value string) []byte {
-	buf := bytes.NewBuffer([]byte{})
-
-	entrypointTags := map[string]byte{
-		"default":         0,
-		"root":            1,
-		"do":              2,
-		"set_delegate":    3,
-		"remove_delegate": 4,
+func (p *Protocol) forgeSetDepositsLimit(s rpc.SetDepositsLimit) ([]byte, error) {
+	err := validator.New().Struct(s)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "invalid input")
 	}
 
-	if val, ok := entrypointTags[value]; ok {
-		buf.WriteByte(val)
+	result := bytes.NewBuffer([]byte{})
+
+	if manager, err := p.forgeManager(rpc.SETDEPOSITSLIMIT, s.Source, s.Fee, s.Counter, s.GasLimit, s.StorageLimit); err == nil {
+		result.Write(manager)
 	} else {
-		buf.WriteByte(byte(255))
-		buf.Write(forgeArray(bytes.NewBufferString(value).Bytes(), 1))
+		return []byte{}, err
 	}
 
-	return buf.Bytes()
+	if s.Limit != "" {
+		result.Write(forgeBool(true))
+		if limit, err := forgeNat(s.Limit); err == nil {
+			result.Write(limit)
+		} else {
+			return []byte{}, errors.Wrap(err, "failed to forge limit")
+		}
+	} else {
+		result.Write(forgeBool(false))
+	}
+
+	return result.Bytes(), nil
 }
 
-func forgeArray(value []byte, l int) []byte {
-	buf := new(bytes.Buffer)
-	num := uint64(len(value))
-	binary.Write(buf, binary.LittleEndian, num)
+func (p *Protocol) forgeIncreasePaidStorage(i rpc.IncreasePaidStorage) ([]byte, error) {
+	err := validator.New().Struct(i)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "invalid input")
+	}
 
-	bytes := reverseBytes(buf.Bytes()[0:l])
-	bytes = append(bytes, value...)
+	result := bytes.NewBuffer([]byte{})
 
-	return bytes
+	if manager, err := p.forgeManager(rpc.INCREASEPAIDSTORAGE, i.Source, i.Fee, i.Counter, i.GasLimit, i.StorageLimit); err == nil {
+		result.Write(manager)
+	} else {
+		return []byte{}, err
+	}
+
+	if amount, ok := new(big.Int).SetString(i.Amount, 10); ok {
+		result.Write(forgeInt(amount))
+	} else {
+		return []byte{}, fmt.Errorf("failed to forge amount: invalid amount '%s'", i.Amount)
+	}
+
+	if destination, err := forgeOriginatedContract(i.Destination); err == nil {
+		result.Write(destination)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge destination")
+	}
+
+	return result.Bytes(), nil
+}
+
+func (p *Protocol) forgeUpdateConsensusKey(u rpc.UpdateConsensusKey) ([]byte, error) {
+	err := validator.New().Struct(u)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "invalid input")
+	}
+
+	result := bytes.NewBuffer([]byte{})
+
+	if manager, err := p.forgeManager(rpc.UPDATECONSENSUSKEY, u.Source, u.Fee, u.Counter, u.GasLimit, u.StorageLimit); err == nil {
+		result.Write(manager)
+	} else {
+		return []byte{}, err
+	}
+
+	if pk, err := forgePublicKey(u.Pk); err == nil {
+		result.Write(pk)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge pk")
+	}
+
+	return result.Bytes(), nil
 }
 
-func forgeInt(value int) []byte {
-	binary := strconv.FormatInt(int64(math.Abs(float64(value))), 2)
-	lenBin := len(binary)
+func (p *Protocol) forgeDrainDelegate(d rpc.DrainDelegate) ([]byte, error) {
+	err := validator.New().Struct(d)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "invalid input")
+	}
 
-	pad := 6
-	if (lenBin-6)%7 == 0 {
-		pad = lenBin
-	} else if lenBin > 6 {
-		pad = lenBin + 7 - (lenBin-6)%7
+	result := bytes.NewBuffer([]byte{})
+
+	if kind, err := p.forgeTag(rpc.DRAINDELEGATE); err == nil {
+		result.Write(kind)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge kind")
 	}
 
-	binary = fmt.Sprintf("%0*s", pad, binary)
-	septets := []string{}
+	if consensusKey, err := forgeSource(d.ConsensusKey); err == nil {
+		result.Write(consensusKey)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge consensus_key")
+	}
+
+	if delegate, err := forgeSource(d.Delegate); err == nil {
+		result.Write(delegate)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge delegate")
+	}
 
-	for i := 0; i <= pad/7; i++ {
-		index := 7 * i
-		length := int(math.Min(7, float64(pad-7*i)))
-		septets = append(septets, binary[index:(index+length)])
+	if destination, err := forgeSource(d.Destination); err == nil {
+		result.Write(destination)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge destination")
+	}
+
+	return result.Bytes(), nil
+}
+
+func (p *Protocol) forgeTransferTicket(t rpc.TransferTicket) ([]byte, error) {
+	err := validator.New().Struct(t)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "invalid input")
+	}
+
+	result := bytes.NewBuffer([]byte{})
+
+	if manager, err := p.forgeManager(rpc.TRANSFERTICKET, t.Source, t.Fee, t.Counter, t.GasLimit, t.StorageLimit); err == nil {
+		result.Write(manager)
+	} else {
+		return []byte{}, err
+	}
+
+	if contents, err := p.forgeExpression(t.TicketContents); err == nil {
+		result.Write(contents)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge ticket_contents")
 	}
 
-	septets = reverseStrings(septets)
-	if value >= 0 {
-		septets[0] = fmt.Sprintf("0%s", septets[0])
+	if ty, err := p.forgeExpression(t.TicketTy); err == nil {
+		result.Write(ty)
 	} else {
-		septets[0] = fmt.Sprintf("1%s", septets[0])
+		return []byte{}, errors.Wrap(err, "failed to forge ticket_ty")
+	}
+
+	if ticketer, err := forgeAddress(t.TicketTicketer); err == nil {
+		result.Write(ticketer)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge ticket_ticketer")
+	}
+
+	if amount, err := forgeNat(t.TicketAmount); err == nil {
+		result.Write(amount)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge ticket_amount")
+	}
+
+	if destination, err := forgeAddress(t.Destination); err == nil {
+		result.Write(destination)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge destination")
+	}
+
+	result.Write(forgeArray([]byte(t.Entrypoint), 4))
+
+	return result.Bytes(), nil
+}
+
+func (p *Protocol) forgeSmartRollupOriginate(s rpc.SmartRollupOriginate) ([]byte, error) {
+	err := validator.New().Struct(s)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "invalid input")
+	}
+
+	result := bytes.NewBuffer([]byte{})
+
+	if manager, err := p.forgeManager(rpc.SMARTROLLUPORIGINATE, s.Source, s.Fee, s.Counter, s.GasLimit, s.StorageLimit); err == nil {
+		result.Write(manager)
+	} else {
+		return []byte{}, err
+	}
+
+	if pvmKind, ok := pvmKindTags[s.PvmKind]; ok {
+		result.WriteByte(pvmKind)
+	} else {
+		return []byte{}, fmt.Errorf("failed to forge pvm_kind: invalid pvm kind '%s'", s.PvmKind)
+	}
+
+	if kernel, err := hex.DecodeString(s.Kernel); err == nil {
+		result.Write(forgeArray(kernel, 4))
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge kernel")
+	}
+
+	if p.originationProof {
+		if proof, err := hex.DecodeString(s.OriginationProof); err == nil {
+			result.Write(forgeArray(proof, 4))
+		} else {
+			return []byte{}, errors.Wrap(err, "failed to forge origination_proof")
+		}
+	}
+
+	if parametersTy, err := p.forgeExpression(s.ParametersTy); err == nil {
+		result.Write(parametersTy)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge parameters_ty")
+	}
+
+	if p.rollupWhitelist {
+		if s.Whitelist != nil {
+			result.Write(forgeBool(true))
+
+			buf := bytes.NewBuffer([]byte{})
+			for _, pkh := range s.Whitelist {
+				if v, err := forgeSource(pkh); err == nil {
+					buf.Write(v)
+				} else {
+					return []byte{}, errors.Wrap(err, "failed to forge whitelist")
+				}
+			}
+			result.Write(forgeArray(buf.Bytes(), 4))
+		} else {
+			result.Write(forgeBool(false))
+		}
+	}
+
+	return result.Bytes(), nil
+}
+
+func (p *Protocol) forgeSmartRollupAddMessages(s rpc.SmartRollupAddMessages) ([]byte, error) {
+	err := validator.New().Struct(s)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "invalid input")
+	}
+
+	result := bytes.NewBuffer([]byte{})
+
+	if manager, err := p.forgeManager(rpc.SMARTROLLUPADDMESSAGES, s.Source, s.Fee, s.Counter, s.GasLimit, s.StorageLimit); err == nil {
+		result.Write(manager)
+	} else {
+		return []byte{}, err
 	}
 
 	buf := bytes.NewBuffer([]byte{})
+	for _, message := range s.Message {
+		if v, err := hex.DecodeString(message); err == nil {
+			buf.Write(forgeArray(v, 4))
+		} else {
+			return []byte{}, errors.Wrap(err, "failed to forge message")
+		}
+	}
+	result.Write(forgeArray(buf.Bytes(), 4))
+
+	return result.Bytes(), nil
+}
+
+func (p *Protocol) forgeSmartRollupCement(s rpc.SmartRollupCement) ([]byte, error) {
+	err := validator.New().Struct(s)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "invalid input")
+	}
+
+	result := bytes.NewBuffer([]byte{})
 
-	for i := 0; i < len(septets); i++ {
+	if manager, err := p.forgeManager(rpc.SMARTROLLUPCEMENT, s.Source, s.Fee, s.Counter, s.GasLimit, s.StorageLimit); err == nil {
+		result.Write(manager)
+	} else {
+		return []byte{}, err
+	}
 
-		prefix := "1"
-		if i == len(septets)-1 {
-			prefix = "0"
+	if rollup, err := forgeBase58(s.Rollup, b58.SmartRollupHash); err == nil {
+		result.Write(rollup)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge rollup")
+	}
+
+	if p.cementCommitment {
+		if commitment, err := forgeBase58(s.Commitment, b58.SmartRollupCommitmentHash); err == nil {
+			result.Write(commitment)
+		} else {
+			return []byte{}, errors.Wrap(err, "failed to forge commitment")
 		}
-		n := new(big.Int)
-		n.SetString(prefix+septets[i], 2)
-		buf.Write(n.Bytes())
 	}
 
-	return buf.Bytes()
+	return result.Bytes(), nil
 }
 
-func forgePublicKey(value string) ([]byte, error) {
-	if len(value) < 54 {
-		return []byte{}, fmt.Errorf("invalid public key '%s'", value)
+func (p *Protocol) forgeSmartRollupPublish(s rpc.SmartRollupPublish) ([]byte, error) {
+	err := validator.New().Struct(s)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "invalid input")
+	}
+
+	result := bytes.NewBuffer([]byte{})
+
+	if manager, err := p.forgeManager(rpc.SMARTROLLUPPUBLISH, s.Source, s.Fee, s.Counter, s.GasLimit, s.StorageLimit); err == nil {
+		result.Write(manager)
+	} else {
+		return []byte{}, err
+	}
+
+	if rollup, err := forgeBase58(s.Rollup, b58.SmartRollupHash); err == nil {
+		result.Write(rollup)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge rollup")
+	}
+
+	if compressedState, err := forgeBase58(s.Commitment.CompressedState, b58.SmartRollupStateHash); err == nil {
+		result.Write(compressedState)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge compressed_state")
 	}
 
-	prefix := value[0:(0 + 4)]
-	buf, err := crypto.Decode(value)
+	result.Write(forgeInt32(s.Commitment.InboxLevel, 4))
+
+	if predecessor, err := forgeBase58(s.Commitment.Predecessor, b58.SmartRollupCommitmentHash); err == nil {
+		result.Write(predecessor)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge predecessor")
+	}
+
+	if ticks, err := strconv.ParseInt(s.Commitment.NumberOfTicks, 10, 64); err == nil {
+		result.Write(forgeInt32(int(ticks), 8))
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge number_of_ticks")
+	}
+
+	return result.Bytes(), nil
+}
+
+func (p *Protocol) forgeSmartRollupExecuteOutboxMessage(s rpc.SmartRollupExecuteOutboxMessage) ([]byte, error) {
+	err := validator.New().Struct(s)
 	if err != nil {
-		return []byte{}, errors.Wrap(err, "failed to decode from base58")
+		return []byte{}, errors.Wrap(err, "invalid input")
 	}
-	buf = buf[4:]
 
-	switch prefix {
-	case "edpk":
-		buf = append([]byte{0}, buf...)
-	case "sppk":
-		buf = append([]byte{1}, buf...)
-	case "p2pk":
-		buf = append([]byte{2}, buf...)
-	default:
-		return []byte{}, fmt.Errorf("invalid public key prefix '%s'", prefix)
+	result := bytes.NewBuffer([]byte{})
+
+	if manager, err := p.forgeManager(rpc.SMARTROLLUPEXECUTEOUTBOXMESSAGE, s.Source, s.Fee, s.Counter, s.GasLimit, s.StorageLimit); err == nil {
+		result.Write(manager)
+	} else {
+		return []byte{}, err
+	}
+
+	if rollup, err := forgeBase58(s.Rollup, b58.SmartRollupHash); err == nil {
+		result.Write(rollup)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge rollup")
+	}
+
+	if commitment, err := forgeBase58(s.CementedCommitment, b58.SmartRollupCommitmentHash); err == nil {
+		result.Write(commitment)
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge cemented_commitment")
+	}
+
+	if proof, err := hex.DecodeString(s.OutputProof); err == nil {
+		result.Write(forgeArray(proof, 4))
+	} else {
+		return []byte{}, errors.Wrap(err, "failed to forge output_proof")
+	}
+
+	return result.Bytes(), nil
+}
+
+func forgeInt32(value int, l int) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(value))
+	return buf[8-l:]
+}
+
+func forgeNat(value string) ([]byte, error) {
+	n, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil, fmt.Errorf("value (%s) has to be a number", value)
+	}
+
+	if n.Sign() < 0 {
+		return nil, fmt.Errorf("nat value (%s) cannot be negative", value)
+	}
+
+	buf := bytes.NewBuffer([]byte{})
+	for {
+		b := lowBits(n, 7)
+		n.Rsh(n, 7)
+		if n.Sign() == 0 {
+			buf.WriteByte(b)
+			return buf.Bytes(), nil
+		}
+
+		buf.WriteByte(b | 0x80)
+	}
+}
+
+func forgeSource(source string) ([]byte, error) {
+	addr, err := address.Parse(source)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "invalid source")
+	}
+
+	buf, err := addr.PublicKeyHashBytes()
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "invalid source")
 	}
 
 	return buf, nil
 }
 
+func forgeAddress(destination string) ([]byte, error) {
+	addr, err := address.Parse(destination)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "invalid address")
+	}
+
+	return addr.ContractBytes(), nil
+}
+
+// forgeOriginatedContract forges the 21 byte encoding of a KT1 address, without the tag of contract ids.
+func forgeOriginatedContract(value string) ([]byte, error) {
+	addr, err := address.Parse(value)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "invalid address")
+	}
+
+	if addr.Kind() != address.KT1 {
+		return []byte{}, fmt.Errorf("address '%s' is not an originated contract", value)
+	}
+
+	return append(addr.Hash(), 0), nil
+}
+
+func forgeBool(value bool) []byte {
+	if value {
+		return []byte{255}
+	}
+
+	return []byte{0}
+}
+
+func forgeEntrypoint(value string) []byte {
+	buf := bytes.NewBuffer([]byte{})
+
+	if val, ok := entrypointTags[value]; ok {
+		buf.WriteByte(val)
+	} else {
+		buf.WriteByte(byte(255))
+		buf.Write(forgeArray(bytes.NewBufferString(value).Bytes(), 1))
+	}
+
+	return buf.Bytes()
+}
+
+func forgeArray(value []byte, l int) []byte {
+	buf := new(bytes.Buffer)
+	num := uint64(len(value))
+	binary.Write(buf, binary.LittleEndian, num)
+
+	bytes := reverseBytes(buf.Bytes()[0:l])
+	bytes = append(bytes, value...)
+
+	return bytes
+}
+
+// forgeInt forges a signed zarith number: the first byte holds the sign and the 6 lowest bits, and the next
+// bytes hold 7 bits each.
+func forgeInt(value *big.Int) []byte {
+	n := new(big.Int).Abs(value)
+
+	b := lowBits(n, 6)
+	if value.Sign() < 0 {
+		b |= 0x40
+	}
+	n.Rsh(n, 6)
+
+	buf := bytes.NewBuffer([]byte{})
+	for n.Sign() > 0 {
+		buf.WriteByte(b | 0x80)
+		b = lowBits(n, 7)
+		n.Rsh(n, 7)
+	}
+	buf.WriteByte(b)
+
+	return buf.Bytes()
+}
+
+// lowBits returns the l lowest bits of n, l being at most 8.
+func lowBits(n *big.Int, l uint) byte {
+	mask := big.NewInt(1<<l - 1)
+	return byte(new(big.Int).And(n, mask).Uint64())
+}
+
+func forgePublicKey(value string) ([]byte, error) {
+	prefix, buf, err := b58.DecodePrefix(value, b58.Ed25519PublicKey, b58.Secp256k1PublicKey, b58.P256PublicKey, b58.BLS12_381PublicKey)
+	if err != nil {
+		return []byte{}, errors.Wrapf(err, "invalid public key '%s'", value)
+	}
+
+	tags := map[string]byte{
+		b58.Ed25519PublicKey.Name:   0,
+		b58.Secp256k1PublicKey.Name: 1,
+		b58.P256PublicKey.Name:      2,
+		b58.BLS12_381PublicKey.Name: 3,
+	}
+
+	return append([]byte{tags[prefix.Name]}, buf...), nil
+}
+
 func forgeActivationAddress(value string) ([]byte, error) {
-	buf := base58.Decode(value)
-	if len(buf) < 3 {
-		return []byte{}, fmt.Errorf("invalid activation address '%s'", value)
+	buf, err := b58.DecodeAs(value, b58.Ed25519PublicKeyHash)
+	if err != nil {
+		return []byte{}, errors.Wrapf(err, "invalid activation address '%s'", value)
 	}
 
-	return buf[3:], nil
+	return buf, nil
 }
 
-func forgeScript(script rpc.Script) ([]byte, error) {
+func (p *Protocol) forgeScript(script rpc.Script) ([]byte, error) {
 	buf := bytes.NewBuffer([]byte{})
 
-	var p fastjson.Parser
-	v, err := p.Parse(string(*script.Code))
+	var parser fastjson.Parser
+	v, err := parser.Parse(string(*script.Code))
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "failed to represent script code as json blob")
 	}
 
-	if michline, err := forgeMicheline(v); err == nil {
+	if michline, err := p.forgeMicheline(v); err == nil {
 		buf.Write(forgeArray(michline, 4))
 	} else {
 		return []byte{}, err
 	}
 
-	v, err = p.Parse(string(*script.Storage))
+	v, err = parser.Parse(string(*script.Storage))
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "failed to represent script storage as json blob")
 	}
 
-	if michline, err := forgeMicheline(v); err == nil {
+	if michline, err := p.forgeMicheline(v); err == nil {
 		buf.Write(forgeArray(michline, 4))
 	} else {
 		return []byte{}, err
@@ -1069,12 +1759,24 @@ func forgeScript(script rpc.Script) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-func reverseStrings(s []string) []string {
-	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
-		s[i], s[j] = s[j], s[i]
+// forgeExpression forges a Micheline expression prefixed by its 4 byte length.
+func (p *Protocol) forgeExpression(expression *json.RawMessage) ([]byte, error) {
+	if expression == nil {
+		return []byte{}, errors.New("missing micheline expression")
 	}
 
-	return s
+	var parser fastjson.Parser
+	v, err := parser.ParseBytes(*expression)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "failed to represent micheline as json blob")
+	}
+
+	micheline, err := p.forgeMicheline(v)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return forgeArray(micheline, 4), nil
 }
 
 func reverseBytes(s []byte) []byte {
@@ -1085,33 +1787,15 @@ func reverseBytes(s []byte) []byte {
 	return s
 }
 
-func forgeMicheline(micheline *fastjson.Value) ([]byte, error) {
+func (p *Protocol) forgeMicheline(micheline *fastjson.Value) ([]byte, error) {
 	buf := bytes.NewBuffer([]byte{})
-	lenTags := []map[bool]byte{
-		{
-			false: 3,
-			true:  4,
-		},
-		{
-			false: 5,
-			true:  6,
-		},
-		{
-			false: 7,
-			true:  8,
-		},
-		{
-			false: 9,
-			true:  9,
-		},
-	}
 
 	if array, err := micheline.Array(); err == nil { // TODO Don't forget about the error
 		buf.WriteByte(0x02)
 
 		tmpBuf := bytes.NewBuffer([]byte{})
 		for _, x := range array {
-			v, err := forgeMicheline(x)
+			v, err := p.forgeMicheline(x)
 			if err != nil {
 				return []byte{}, errors.Wrap(err, "failed to forge micheline array")
 			}
@@ -1138,13 +1822,19 @@ func forgeMicheline(micheline *fastjson.Value) ([]byte, error) {
 			}
 			annotsLen := len(annots) // NOT SURE IF CORRECT WAY TO USE PARSER
 
-			buf.WriteByte(lenTags[argsLen][annotsLen > 0])
-			buf.WriteByte(primTags(strings.Trim(obj.Get("prim").String(), "\"")))
+			prim := strings.Trim(obj.Get("prim").String(), "\"")
+			tag, ok := p.primitiveTags[prim]
+			if !ok {
+				return []byte{}, fmt.Errorf("failed to forge micheline: unknown prim '%s' in %s", prim, p.Name)
+			}
+
+			buf.WriteByte(michelineTag(argsLen, annotsLen > 0))
+			buf.WriteByte(tag)
 
 			if argsLen > 0 {
 				argsBuf := bytes.NewBuffer([]byte{})
 				for _, obj := range args {
-					v, err := forgeMicheline(obj)
+					v, err := p.forgeMicheline(obj)
 					if err != nil {
 						return []byte{}, errors.Wrap(err, "failed to forge michline args")
 					}
@@ -1161,7 +1851,7 @@ func forgeMicheline(micheline *fastjson.Value) ([]byte, error) {
 
 			if annotsLen > 0 {
 				buf.Write(forgeArray([]byte(strings.Join(annots, " ")), 4))
-			} else if argsLen == 3 {
+			} else if argsLen >= 3 {
 				buf.Write([]byte{0, 0, 0, 0})
 			}
 
@@ -1177,25 +1867,32 @@ func forgeMicheline(micheline *fastjson.Value) ([]byte, error) {
 		} else if obj.Get("int") != nil {
 			buf.WriteByte(0x00)
 
-			i, err := strconv.Atoi(strings.Trim(obj.Get("int").String(), "\""))
-			if err != nil {
+			i, ok := new(big.Int).SetString(strings.Trim(obj.Get("int").String(), "\""), 10)
+			if !ok {
 				return []byte{}, errors.New("failed to forge \"int\"")
 			}
 
 			buf.Write(forgeInt(i))
 		} else if obj.Get("string") != nil {
 			buf.WriteByte(0x01)
-			buf.Write(forgeArray(bytes.Trim(obj.Get("string").MarshalTo([]byte{}), "\""), 4))
+
+			str, err := obj.Get("string").StringBytes()
+			if err != nil {
+				return []byte{}, errors.New("failed to forge \"string\"")
+			}
+
+			buf.Write(forgeArray(str, 4))
 		}
 	}
 
 	return buf.Bytes(), nil
 }
 
-func prefixAndBase58Encode(hexPayload string, prefix []byte) (string, error) {
-	v, err := hex.DecodeString(fmt.Sprintf("%s%s", hex.EncodeToString(prefix), hexPayload))
+func forgeBase58(value string, prefix b58.Prefix) ([]byte, error) {
+	buf, err := b58.DecodeAs(value, prefix)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to encode to base58")
+		return []byte{}, errors.Wrap(err, "failed to decode from base58")
 	}
-	return crypto.Encode(v), nil
+
+	return buf, nil
 }
@@ -3,9 +3,12 @@ package forge
 import (
 	"encoding/hex"
 	"encoding/json"
+	"strings"
 	"testing"
 
+	"github.com/goat-systems/go-tezos/v3/b58"
 	"github.com/goat-systems/go-tezos/v3/internal/testutils"
+	"github.com/goat-systems/go-tezos/v3/keys"
 	"github.com/goat-systems/go-tezos/v3/rpc"
 	"github.com/stretchr/testify/assert"
 )
@@ -563,7 +566,7 @@ func Test_Forge_Origination(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			origination, err := forgeOrigination(tt.input)
+			origination, err := DefaultProtocol().forgeOrigination(tt.input)
 			testutils.CheckErr(t, tt.want.err, tt.want.errContains, err)
 			assert.Equal(t, tt.want.operation, hex.EncodeToString(origination))
 		})
@@ -690,11 +693,60 @@ func Test_Forge_Transaction(t *testing.T) {
 				"6c00490dc9520ec45270f240a3cc4f07aec76adc358d9617b693089fcd01000001fcc0bee1480bfca3a80481904cee4099400b1c8d00ff020000004f020000004a0358053d036d0743035d0100000024747a324c324875686161536e663653684544646854454172356a475057504e7770766342031e0743036a0002034f034d031b051f02000000020320",
 			},
 		},
+		{
+			"handles invalid source checksum",
+			rpc.Transaction{
+				Source:      "tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8f",
+				Fee:         "1",
+				Counter:     "1",
+				GasLimit:    "1",
+				Destination: "KT1SkmB19o8nfhRvG9LL7TjDfX2Bm1nCuYoY",
+			},
+			want{
+				true,
+				"failed to forge source: invalid source",
+				"",
+			},
+		},
+		{
+			"handles originated source",
+			rpc.Transaction{
+				Source:       "KT1SkmB19o8nfhRvG9LL7TjDfX2Bm1nCuYoY",
+				Destination:  "KT1SkmB19o8nfhRvG9LL7TjDfX2Bm1nCuYoY",
+				Fee:          "1",
+				Counter:      "1",
+				GasLimit:     "1",
+				StorageLimit: "0",
+				Amount:       "0",
+			},
+			want{
+				true,
+				"is not an implicit account",
+				"",
+			},
+		},
+		{
+			"handles invalid destination",
+			rpc.Transaction{
+				Source:       "tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e",
+				Destination:  "KT1SkmB19o8nf",
+				Fee:          "1",
+				Counter:      "1",
+				GasLimit:     "1",
+				StorageLimit: "0",
+				Amount:       "0",
+			},
+			want{
+				true,
+				"failed to forge destination: invalid address",
+				"",
+			},
+		},
 	}
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			transaction, err := forgeTransaction(tt.input)
+			transaction, err := DefaultProtocol().forgeTransaction(tt.input)
 			testutils.CheckErr(t, tt.want.err, tt.want.errContains, err)
 			assert.Equal(t, tt.want.operation, hex.EncodeToString(transaction))
 		})
@@ -740,9 +792,205 @@ func Test_Forge_Reveal(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			reveal, err := forgeReveal(tt.input)
+			reveal, err := DefaultProtocol().forgeReveal(tt.input)
 			testutils.CheckErr(t, tt.want.err, tt.want.errContains, err)
 			assert.Equal(t, tt.want.operation, hex.EncodeToString(reveal))
 		})
 	}
 }
+
+func Test_EncodeAndSign(t *testing.T) {
+	key, err := keys.NewKey(keys.NewKeyInput{
+		EncodedString: "edskRxB2DmoyZSyvhsqaJmw5CK6zYT7dbkUfEVSiQeWU1gw3ZMnC99QMMXru3imsbUrLhvuHktrymvNqhMxkhz7Y4LJAtevW5V",
+		Kind:          keys.Ed25519,
+	})
+	testutils.CheckErr(t, false, "", err)
+
+	transaction := rpc.Transaction{
+		Kind:         rpc.TRANSACTION,
+		Source:       key.PubKey.GetPublicKeyHash(),
+		Fee:          "1283",
+		Counter:      "7",
+		GasLimit:     "10307",
+		StorageLimit: "0",
+		Amount:       "20000000000",
+		Destination:  "tz1aWXP237BLwNHJcCD4b3DutCevhqq2T1Z9",
+	}
+
+	op, err := Encode("BLyvCRkxuTXkx1KeGvrcEXiPYj4p1tFxzvFDhoHE7SFKtmP1rbk", transaction.ToContent())
+	testutils.CheckErr(t, false, "", err)
+
+	signed, err := EncodeAndSign(&key, "BLyvCRkxuTXkx1KeGvrcEXiPYj4p1tFxzvFDhoHE7SFKtmP1rbk", transaction.ToContent())
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, op, signed[:len(op)])
+
+	v, _ := hex.DecodeString(op)
+	signature, _ := hex.DecodeString(signed[len(op):])
+	assert.True(t, key.Verify(keys.VerifyInput{
+		BytesData:      v,
+		BytesSignature: signature,
+	}))
+}
+
+func Test_Forge_ManagerOperations(t *testing.T) {
+	branch := "BLyvCRkxuTXkx1KeGvrcEXiPYj4p1tFxzvFDhoHE7SFKtmP1rbk"
+	source := "tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e"
+	contract := "KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9"
+	rollup := b58.MustEncode(b58.SmartRollupHash, make([]byte, 20))
+	commitment := b58.MustEncode(b58.SmartRollupCommitmentHash, make([]byte, 32))
+	state := b58.MustEncode(b58.SmartRollupStateHash, make([]byte, 32))
+
+	value := json.RawMessage(`{"prim":"Pair","args":[{"int":"1"},{"string":"foo"}]}`)
+	ty := json.RawMessage(`{"prim":"string"}`)
+	contents := json.RawMessage(`{"string":"ticket"}`)
+
+	manager := func(kind rpc.Kind) rpc.Content {
+		return rpc.Content{Kind: kind, Source: source, Fee: "1000", Counter: "12", GasLimit: "10000", StorageLimit: "257"}
+	}
+
+	with := func(c rpc.Content, f func(c *rpc.Content)) rpc.Content {
+		f(&c)
+		return c
+	}
+
+	type input struct {
+		protocol string
+		content  rpc.Content
+	}
+
+	type want struct {
+		wantErr     bool
+		containsErr string
+		tag         string
+		suffix      string
+	}
+
+	cases := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			"is successful with register_global_constant",
+			input{quebec, with(manager(rpc.REGISTERGLOBALCONSTANT), func(c *rpc.Content) { c.Value = &value })},
+			want{false, "", "6f", "0000000c070700010100000003666f6f"},
+		},
+		{
+			"is successful with set_deposits_limit",
+			input{ithaca, with(manager(rpc.SETDEPOSITSLIMIT), func(c *rpc.Content) { c.Limit = "1000000" })},
+			want{false, "", "70", "ffc0843d"},
+		},
+		{
+			"is successful with set_deposits_limit without limit",
+			input{ithaca, manager(rpc.SETDEPOSITSLIMIT)},
+			want{false, "", "70", "00"},
+		},
+		{
+			"handles set_deposits_limit on Paris",
+			input{"PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ", manager(rpc.SETDEPOSITSLIMIT)},
+			want{true, "kind 'set_deposits_limit' is not supported by Paris", "", ""},
+		},
+		{
+			"is successful with increase_paid_storage",
+			input{quebec, with(manager(rpc.INCREASEPAIDSTORAGE), func(c *rpc.Content) { c.Amount = "100"; c.Destination = contract })},
+			want{false, "", "71", "a401" + "6498b7494a18a572c1d24484038545662c0454ed00"},
+		},
+		{
+			"handles increase_paid_storage to an implicit account",
+			input{quebec, with(manager(rpc.INCREASEPAIDSTORAGE), func(c *rpc.Content) { c.Amount = "100"; c.Destination = source })},
+			want{true, "is not an originated contract", "", ""},
+		},
+		{
+			"is successful with update_consensus_key",
+			input{lima, with(manager(rpc.UPDATECONSENSUSKEY), func(c *rpc.Content) { c.Pk = "edpkvEoAbkdaGALxi2FfeefB8hUkMZ4J1UVwkzyumx2GvbVpkYUHnm" })},
+			want{false, "", "72", ""},
+		},
+		{
+			"is successful with drain_delegate",
+			input{lima, rpc.Content{Kind: rpc.DRAINDELEGATE, ConsensusKey: source, Delegate: source, Destination: "tz2Ch1abG7FNiibmV26Uzgdsnfni9XGrk5wD"}},
+			want{false, "", "09", "012ffebbf1560632ca767bc960ccdb84669d284c2c"},
+		},
+		{
+			"handles drain_delegate before Lima",
+			input{kathmandu, rpc.Content{Kind: rpc.DRAINDELEGATE, ConsensusKey: source, Delegate: source, Destination: source}},
+			want{true, "kind 'drain_delegate' is not supported by Kathmandu", "", ""},
+		},
+		{
+			"is successful with transfer_ticket",
+			input{quebec, with(manager(rpc.TRANSFERTICKET), func(c *rpc.Content) {
+				c.TicketContents, c.TicketTy, c.TicketTicketer, c.TicketAmount = &contents, &ty, contract, "5"
+				c.Destination, c.Entrypoint = contract, "receive"
+			})},
+			want{false, "", "9e", "05016498b7494a18a572c1d24484038545662c0454ed0000000007" + hex.EncodeToString([]byte("receive"))},
+		},
+		{
+			"is successful with smart_rollup_originate on Mumbai",
+			input{"PtMumbai2TmsJHNGRkD8v8YDbtao7BLUC3wjASn1inAKLFCjaH1", with(manager(rpc.SMARTROLLUPORIGINATE), func(c *rpc.Content) {
+				c.PvmKind, c.Kernel, c.OriginationProof, c.ParametersTy = "wasm_2_0_0", "23212f", "cafe", &ty
+			})},
+			want{false, "", "c8", "0100000003" + "23212f" + "00000002cafe" + "000000020368"},
+		},
+		{
+			"is successful with smart_rollup_originate on Quebec",
+			input{quebec, with(manager(rpc.SMARTROLLUPORIGINATE), func(c *rpc.Content) {
+				c.PvmKind, c.Kernel, c.ParametersTy, c.Whitelist = "arith", "", &ty, []string{source}
+			})},
+			want{false, "", "c8", "0000000000" + "000000020368" + "ff00000015" + "00" + "1fb7d0a599ddca61b88dc203eeefbac341422cdf"},
+		},
+		{
+			"handles invalid pvm kind",
+			input{quebec, with(manager(rpc.SMARTROLLUPORIGINATE), func(c *rpc.Content) { c.PvmKind, c.ParametersTy = "evm", &ty })},
+			want{true, "invalid pvm kind 'evm'", "", ""},
+		},
+		{
+			"is successful with smart_rollup_add_messages",
+			input{quebec, with(manager(rpc.SMARTROLLUPADDMESSAGES), func(c *rpc.Content) { c.Message = []string{"01", "0203"} })},
+			want{false, "", "c9", "0000000b" + "0000000101" + "000000020203"},
+		},
+		{
+			"is successful with smart_rollup_cement on Nairobi",
+			input{"PtNairobiyssHuh87hEhfVBGCVrK3WnS8Z2FT4ymB5tAa4r1nQf", with(manager(rpc.SMARTROLLUPCEMENT), func(c *rpc.Content) {
+				c.Rollup, c.Commitment = rollup, &rpc.SmartRollupCommitment{Hash: commitment}
+			})},
+			want{false, "", "ca", hex.EncodeToString(make([]byte, 52))},
+		},
+		{
+			"is successful with smart_rollup_cement on Quebec",
+			input{quebec, with(manager(rpc.SMARTROLLUPCEMENT), func(c *rpc.Content) { c.Rollup = rollup })},
+			want{false, "", "ca", "8102" + hex.EncodeToString(make([]byte, 20))},
+		},
+		{
+			"is successful with smart_rollup_publish",
+			input{quebec, with(manager(rpc.SMARTROLLUPPUBLISH), func(c *rpc.Content) {
+				c.Rollup = rollup
+				c.Commitment = &rpc.SmartRollupCommitment{CompressedState: state, InboxLevel: 300, Predecessor: commitment, NumberOfTicks: "880000000000"}
+			})},
+			want{false, "", "cb", "0000012c" + hex.EncodeToString(make([]byte, 32)) + "000000cce4166000"},
+		},
+		{
+			"is successful with smart_rollup_execute_outbox_message",
+			input{quebec, with(manager(rpc.SMARTROLLUPEXECUTEOUTBOXMESSAGE), func(c *rpc.Content) {
+				c.Rollup, c.CementedCommitment, c.OutputProof = rollup, commitment, "0a0b"
+			})},
+			want{false, "", "ce", "000000020a0b"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			p := MustProtocol(tt.input.protocol)
+			op, err := p.Encode(branch, tt.input.content)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			if tt.want.wantErr {
+				return
+			}
+
+			assert.Equal(t, tt.want.tag, op[64:66])
+			assert.True(t, strings.HasSuffix(op, tt.want.suffix), "%s doesn't end with %s", op, tt.want.suffix)
+
+			_, decoded, err := p.Decode(op)
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, rpc.Contents{tt.input.content}, decoded)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package forge
+
+import (
+	"encoding/hex"
+
+	"github.com/goat-systems/go-tezos/v3/b58"
+	"github.com/goat-systems/go-tezos/v3/rpc"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+/*
+OperationHash returns the hash (o...) of a signed operation, e.g. the result of EncodeAndSign, which is the hash
+returned by InjectionOperation once the operation is injected.
+
+Parameters:
+
+	signedOperation:
+		The hex encoded signed operation.
+*/
+func OperationHash(signedOperation string) (string, error) {
+	v, err := hex.DecodeString(signedOperation)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash operation")
+	}
+
+	hash := blake2b.Sum256(v)
+	return b58.MustEncode(b58.OperationHash, hash[:]), nil
+}
+
+/*
+OriginatedContract returns the address (KT1...) of a contract originated by an operation.
+
+Note:
+	Originations are numbered from 0 in the order they are applied, including the originations of internal
+	operations, e.g. the second origination of the operation has index 1.
+
+Parameters:
+
+	operationHash:
+		The hash of the operation originating the contract.
+
+	index:
+		The index of the origination in the operation.
+*/
+func OriginatedContract(operationHash string, index int) (string, error) {
+	hash, err := b58.DecodeAs(operationHash, b58.OperationHash)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to compute originated contract")
+	}
+
+	h, err := blake2b.New(b58.ContractHash.Length, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to compute originated contract")
+	}
+	h.Write(hash)
+	h.Write(forgeInt32(index, 4))
+
+	return b58.MustEncode(b58.ContractHash, h.Sum(nil)), nil
+}
+
+/*
+BlockHash returns the hash (B...) of a block from its forged header.
+
+Parameters:
+
+	header:
+		The hex encoded header of the block, its shell header followed by its signed protocol data.
+*/
+func BlockHash(header string) (string, error) {
+	v, err := hex.DecodeString(header)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash block header")
+	}
+
+	hash := blake2b.Sum256(v)
+	return b58.MustEncode(b58.BlockHash, hash[:]), nil
+}
+
+/*
+BlockHash returns the hash (B...) of a block header forged for the protocol.
+
+Parameters:
+
+	header:
+		The signed header of the block.
+*/
+func (p *Protocol) BlockHash(header rpc.BlockHeader) (string, error) {
+	v, err := p.forgeBlockHeader(header)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash block header")
+	}
+
+	// forgeBlockHeader prefixes the header with its length, which isn't hashed.
+	hash := blake2b.Sum256(v[4:])
+	return b58.MustEncode(b58.BlockHash, hash[:]), nil
+}
+
+/*
+OperationListHash returns the hash (Lo...) of a list of operations, the Merkle tree root of the hashes of
+the operations of a validation pass.
+
+Parameters:
+
+	operationHashes:
+		The hashes of the operations in the order they are in the block.
+*/
+func OperationListHash(operationHashes []string) (string, error) {
+	hash, err := operationListHash(operationHashes)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash operation list")
+	}
+
+	return b58.MustEncode(b58.OperationListHash, hash), nil
+}
+
+/*
+OperationListListHash returns the hash (LLo...) of the lists of operations of a block, the operations_hash of its
+header.
+
+Parameters:
+
+	operationHashes:
+		The hashes of the operations of each validation pass, e.g. the result of OperationHashes.
+*/
+func OperationListListHash(operationHashes [][]string) (string, error) {
+	leaves := make([][]byte, len(operationHashes))
+	for i, list := range operationHashes {
+		hash, err := operationListHash(list)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to hash operation list list: list %d", i)
+		}
+		leaves[i] = hash
+	}
+
+	return b58.MustEncode(b58.OperationListListHash, merkleRoot(leaves)), nil
+}
+
+func operationListHash(operationHashes []string) ([]byte, error) {
+	leaves := make([][]byte, len(operationHashes))
+	for i, operationHash := range operationHashes {
+		v, err := b58.DecodeAs(operationHash, b58.OperationHash)
+		if err != nil {
+			return nil, err
+		}
+		leaves[i] = v
+	}
+
+	return merkleRoot(leaves), nil
+}
+
+// merkleRoot computes the root of the Merkle tree of leaves, padded to a power of two with the last leaf.
+func merkleRoot(leaves [][]byte) []byte {
+	if len(leaves) == 0 {
+		hash := blake2b.Sum256(nil)
+		return hash[:]
+	}
+
+	size := 1
+	for size < len(leaves) {
+		size *= 2
+	}
+
+	nodes := make([][]byte, size)
+	for i := range nodes {
+		leaf := leaves[len(leaves)-1]
+		if i < len(leaves) {
+			leaf = leaves[i]
+		}
+
+		hash := blake2b.Sum256(leaf)
+		nodes[i] = hash[:]
+	}
+
+	for len(nodes) > 1 {
+		parents := make([][]byte, len(nodes)/2)
+		for i := range parents {
+			hash := blake2b.Sum256(append(append([]byte{}, nodes[2*i]...), nodes[2*i+1]...))
+			parents[i] = hash[:]
+		}
+		nodes = parents
+	}
+
+	return nodes[0]
+}
@@ -0,0 +1,153 @@
+package forge
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/goat-systems/go-tezos/v3/b58"
+	"github.com/goat-systems/go-tezos/v3/internal/testutils"
+	"github.com/goat-systems/go-tezos/v3/keys"
+	"github.com/goat-systems/go-tezos/v3/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+// Operations of block BLBL72xDLHf4ffKu8NZhYnqy21DECDkZ3Vpjw7oZJDhbgySzwFT on mainnet.
+var blockOperationHashes = [][]string{
+	{
+		"ooy6DvwxByrtWiE5uatSdudCKJongCdUUXkZqrb67r2eGSAgdaU", "oo8XCLosHE41yS5ZwTw1NpccZrm3YRK55MZe9DbtnHGiENXuNA8",
+		"ooj4f2eEuQfwCcM2uCMuSmMUyD3CTDLGfkRnYxxtJaiPZrNxT3y", "oonPqdvh5dLzDupofjqbJJd5HpY4ZRWxXajcrWtwE76fGV8P2um",
+		"opS69K2jSPz6J1SAA62jBYYXS3Hr4vKJcufm2KKncYdWsWc5ngz", "ooq3T61AU96dpGY8EBnZ99dBU7zU5f2H4wfTPKeihySQv1pdu1A",
+		"op8XzGyQRwEDJYo1n3uRHMWDa5ifyYueConMySSem8ZKntLHCcn", "onowr5ggr7RVDoYzGWqiHxuSrTrkp6dyLNBDxFQbY7rfNHC9JuH",
+		"ooackYPhYeikE4Hbjajoew73ss38xVaYQDjV8qTJS5eUsNYETYd", "ooUfHkicskZBwsKtjNZ8q6aJeuBgUUCXgqcxFoCEkiQ9kpviYHS",
+		"ooKzXBBYgvzAYwnTSjEYmnmUqU2rn2fBDq996nSmhX1qJL9J3Mk", "opLmUCAKYrt2NngdAVqcaP1Gxz9A1zoZ74Cqk3CL5DAh25YHwtq",
+		"ooamSeYX35EQSAjoSWP4uVycvsvALF5MidQp6wSFz9DiasjfHSD", "oog3Yx6jucfexN2TYk4EarqBozpgoekZZqAJ7hroKCvdY7sU1fU",
+		"ooLG83KNxArZSSssmGeU28zCiaCPmKfCpGagHMRHM7QF8o1xqfc", "ooqA1VChhznS3isikZuwASCbWNNGBqktJVDm3b1YHxEveCvw4vf",
+		"oobfWVyvrSsNzByHpTgHFfAHVXqyfWadrFJm7UHCCCdPtp2NTc8", "onsSt4s256ZSciCcmE9aRjVn9oq8vvsHbS53BddCSo46PsYUqkM",
+		"oo4jkbNSEpdTwnCGSrvZUkSjuL82XrD5SvHHNiqPpvDxbVE3U9F", "onpvuqEWyuk9Ks9TD6EgDydaSZaJhjX3LUCkxtyWz8Vk9KQ88sp",
+		"opKaPi4zwDSR2WQBX616r3LSpbsPeUNX9YN7eqEBkMC3MUxAJ74", "oooXhALtpdWAwaWvGoWaYTsLbghHKRrvTM9jfhtPsikFr3mz65c",
+		"onmgcLbuNabVej82x47rc9qKpXsUdWTyj9bfKfaXEGyKf8pphrT",
+	},
+	{},
+	{},
+	{
+		"oozWCsudcyv9vdp8xzpBNLeaogU6fNHg4ikKYonJYhJiC7jw1W7", "ooQu7s2rxdYe2qQB116gTBCJA6h9QWiqW6tecYrGajh2bMGgBCW",
+		"onsWKc7s1YyPoFTmXpK7hqZnT579bWcTpnWNUnfgaBYwh6wHkej", "oowkMu3yTAUBYRcshvbvzMd1soQ1hws6auEh9fkDnTbstbhsjUv",
+	},
+}
+
+func Test_OperationHash(t *testing.T) {
+	operation, err := MustProtocol(carthage).Encode("BM4SD3ePyXC9DoiksYEeT3MYReeFaqh68CkuPPjiEtAxagkViYU", rpc.Content{Kind: rpc.ENDORSEMENT, Level: 1064977})
+	testutils.CheckErr(t, false, "", err)
+
+	signature, err := keys.ParseSignature("sigwHRS2Pz6pbg7AibHYXMS8cqrBageUdbA6a3axWnkt7BwAfFxbi6LZMBTo9WHstV9ZFAt9RJwaxCnmH2PCHMmvRKoYpmfk")
+	testutils.CheckErr(t, false, "", err)
+
+	hash, err := OperationHash(operation + hex.EncodeToString(signature.Bytes))
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, "ooy6DvwxByrtWiE5uatSdudCKJongCdUUXkZqrb67r2eGSAgdaU", hash)
+
+	_, err = OperationHash("not_hex")
+	testutils.CheckErr(t, true, "failed to hash operation", err)
+}
+
+func Test_OriginatedContract(t *testing.T) {
+	operationHash := "ooy6DvwxByrtWiE5uatSdudCKJongCdUUXkZqrb67r2eGSAgdaU"
+
+	first, err := OriginatedContract(operationHash, 0)
+	testutils.CheckErr(t, false, "", err)
+
+	_, err = b58.DecodeAs(first, b58.ContractHash)
+	testutils.CheckErr(t, false, "", err)
+
+	second, err := OriginatedContract(operationHash, 1)
+	testutils.CheckErr(t, false, "", err)
+	assert.NotEqual(t, first, second)
+
+	_, err = OriginatedContract("BM4SD3ePyXC9DoiksYEeT3MYReeFaqh68CkuPPjiEtAxagkViYU", 0)
+	testutils.CheckErr(t, true, "failed to compute originated contract", err)
+}
+
+func Test_BlockHash(t *testing.T) {
+	header := rpc.BlockHeader{
+		Level:            1064978,
+		Proto:            6,
+		Predecessor:      "BM4SD3ePyXC9DoiksYEeT3MYReeFaqh68CkuPPjiEtAxagkViYU",
+		Timestamp:        time.Date(2020, 8, 1, 7, 34, 23, 0, time.UTC),
+		ValidationPass:   4,
+		OperationsHash:   "LLoaG8QGjYYR4hBA5i15XmfY9RuTVsRYfKawJ7BxxzWeBDKpRNbGK",
+		Fitness:          []string{"01", "0000000000064012"},
+		Context:          "CoW1NHCDngqDhqEApiLEwyd1oEfy6NHHPwjbUjmET6SgB4AiAVbV",
+		ProofOfWorkNonce: "a21a26e6f3940000",
+		Signature:        "sighZwxywuqaCrPbJEqmsy9xkAAbAkYB6rRpom3bBz7maMHVXCHzvKJutbQaBe8eXyx73pd1ovVK1jm9oQ3PUuYGC3k7Ucoi",
+	}
+
+	hash, err := MustProtocol(carthage).BlockHash(header)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, "BLBL72xDLHf4ffKu8NZhYnqy21DECDkZ3Vpjw7oZJDhbgySzwFT", hash)
+
+	forged, err := MustProtocol(carthage).forgeBlockHeader(header)
+	testutils.CheckErr(t, false, "", err)
+
+	hash, err = BlockHash(hex.EncodeToString(forged[4:]))
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, "BLBL72xDLHf4ffKu8NZhYnqy21DECDkZ3Vpjw7oZJDhbgySzwFT", hash)
+
+	header.Signature = ""
+	_, err = MustProtocol(carthage).BlockHash(header)
+	testutils.CheckErr(t, true, "failed to hash block header", err)
+}
+
+func Test_OperationListListHash(t *testing.T) {
+	type want struct {
+		wantErr     bool
+		containsErr string
+		hash        string
+	}
+
+	cases := []struct {
+		name  string
+		input [][]string
+		want  want
+	}{
+		{
+			"is successful with block operations",
+			blockOperationHashes,
+			want{false, "", "LLoaG8QGjYYR4hBA5i15XmfY9RuTVsRYfKawJ7BxxzWeBDKpRNbGK"},
+		},
+		{
+			"is successful with empty validation passes",
+			[][]string{{}, {}, {}, {}},
+			want{false, "", "LLoa7bxRTKaQN2bLYoitYB6bU2DvLnBAqrVjZcvJ364cTcX2PZYKU"},
+		},
+		{
+			"is successful without validation passes",
+			[][]string{},
+			want{false, "", "LLoZS2LW3rEi7KYU4ouBQtorua37aWWCtpDmv1n2x3xoKi6sVXLWp"},
+		},
+		{
+			"handles invalid operation hash",
+			[][]string{{}, {"BM4SD3ePyXC9DoiksYEeT3MYReeFaqh68CkuPPjiEtAxagkViYU"}},
+			want{true, "failed to hash operation list list: list 1", ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := OperationListListHash(tt.input)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.hash, hash)
+		})
+	}
+}
+
+func Test_OperationListHash(t *testing.T) {
+	hash, err := OperationListHash(blockOperationHashes[3])
+	testutils.CheckErr(t, false, "", err)
+
+	_, err = b58.DecodeAs(hash, b58.OperationListHash)
+	testutils.CheckErr(t, false, "", err)
+
+	_, err = OperationListHash([]string{"invalid"})
+	testutils.CheckErr(t, true, "failed to hash operation list", err)
+}
@@ -0,0 +1,247 @@
+package forge
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+/*
+DecodeMicheline unforges binary Micheline to its JSON representation.
+
+Parameters:
+
+	micheline:
+		The hex encoded binary Micheline expression, e.g. the value of transaction parameters.
+*/
+func DecodeMicheline(micheline string) (*json.RawMessage, error) {
+	v, err := hex.DecodeString(micheline)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge micheline")
+	}
+
+	raw, err := unforgeMicheline(v, latestProtocol)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge micheline")
+	}
+
+	return raw, nil
+}
+
+/*
+DecodePacked unforges packed data (as returned by PACK or the pack_data RPC) to its JSON representation.
+
+Note:
+	Packed data is binary Micheline prefixed by 0x05. Values packed with an optimized encoding are returned
+	as they are encoded, e.g. addresses are returned as bytes.
+
+Parameters:
+
+	packed:
+		The hex encoded packed data, e.g. 050a000000160000...
+*/
+func DecodePacked(packed string) (*json.RawMessage, error) {
+	v, err := hex.DecodeString(packed)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge packed data")
+	}
+
+	if len(v) == 0 || v[0] != 0x05 {
+		return nil, errors.New("failed to unforge packed data: missing 0x05 prefix")
+	}
+
+	raw, err := unforgeMicheline(v[1:], latestProtocol)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge packed data")
+	}
+
+	return raw, nil
+}
+
+func unforgeMicheline(v []byte, p *Protocol) (*json.RawMessage, error) {
+	d := &decoder{v: v, p: p}
+	node, err := d.michelineNode()
+	if err != nil {
+		return nil, err
+	}
+
+	if !d.empty() {
+		return nil, errors.New("unexpected trailing bytes")
+	}
+
+	out, err := json.Marshal(node)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal micheline")
+	}
+
+	raw := json.RawMessage(out)
+	return &raw, nil
+}
+
+// zarith reads a signed zarith number.
+func (d *decoder) zarith() (string, error) {
+	b, err := d.byte()
+	if err != nil {
+		return "", err
+	}
+
+	negative := b&0x40 != 0
+	n := big.NewInt(int64(b & 0x3f))
+	for shift := uint(6); b&0x80 != 0; shift += 7 {
+		if b, err = d.byte(); err != nil {
+			return "", err
+		}
+
+		if b == 0 {
+			return "", errors.New("invalid zarith: trailing zero")
+		}
+		n.Or(n, new(big.Int).Lsh(big.NewInt(int64(b&0x7f)), shift))
+	}
+
+	if negative {
+		n.Neg(n)
+	}
+
+	return n.String(), nil
+}
+
+type michelinePrim struct {
+	Prim   string        `json:"prim"`
+	Args   []interface{} `json:"args,omitempty"`
+	Annots []string      `json:"annots,omitempty"`
+}
+
+type michelineInt struct {
+	Int string `json:"int"`
+}
+
+type michelineString struct {
+	String string `json:"string"`
+}
+
+type michelineBytes struct {
+	Bytes string `json:"bytes"`
+}
+
+func (d *decoder) michelineNode() (interface{}, error) {
+	tag, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag {
+	case 0x00:
+		i, err := d.zarith()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unforge int")
+		}
+		return michelineInt{Int: i}, nil
+	case 0x01:
+		s, err := d.array()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unforge string")
+		}
+		return michelineString{String: string(s)}, nil
+	case 0x02:
+		v, err := d.array()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unforge sequence")
+		}
+		return (&decoder{v: v, p: d.p}).michelineSeq()
+	case 0x03, 0x04, 0x05, 0x06, 0x07, 0x08:
+		prim, err := d.prim()
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < int(tag-0x03)/2; i++ {
+			arg, err := d.michelineNode()
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to unforge args of '%s'", prim.Prim)
+			}
+			prim.Args = append(prim.Args, arg)
+		}
+
+		if (tag-0x03)%2 == 1 {
+			if prim.Annots, err = d.annots(); err != nil {
+				return nil, errors.Wrapf(err, "failed to unforge annots of '%s'", prim.Prim)
+			}
+		}
+
+		return prim, nil
+	case 0x09:
+		prim, err := d.prim()
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := d.array()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unforge args of '%s'", prim.Prim)
+		}
+
+		args, err := (&decoder{v: v, p: d.p}).michelineSeq()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unforge args of '%s'", prim.Prim)
+		}
+		prim.Args = args
+
+		if prim.Annots, err = d.annots(); err != nil {
+			return nil, errors.Wrapf(err, "failed to unforge annots of '%s'", prim.Prim)
+		}
+
+		return prim, nil
+	case 0x0A:
+		v, err := d.array()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unforge bytes")
+		}
+		return michelineBytes{Bytes: hex.EncodeToString(v)}, nil
+	}
+
+	return nil, fmt.Errorf("invalid micheline tag %d", tag)
+}
+
+func (d *decoder) michelineSeq() ([]interface{}, error) {
+	seq := []interface{}{}
+	for !d.empty() {
+		node, err := d.michelineNode()
+		if err != nil {
+			return nil, err
+		}
+		seq = append(seq, node)
+	}
+
+	return seq, nil
+}
+
+func (d *decoder) prim() (michelinePrim, error) {
+	tag, err := d.byte()
+	if err != nil {
+		return michelinePrim{}, errors.Wrap(err, "failed to unforge prim")
+	}
+
+	prim, ok := d.p.primitiveNames[tag]
+	if !ok {
+		return michelinePrim{}, fmt.Errorf("invalid prim tag %d", tag)
+	}
+
+	return michelinePrim{Prim: prim}, nil
+}
+
+func (d *decoder) annots() ([]string, error) {
+	v, err := d.array()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(v) == 0 {
+		return nil, nil
+	}
+
+	return strings.Split(string(v), " "), nil
+}
@@ -0,0 +1,242 @@
+package forge
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v3/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fastjson"
+)
+
+func Test_DecodeMicheline(t *testing.T) {
+	type want struct {
+		wantErr     bool
+		containsErr string
+		micheline   string
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want  want
+	}{
+		{
+			"is successful with int",
+			"0001",
+			want{false, "", `{"int":"1"}`},
+		},
+		{
+			"is successful with negative int",
+			"00ec04",
+			want{false, "", `{"int":"-300"}`},
+		},
+		{
+			"is successful with string",
+			"0100000003666f6f",
+			want{false, "", `{"string":"foo"}`},
+		},
+		{
+			"is successful with bytes",
+			"0a0000000200ff",
+			want{false, "", `{"bytes":"00ff"}`},
+		},
+		{
+			"is successful with empty sequence",
+			"0200000000",
+			want{false, "", `[]`},
+		},
+		{
+			"is successful with prim without args",
+			"030b",
+			want{false, "", `{"prim":"Unit"}`},
+		},
+		{
+			"is successful with prim with annots",
+			"046c0000000425616263",
+			want{false, "", `{"prim":"unit","annots":["%abc"]}`},
+		},
+		{
+			"is successful with prim with one arg and annots",
+			"0500046c0000000425616263",
+			want{false, "", `{"prim":"parameter","args":[{"prim":"unit","annots":["%abc"]}]}`},
+		},
+		{
+			"is successful with prim with two args",
+			"070700010100000003666f6f",
+			want{false, "", `{"prim":"Pair","args":[{"int":"1"},{"string":"foo"}]}`},
+		},
+		{
+			"is successful with prim with two args and annots",
+			"0865036c0362000000052570202571",
+			want{false, "", `{"prim":"pair","args":[{"prim":"unit"},{"prim":"nat"}],"annots":["%p","%q"]}`},
+		},
+		{
+			"is successful with generic prim",
+			"09070000000600010002000300000000",
+			want{false, "", `{"prim":"Pair","args":[{"int":"1"},{"int":"2"},{"int":"3"}]}`},
+		},
+		{
+			"is successful with generic prim with annots",
+			"09070000000600010002000300000002256b",
+			want{false, "", `{"prim":"Pair","args":[{"int":"1"},{"int":"2"},{"int":"3"}],"annots":["%k"]}`},
+		},
+		{
+			"is successful with origination code",
+			"020000001f0500046c00000004256162630501036c050202000000080317053d036d0342",
+			want{false, "", `[{"prim":"parameter","args":[{"prim":"unit","annots":["%abc"]}]},{"prim":"storage","args":[{"prim":"unit"}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`},
+		},
+		{
+			"is successful with origination code 2",
+			"02000000ea0500036c0501036c050202000000db0321051f0200000002031703160743036e01000000244b54314d384d5374774131523553754778325636414d7667643864474146596345556d7505550764085e036c055f036d0000000325646f046c000000082564656661756c74072f020000001807430368010000000d74797065206d69736d6174636803270200000051020000000f071f00020200000002032105700003053d036d020000000f071f00020200000002032105700003071f0003020000000203200743036a0080897a034f0544075e036c055f036d034d031b0342051f020000000403200320",
+			want{false, "", ""},
+		},
+		{
+			"handles invalid tag",
+			"0b",
+			want{true, "invalid micheline tag 11", ""},
+		},
+		{
+			"handles invalid prim",
+			"03ff",
+			want{true, "invalid prim tag 255", ""},
+		},
+		{
+			"handles truncated sequence",
+			"0200000004030b",
+			want{true, "unexpected end of bytes", ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			micheline, err := DecodeMicheline(tt.input)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			if tt.want.wantErr {
+				return
+			}
+
+			if tt.want.micheline != "" {
+				assert.JSONEq(t, tt.want.micheline, string(*micheline))
+			}
+
+			v, err := fastjson.ParseBytes(*micheline)
+			testutils.CheckErr(t, false, "", err)
+
+			forged, err := latestProtocol.forgeMicheline(v)
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, tt.input, hex.EncodeToString(forged))
+		})
+	}
+}
+
+func Test_DecodePacked(t *testing.T) {
+	type want struct {
+		wantErr     bool
+		containsErr string
+		micheline   string
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want  want
+	}{
+		{
+			"is successful with address",
+			"050a0000001600001fb7d0a599ddca61b88dc203eeefbac341422cdf",
+			want{false, "", `{"bytes":"00001fb7d0a599ddca61b88dc203eeefbac341422cdf"}`},
+		},
+		{
+			"is successful with pair",
+			"05070700010100000003666f6f",
+			want{false, "", `{"prim":"Pair","args":[{"int":"1"},{"string":"foo"}]}`},
+		},
+		{
+			"handles missing prefix",
+			"0a0000001600001fb7d0a599ddca61b88dc203eeefbac341422cdf",
+			want{true, "missing 0x05 prefix", ""},
+		},
+		{
+			"handles empty data",
+			"",
+			want{true, "missing 0x05 prefix", ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			micheline, err := DecodePacked(tt.input)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			if !tt.want.wantErr {
+				assert.JSONEq(t, tt.want.micheline, string(*micheline))
+			}
+		})
+	}
+}
+
+func Test_forgeMicheline(t *testing.T) {
+	_, err := latestProtocol.forgeMicheline(fastjson.MustParse(`{"prim":"NOT_A_PRIM"}`))
+	testutils.CheckErr(t, true, "unknown prim 'NOT_A_PRIM'", err)
+
+	forged, err := latestProtocol.forgeMicheline(fastjson.MustParse(`{"prim":"Pair","args":[{"int":"1"},{"int":"2"},{"int":"3"},{"int":"4"}]}`))
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, "090700000008000100020003000400000000", hex.EncodeToString(forged))
+
+	forged, err = latestProtocol.forgeMicheline(fastjson.MustParse(`{"int":"-1000000000000000000000000"}`))
+	testutils.CheckErr(t, false, "", err)
+
+	decoded, err := DecodeMicheline(hex.EncodeToString(forged))
+	testutils.CheckErr(t, false, "", err)
+	assert.JSONEq(t, `{"int":"-1000000000000000000000000"}`, string(*decoded))
+
+	_, err = latestProtocol.forgeMicheline(fastjson.MustParse(`{"int":"1.5"}`))
+	testutils.CheckErr(t, true, "failed to forge \"int\"", err)
+}
+
+func Test_Zarith(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+		int   string
+		nat   string
+	}{
+		{"is successful with zero", "0", "00", "00"},
+		{"is successful with one", "1", "01", "01"},
+		{"is successful with minus one", "-1", "41", ""},
+		{"is successful with 6 bits", "63", "3f", "3f"},
+		{"is successful with 7 bits", "64", "8001", "40"},
+		{"is successful with negative 7 bits", "-64", "c001", ""},
+		{"is successful with 8 bits", "128", "8002", "8001"},
+		{"is successful with 1000000", "1000000", "80897a", "c0843d"},
+		{"is successful with 2^64", "18446744073709551616", "80808080808080808004", "80808080808080808002"},
+		{"is successful with -2^64", "-18446744073709551616", "c0808080808080808004", ""},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			n, ok := new(big.Int).SetString(tt.value, 10)
+			assert.True(t, ok)
+
+			forged := forgeInt(n)
+			assert.Equal(t, tt.int, hex.EncodeToString(forged))
+
+			i, err := (&decoder{v: forged}).zarith()
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, tt.value, i)
+
+			nat, err := forgeNat(tt.value)
+			if tt.nat == "" {
+				testutils.CheckErr(t, true, "cannot be negative", err)
+				return
+			}
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, tt.nat, hex.EncodeToString(nat))
+
+			decoded, err := (&decoder{v: nat}).nat()
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, tt.value, decoded)
+		})
+	}
+}
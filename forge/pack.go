@@ -0,0 +1,571 @@
+package forge
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/goat-systems/go-tezos/v3/address"
+	"github.com/goat-systems/go-tezos/v3/b58"
+	"github.com/pkg/errors"
+	"github.com/valyala/fastjson"
+)
+
+// packedPublicKeys are the prefixes of public keys indexed by their tag in the optimized encoding.
+var packedPublicKeys = []b58.Prefix{b58.Ed25519PublicKey, b58.Secp256k1PublicKey, b58.P256PublicKey, b58.BLS12_381PublicKey}
+
+/*
+Pack packs Michelson data the same way the PACK instruction does, using the type of the data to pick the
+optimized encoding of addresses, contracts, keys, key hashes, signatures, timestamps and chain ids.
+
+Note:
+	The result starts with 0x05. Pairs are packed as right combs of binary pairs, so Pair 1 2 3 and
+	{1; 2; 3} are packed the same as Pair 1 (Pair 2 3). Use rpc.ForgeScriptExpression to hash the
+	result into a big map key. Strings can only have printable ASCII characters and line feeds, as in Michelson.
+
+Parameters:
+
+	value:
+		The Micheline JSON of the data, e.g. {"string":"tz1..."}.
+
+	typ:
+		The Micheline JSON of the type of the data, e.g. {"prim":"address"}.
+*/
+func Pack(value, typ *json.RawMessage) ([]byte, error) {
+	if value == nil || typ == nil {
+		return nil, errors.New("failed to pack: missing value or type")
+	}
+
+	v, err := parseMicheline(*value)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pack value")
+	}
+
+	t, err := parseMicheline(*typ)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pack type")
+	}
+
+	optimized, err := packData(v, t)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pack")
+	}
+
+	out, err := marshalMicheline(optimized)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pack")
+	}
+
+	forged, err := latestProtocol.forgeMicheline(fastjson.MustParseBytes(out))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pack")
+	}
+
+	return append([]byte{0x05}, forged...), nil
+}
+
+/*
+Unpack unpacks data packed by Pack or the PACK instruction, using the type of the data to decode optimized
+encodings back to their readable form.
+
+Parameters:
+
+	packed:
+		The packed data, starting with 0x05.
+
+	typ:
+		The Micheline JSON of the type of the data, e.g. {"prim":"address"}.
+*/
+func Unpack(packed []byte, typ *json.RawMessage) (*json.RawMessage, error) {
+	if typ == nil {
+		return nil, errors.New("failed to unpack: missing type")
+	}
+
+	if len(packed) == 0 || packed[0] != 0x05 {
+		return nil, errors.New("failed to unpack: missing 0x05 prefix")
+	}
+
+	t, err := parseMicheline(*typ)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unpack type")
+	}
+
+	d := &decoder{v: packed[1:], p: latestProtocol}
+	v, err := d.michelineNode()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unpack")
+	}
+
+	if !d.empty() {
+		return nil, errors.New("failed to unpack: unexpected trailing bytes")
+	}
+
+	readable, err := unpackData(v, t)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unpack")
+	}
+
+	out, err := marshalMicheline(readable)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unpack")
+	}
+
+	raw := json.RawMessage(out)
+	return &raw, nil
+}
+
+func packData(v, t interface{}) (interface{}, error) {
+	typ, ok := t.(michelinePrim)
+	if !ok {
+		return nil, errors.Errorf("invalid type '%s'", marshalNode(t))
+	}
+
+	switch typ.Prim {
+	case "address", "contract":
+		s, ok := v.(michelineString)
+		if !ok {
+			return v, nil
+		}
+
+		v, err := packAddress(s.String)
+		if err != nil {
+			return nil, err
+		}
+		return michelineBytes{Bytes: hex.EncodeToString(v)}, nil
+	case "key_hash":
+		s, ok := v.(michelineString)
+		if !ok {
+			return v, nil
+		}
+
+		addr, err := address.Parse(s.String)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid key_hash")
+		}
+
+		v, err := addr.PublicKeyHashBytes()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid key_hash")
+		}
+		return michelineBytes{Bytes: hex.EncodeToString(v)}, nil
+	case "key":
+		s, ok := v.(michelineString)
+		if !ok {
+			return v, nil
+		}
+
+		prefix, v, err := b58.DecodePrefix(s.String, packedPublicKeys...)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid key")
+		}
+
+		for tag, p := range packedPublicKeys {
+			if p.Name == prefix.Name {
+				return michelineBytes{Bytes: hex.EncodeToString(append([]byte{byte(tag)}, v...))}, nil
+			}
+		}
+	case "signature":
+		s, ok := v.(michelineString)
+		if !ok {
+			return v, nil
+		}
+
+		_, v, err := b58.DecodePrefix(s.String, b58.Ed25519Signature, b58.Secp256k1Signature, b58.P256Signature, b58.BLS12_381Signature, b58.GenericSignature)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid signature")
+		}
+		return michelineBytes{Bytes: hex.EncodeToString(v)}, nil
+	case "chain_id":
+		s, ok := v.(michelineString)
+		if !ok {
+			return v, nil
+		}
+
+		v, err := b58.DecodeAs(s.String, b58.ChainID)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid chain_id")
+		}
+		return michelineBytes{Bytes: hex.EncodeToString(v)}, nil
+	case "timestamp":
+		s, ok := v.(michelineString)
+		if !ok {
+			return v, nil
+		}
+
+		ts, err := time.Parse(time.RFC3339, s.String)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid timestamp")
+		}
+		return michelineInt{Int: fmt.Sprintf("%d", ts.Unix())}, nil
+	case "string":
+		if s, ok := v.(michelineString); !ok || !printableASCII(s.String) {
+			return nil, errors.Errorf("invalid string '%s'", marshalNode(v))
+		}
+		return v, nil
+	case "int", "nat", "mutez":
+		i, ok := v.(michelineInt)
+		if !ok {
+			return nil, errors.Errorf("invalid %s '%s'", typ.Prim, marshalNode(v))
+		}
+
+		if n, ok := new(big.Int).SetString(i.Int, 10); !ok || (typ.Prim != "int" && n.Sign() < 0) {
+			return nil, errors.Errorf("invalid %s '%s'", typ.Prim, i.Int)
+		}
+		return v, nil
+	}
+
+	return mapData(v, typ, packData)
+}
+
+func unpackData(v, t interface{}) (interface{}, error) {
+	typ, ok := t.(michelinePrim)
+	if !ok {
+		return nil, errors.Errorf("invalid type '%s'", marshalNode(t))
+	}
+
+	b, isBytes := v.(michelineBytes)
+	var raw []byte
+	if isBytes {
+		raw, _ = hex.DecodeString(b.Bytes)
+	}
+
+	switch typ.Prim {
+	case "address", "contract":
+		if !isBytes {
+			return v, nil
+		}
+
+		if len(raw) < 2+address.HashLength {
+			return nil, errors.Errorf("invalid address '%s'", b.Bytes)
+		}
+
+		addr, err := address.FromContractBytes(raw[:2+address.HashLength])
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid address")
+		}
+
+		s := addr.String()
+		if entrypoint := raw[2+address.HashLength:]; len(entrypoint) > 0 {
+			s = fmt.Sprintf("%s%%%s", s, entrypoint)
+		}
+		return michelineString{String: s}, nil
+	case "key_hash":
+		if !isBytes {
+			return v, nil
+		}
+
+		addr, err := address.FromPublicKeyHashBytes(raw)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid key_hash")
+		}
+		return michelineString{String: addr.String()}, nil
+	case "key":
+		if !isBytes {
+			return v, nil
+		}
+
+		if len(raw) == 0 || int(raw[0]) >= len(packedPublicKeys) {
+			return nil, errors.Errorf("invalid key '%s'", b.Bytes)
+		}
+
+		s, err := b58.Encode(packedPublicKeys[raw[0]], raw[1:])
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid key")
+		}
+		return michelineString{String: s}, nil
+	case "signature":
+		if !isBytes {
+			return v, nil
+		}
+
+		prefix := b58.GenericSignature
+		if len(raw) == b58.BLS12_381Signature.Length {
+			prefix = b58.BLS12_381Signature
+		}
+
+		s, err := b58.Encode(prefix, raw)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid signature")
+		}
+		return michelineString{String: s}, nil
+	case "chain_id":
+		if !isBytes {
+			return v, nil
+		}
+
+		s, err := b58.Encode(b58.ChainID, raw)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid chain_id")
+		}
+		return michelineString{String: s}, nil
+	case "string":
+		if s, ok := v.(michelineString); !ok || !printableASCII(s.String) {
+			return nil, errors.Errorf("invalid string '%s'", marshalNode(v))
+		}
+		return v, nil
+	case "timestamp":
+		i, ok := v.(michelineInt)
+		if !ok {
+			return v, nil
+		}
+
+		n, ok := new(big.Int).SetString(i.Int, 10)
+		if !ok || !n.IsInt64() {
+			return v, nil
+		}
+
+		ts := time.Unix(n.Int64(), 0).UTC()
+		if ts.Year() < 0 || ts.Year() > 9999 {
+			return v, nil
+		}
+		return michelineString{String: ts.Format(time.RFC3339)}, nil
+	}
+
+	return mapData(v, typ, unpackData)
+}
+
+/*
+mapData applies f to the data nested in pairs, options, ors, lists, sets and maps, with the type of each
+nested value. Data of other types is returned as it is.
+*/
+func mapData(v interface{}, typ michelinePrim, f func(v, t interface{}) (interface{}, error)) (interface{}, error) {
+	switch typ.Prim {
+	case "pair":
+		if len(typ.Args) < 2 {
+			return nil, errors.Errorf("invalid type '%s'", marshalNode(typ))
+		}
+
+		left, right := typ.Args[0], interface{}(typ.Args[1])
+		if len(typ.Args) > 2 {
+			right = michelinePrim{Prim: "pair", Args: typ.Args[1:]}
+		}
+
+		l, r, err := splitPair(v)
+		if err != nil {
+			return nil, err
+		}
+
+		if l, err = f(l, left); err != nil {
+			return nil, err
+		}
+
+		if r, err = f(r, right); err != nil {
+			return nil, err
+		}
+
+		return michelinePrim{Prim: "Pair", Args: []interface{}{l, r}}, nil
+	case "option":
+		p, ok := v.(michelinePrim)
+		if ok && p.Prim == "None" && len(p.Args) == 0 {
+			return michelinePrim{Prim: "None"}, nil
+		}
+
+		if !ok || p.Prim != "Some" || len(p.Args) != 1 || len(typ.Args) != 1 {
+			return nil, errors.Errorf("invalid option '%s'", marshalNode(v))
+		}
+
+		arg, err := f(p.Args[0], typ.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		return michelinePrim{Prim: "Some", Args: []interface{}{arg}}, nil
+	case "or":
+		p, ok := v.(michelinePrim)
+		if !ok || (p.Prim != "Left" && p.Prim != "Right") || len(p.Args) != 1 || len(typ.Args) != 2 {
+			return nil, errors.Errorf("invalid or '%s'", marshalNode(v))
+		}
+
+		t := typ.Args[0]
+		if p.Prim == "Right" {
+			t = typ.Args[1]
+		}
+
+		arg, err := f(p.Args[0], t)
+		if err != nil {
+			return nil, err
+		}
+		return michelinePrim{Prim: p.Prim, Args: []interface{}{arg}}, nil
+	case "list", "set":
+		elements, ok := v.([]interface{})
+		if !ok || len(typ.Args) != 1 {
+			return nil, errors.Errorf("invalid %s '%s'", typ.Prim, marshalNode(v))
+		}
+
+		out := []interface{}{}
+		for _, element := range elements {
+			e, err := f(element, typ.Args[0])
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, e)
+		}
+		return out, nil
+	case "map", "big_map":
+		if _, ok := v.(michelineInt); ok && typ.Prim == "big_map" {
+			return v, nil
+		}
+
+		elements, ok := v.([]interface{})
+		if !ok || len(typ.Args) != 2 {
+			return nil, errors.Errorf("invalid %s '%s'", typ.Prim, marshalNode(v))
+		}
+
+		out := []interface{}{}
+		for _, element := range elements {
+			elt, ok := element.(michelinePrim)
+			if !ok || elt.Prim != "Elt" || len(elt.Args) != 2 {
+				return nil, errors.Errorf("invalid %s element '%s'", typ.Prim, marshalNode(element))
+			}
+
+			key, err := f(elt.Args[0], typ.Args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := f(elt.Args[1], typ.Args[1])
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, michelinePrim{Prim: "Elt", Args: []interface{}{key, value}})
+		}
+		return out, nil
+	}
+
+	return v, nil
+}
+
+// splitPair splits a pair value, or a sequence of the elements of a comb, into its first element and the rest.
+func splitPair(v interface{}) (interface{}, interface{}, error) {
+	var elements []interface{}
+	switch p := v.(type) {
+	case michelinePrim:
+		if p.Prim == "Pair" {
+			elements = p.Args
+		}
+	case []interface{}:
+		elements = p
+	}
+
+	if len(elements) < 2 {
+		return nil, nil, errors.Errorf("invalid pair '%s'", marshalNode(v))
+	}
+
+	if len(elements) == 2 {
+		return elements[0], elements[1], nil
+	}
+
+	return elements[0], michelinePrim{Prim: "Pair", Args: elements[1:]}, nil
+}
+
+func packAddress(s string) ([]byte, error) {
+	entrypoint := ""
+	if i := strings.IndexByte(s, '%'); i != -1 {
+		s, entrypoint = s[:i], s[i+1:]
+	}
+
+	addr, err := address.Parse(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid address")
+	}
+
+	v := addr.ContractBytes()
+	if entrypoint != "" && entrypoint != "default" {
+		v = append(v, entrypoint...)
+	}
+
+	return v, nil
+}
+
+// parseMicheline parses Micheline JSON to the nodes returned by michelineNode.
+func parseMicheline(v []byte) (interface{}, error) {
+	var raw interface{}
+	if err := json.Unmarshal(v, &raw); err != nil {
+		return nil, errors.Wrap(err, "invalid micheline")
+	}
+
+	return toMichelineNode(raw)
+}
+
+func toMichelineNode(raw interface{}) (interface{}, error) {
+	switch n := raw.(type) {
+	case []interface{}:
+		seq := []interface{}{}
+		for _, element := range n {
+			node, err := toMichelineNode(element)
+			if err != nil {
+				return nil, err
+			}
+			seq = append(seq, node)
+		}
+		return seq, nil
+	case map[string]interface{}:
+		if s, ok := n["int"].(string); ok {
+			return michelineInt{Int: s}, nil
+		}
+
+		if s, ok := n["string"].(string); ok {
+			return michelineString{String: s}, nil
+		}
+
+		if s, ok := n["bytes"].(string); ok {
+			return michelineBytes{Bytes: s}, nil
+		}
+
+		if s, ok := n["prim"].(string); ok {
+			prim := michelinePrim{Prim: s}
+			if args, ok := n["args"].([]interface{}); ok {
+				for _, arg := range args {
+					node, err := toMichelineNode(arg)
+					if err != nil {
+						return nil, err
+					}
+					prim.Args = append(prim.Args, node)
+				}
+			}
+
+			if annots, ok := n["annots"].([]interface{}); ok {
+				for _, annot := range annots {
+					if s, ok := annot.(string); ok {
+						prim.Annots = append(prim.Annots, s)
+					}
+				}
+			}
+			return prim, nil
+		}
+	}
+
+	v, _ := json.Marshal(raw)
+	return nil, errors.Errorf("invalid micheline node '%s'", v)
+}
+
+func marshalNode(v interface{}) string {
+	out, _ := marshalMicheline(v)
+	return string(out)
+}
+
+// marshalMicheline marshals a Micheline node without escaping HTML characters, which would be forged as escapes.
+func marshalMicheline(v interface{}) ([]byte, error) {
+	buf := bytes.NewBuffer([]byte{})
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// printableASCII returns if s only has the characters of Michelson strings, printable ASCII and line feeds.
+func printableASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\n' && (s[i] < ' ' || s[i] > '~') {
+			return false
+		}
+	}
+
+	return true
+}
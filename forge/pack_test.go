@@ -0,0 +1,211 @@
+package forge
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v3/b58"
+	"github.com/goat-systems/go-tezos/v3/internal/testutils"
+	"github.com/goat-systems/go-tezos/v3/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Pack(t *testing.T) {
+	signature := b58.MustEncode(b58.GenericSignature, make([]byte, 64))
+
+	type input struct {
+		value string
+		typ   string
+	}
+
+	type want struct {
+		wantErr     bool
+		containsErr string
+		packed      string
+		unpacked    string
+	}
+
+	cases := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			"is successful with nat",
+			input{`{"int":"1"}`, `{"prim":"nat"}`},
+			want{false, "", "050001", ""},
+		},
+		{
+			"is successful with string",
+			input{`{"string":"foo"}`, `{"prim":"string"}`},
+			want{false, "", "050100000003666f6f", ""},
+		},
+		{
+			"is successful with implicit address",
+			input{`{"string":"tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e"}`, `{"prim":"address"}`},
+			want{false, "", "050a0000001600001fb7d0a599ddca61b88dc203eeefbac341422cdf", ""},
+		},
+		{
+			"is successful with contract and entrypoint",
+			input{`{"string":"KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9%transfer"}`, `{"prim":"contract","args":[{"prim":"nat"}]}`},
+			want{false, "", "050a0000001e016498b7494a18a572c1d24484038545662c0454ed007472616e73666572", ""},
+		},
+		{
+			"is successful with default entrypoint",
+			input{`{"string":"KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9%default"}`, `{"prim":"address"}`},
+			want{false, "", "050a00000016016498b7494a18a572c1d24484038545662c0454ed00", `{"string":"KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9"}`},
+		},
+		{
+			"is successful with key_hash",
+			input{`{"string":"tz2Ch1abG7FNiibmV26Uzgdsnfni9XGrk5wD"}`, `{"prim":"key_hash"}`},
+			want{false, "", "050a00000015012ffebbf1560632ca767bc960ccdb84669d284c2c", ""},
+		},
+		{
+			"is successful with key",
+			input{`{"string":"edpkvEoAbkdaGALxi2FfeefB8hUkMZ4J1UVwkzyumx2GvbVpkYUHnm"}`, `{"prim":"key"}`},
+			want{false, "", "", ""},
+		},
+		{
+			"is successful with signature",
+			input{`{"string":"` + signature + `"}`, `{"prim":"signature"}`},
+			want{false, "", "050a00000040" + hex.EncodeToString(make([]byte, 64)), ""},
+		},
+		{
+			"is successful with chain_id",
+			input{`{"string":"NetXdQprcVkpaWU"}`, `{"prim":"chain_id"}`},
+			want{false, "", "050a000000047a06a770", ""},
+		},
+		{
+			"is successful with timestamp",
+			input{`{"string":"1970-01-01T00:01:40Z"}`, `{"prim":"timestamp"}`},
+			want{false, "", "0500a401", ""},
+		},
+		{
+			"is successful with comb pair",
+			input{`{"prim":"Pair","args":[{"int":"1"},{"int":"2"},{"int":"3"}]}`, `{"prim":"pair","args":[{"prim":"nat"},{"prim":"nat"},{"prim":"nat"}]}`},
+			want{false, "", "0507070001070700020003", `{"prim":"Pair","args":[{"int":"1"},{"prim":"Pair","args":[{"int":"2"},{"int":"3"}]}]}`},
+		},
+		{
+			"is successful with comb sequence",
+			input{`[{"int":"1"},{"int":"2"},{"int":"3"}]`, `{"prim":"pair","args":[{"prim":"nat"},{"prim":"pair","args":[{"prim":"nat"},{"prim":"nat"}]}]}`},
+			want{false, "", "0507070001070700020003", `{"prim":"Pair","args":[{"int":"1"},{"prim":"Pair","args":[{"int":"2"},{"int":"3"}]}]}`},
+		},
+		{
+			"is successful with nested data",
+			input{
+				`{"prim":"Pair","args":[[{"string":"tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e"}],{"prim":"Some","args":[[{"prim":"Elt","args":[{"string":"a"},{"prim":"Left","args":[{"string":"NetXdQprcVkpaWU"}]}]}]]}]}`,
+				`{"prim":"pair","args":[{"prim":"list","args":[{"prim":"address"}]},{"prim":"option","args":[{"prim":"map","args":[{"prim":"string"},{"prim":"or","args":[{"prim":"chain_id"},{"prim":"unit"}]}]}]}]}`,
+			},
+			want{false, "", "", ""},
+		},
+		{
+			"is successful with HTML characters in string",
+			input{`{"string":"x<=0 && y>0"}`, `{"prim":"string"}`},
+			want{false, "", "05010000000b783c3d3020262620793e30", ""},
+		},
+		{
+			"is successful with quote in string",
+			input{`{"string":"a\"b"}`, `{"prim":"string"}`},
+			want{false, "", "050100000003612262", ""},
+		},
+		{
+			"is successful with backslash in string",
+			input{`{"string":"a\\b"}`, `{"prim":"string"}`},
+			want{false, "", "050100000003615c62", ""},
+		},
+		{
+			"is successful with line feed in string",
+			input{`{"string":"a\nb"}`, `{"prim":"string"}`},
+			want{false, "", "050100000003610a62", ""},
+		},
+		{
+			"handles non-ASCII string",
+			input{`{"string":"caf\u00e9"}`, `{"prim":"string"}`},
+			want{true, "invalid string", "", ""},
+		},
+		{
+			"handles negative mutez",
+			input{`{"int":"-1"}`, `{"prim":"mutez"}`},
+			want{true, "invalid mutez '-1'", "", ""},
+		},
+		{
+			"handles invalid address",
+			input{`{"string":"tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8f"}`, `{"prim":"address"}`},
+			want{true, "invalid address", "", ""},
+		},
+		{
+			"handles originated key_hash",
+			input{`{"string":"KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9"}`, `{"prim":"key_hash"}`},
+			want{true, "is not an implicit account", "", ""},
+		},
+		{
+			"handles invalid option",
+			input{`{"prim":"Left","args":[{"int":"1"}]}`, `{"prim":"option","args":[{"prim":"nat"}]}`},
+			want{true, "invalid option", "", ""},
+		},
+		{
+			"handles invalid pair",
+			input{`{"int":"1"}`, `{"prim":"pair","args":[{"prim":"nat"},{"prim":"nat"}]}`},
+			want{true, "invalid pair", "", ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			value, typ := json.RawMessage(tt.input.value), json.RawMessage(tt.input.typ)
+
+			packed, err := Pack(&value, &typ)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			if tt.want.wantErr {
+				return
+			}
+
+			if tt.want.packed != "" {
+				assert.Equal(t, tt.want.packed, hex.EncodeToString(packed))
+			}
+
+			unpacked, err := Unpack(packed, &typ)
+			testutils.CheckErr(t, false, "", err)
+
+			expected := tt.want.unpacked
+			if expected == "" {
+				expected = tt.input.value
+			}
+			assert.JSONEq(t, expected, string(*unpacked))
+		})
+	}
+}
+
+func Test_Pack_ScriptExpression(t *testing.T) {
+	value, typ := json.RawMessage(`{"string":"tz1S82rGFZK8cVbNDpP1Hf9VhTUa4W8oc2WV"}`), json.RawMessage(`{"prim":"address"}`)
+
+	packed, err := Pack(&value, &typ)
+	testutils.CheckErr(t, false, "", err)
+
+	expr, err := rpc.ForgeScriptExpression(packed)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, rpc.ScriptExpression("expru1LH1CafV3yYgs9BkbrMWWfAE9ye3RdWwyndr9MKYN8w5VQ7Rt"), expr)
+}
+
+func Test_Unpack(t *testing.T) {
+	typ := json.RawMessage(`{"prim":"timestamp"}`)
+
+	_, err := Unpack([]byte{0x00, 0x01}, &typ)
+	testutils.CheckErr(t, true, "missing 0x05 prefix", err)
+
+	_, err = Unpack([]byte{0x05, 0x00, 0x01, 0x00}, &typ)
+	testutils.CheckErr(t, true, "unexpected trailing bytes", err)
+
+	unpacked, err := Unpack([]byte{0x05, 0x01, 0x00, 0x00, 0x00, 0x00}, &typ)
+	testutils.CheckErr(t, false, "", err)
+	assert.JSONEq(t, `{"string":""}`, string(*unpacked))
+
+	str := json.RawMessage(`{"prim":"string"}`)
+	unpacked, err = Unpack([]byte{0x05, 0x01, 0x00, 0x00, 0x00, 0x04, 'x', '<', '=', '0'}, &str)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, `{"string":"x<=0"}`, string(*unpacked))
+
+	_, err = Unpack([]byte{0x05, 0x01, 0x00, 0x00, 0x00, 0x02, 0xc3, 0xa9}, &str)
+	testutils.CheckErr(t, true, "invalid string", err)
+}
@@ -0,0 +1,287 @@
+package forge
+
+import (
+	"fmt"
+
+	"github.com/goat-systems/go-tezos/v3/rpc"
+	"github.com/pkg/errors"
+)
+
+/*
+Protocol is the binary encoding of a Tezos protocol. It selects the operation tags, the field layouts and the
+Micheline primitives allowed when forging and unforging operations.
+
+Note:
+	Protocols from Babylon to Quebec are supported, except Jakarta. Use ProtocolByHash or ProtocolFromBlock
+	to get the protocol of a chain and forge operations with its Encode method.
+*/
+type Protocol struct {
+	Name string
+	Hash string
+
+	operationTags    map[rpc.Kind]byte
+	maxPrimitive     byte
+	legacyPrimitives map[byte]string
+
+	// endorsementSlot is set when double_endorsement_evidence ends with the slot of the endorsements.
+	endorsementSlot bool
+	// tenderbake is set when consensus operations have a slot, a round and a block payload hash and block headers
+	// have a payload hash and round instead of a priority.
+	tenderbake bool
+	// escapeVote is set when block headers end with the liquidity baking escape vote.
+	escapeVote bool
+	// toggleVote is set when block headers end with the liquidity baking toggle vote.
+	toggleVote bool
+	// perBlockVotes is set when block headers end with the liquidity baking and adaptive issuance votes.
+	perBlockVotes bool
+	// originationProof is set when smart_rollup_originate has an origination proof.
+	originationProof bool
+	// cementCommitment is set when smart_rollup_cement has the hash of the cemented commitment.
+	cementCommitment bool
+	// rollupWhitelist is set when smart_rollup_originate has an optional whitelist.
+	rollupWhitelist bool
+
+	operationKinds map[byte]rpc.Kind
+	primitiveTags  map[string]byte
+	primitiveNames map[byte]string
+}
+
+// emmyOperations are the operation tags of the protocols using Emmy consensus (Babylon to Hangzhou).
+var emmyOperations = map[rpc.Kind]byte{
+	rpc.ENDORSEMENT:               0,
+	rpc.SEEDNONCEREVELATION:       1,
+	rpc.DOUBLEENDORSEMENTEVIDENCE: 2,
+	rpc.DOUBLEBAKINGEVIDENCE:      3,
+	rpc.ACTIVATEACCOUNT:           4,
+	rpc.PROPOSALS:                 5,
+	rpc.BALLOT:                    6,
+	rpc.REVEAL:                    107,
+	rpc.TRANSACTION:               108,
+	rpc.ORIGINATION:               109,
+	rpc.DELEGATION:                110,
+}
+
+// tenderbakeOperations are the operation tags of the protocols using Tenderbake consensus (Ithaca onwards).
+var tenderbakeOperations = map[rpc.Kind]byte{
+	rpc.SEEDNONCEREVELATION:       1,
+	rpc.DOUBLEENDORSEMENTEVIDENCE: 2,
+	rpc.DOUBLEBAKINGEVIDENCE:      3,
+	rpc.ACTIVATEACCOUNT:           4,
+	rpc.PROPOSALS:                 5,
+	rpc.BALLOT:                    6,
+	rpc.PREENDORSEMENT:            20,
+	rpc.ENDORSEMENT:               21,
+	rpc.REVEAL:                    107,
+	rpc.TRANSACTION:               108,
+	rpc.ORIGINATION:               109,
+	rpc.DELEGATION:                110,
+	rpc.REGISTERGLOBALCONSTANT:    111,
+	rpc.SETDEPOSITSLIMIT:          112,
+}
+
+// txRollupOperations are the operation tags of transaction rollups (Jakarta to Nairobi).
+var txRollupOperations = map[rpc.Kind]byte{
+	rpc.TXROLLUPORIGINATION:        150,
+	rpc.TXROLLUPSUBMITBATCH:        151,
+	rpc.TXROLLUPCOMMIT:             152,
+	rpc.TXROLLUPRETURNBOND:         153,
+	rpc.TXROLLUPFINALIZECOMMITMENT: 154,
+	rpc.TXROLLUPREMOVECOMMITMENT:   155,
+	rpc.TXROLLUPREJECTION:          156,
+	rpc.TXROLLUPDISPATCHTICKETS:    157,
+}
+
+var (
+	hangzhouOperations = operationTags(emmyOperations, map[rpc.Kind]byte{rpc.REGISTERGLOBALCONSTANT: 111})
+	// Jakarta added transfer tickets and transaction rollups, removed in Oxford.
+	jakartaOperations = operationTags(operationTags(tenderbakeOperations, txRollupOperations), map[rpc.Kind]byte{
+		rpc.TRANSFERTICKET: 158,
+	})
+	kathmanduOperations = operationTags(jakartaOperations, map[rpc.Kind]byte{
+		rpc.INCREASEPAIDSTORAGE: 113,
+	})
+	limaOperations = operationTags(kathmanduOperations, map[rpc.Kind]byte{
+		rpc.UPDATECONSENSUSKEY: 114,
+		rpc.DRAINDELEGATE:      9,
+	})
+	mumbaiOperations = operationTags(limaOperations, map[rpc.Kind]byte{
+		rpc.SMARTROLLUPORIGINATE:            200,
+		rpc.SMARTROLLUPADDMESSAGES:          201,
+		rpc.SMARTROLLUPCEMENT:               202,
+		rpc.SMARTROLLUPPUBLISH:              203,
+		rpc.SMARTROLLUPEXECUTEOUTBOXMESSAGE: 206,
+	})
+	// Oxford renamed endorsements attestations and double_endorsement_evidence double_attestation_evidence,
+	// which isn't supported, and removed transaction rollups.
+	oxfordOperations = operationTags(mumbaiOperations, map[rpc.Kind]byte{
+		rpc.PREATTESTATION: 20,
+		rpc.ATTESTATION:    21,
+	}, rpc.PREENDORSEMENT, rpc.ENDORSEMENT, rpc.DOUBLEENDORSEMENTEVIDENCE,
+		rpc.TXROLLUPORIGINATION, rpc.TXROLLUPSUBMITBATCH, rpc.TXROLLUPCOMMIT, rpc.TXROLLUPRETURNBOND,
+		rpc.TXROLLUPFINALIZECOMMITMENT, rpc.TXROLLUPREMOVECOMMITMENT, rpc.TXROLLUPREJECTION, rpc.TXROLLUPDISPATCHTICKETS)
+	parisOperations = operationTags(oxfordOperations, nil, rpc.SETDEPOSITSLIMIT)
+)
+
+var (
+	// Names of primitives that were renamed when their tag was deprecated.
+	saplingTransactionLegacy = map[byte]string{0x84: "sapling_transaction"}
+	ticketLegacy             = map[byte]string{0x88: "TICKET"}
+)
+
+var protocols = []*Protocol{
+	{Name: "Babylon", Hash: "PsBABY5HQTSkA4297zNHfsZNKtxULfL18y95qb3m53QJiXGmrbU", operationTags: emmyOperations, maxPrimitive: 0x75},
+	{Name: "Babylon", Hash: "PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS", operationTags: emmyOperations, maxPrimitive: 0x75},
+	{Name: "Carthage", Hash: "PsCARTHAGazKbHtnKfLzQg3kms52kSRpgnDY982a9oYsSXRLQEb", operationTags: emmyOperations, maxPrimitive: 0x75},
+	{Name: "Delphi", Hash: "PsDELPH1Kxsxt8f9eWbxQeRxkjfbxoqM52jvs5Y5fBxWWh4ifpo", operationTags: emmyOperations, maxPrimitive: 0x75},
+	{Name: "Edo", Hash: "PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA", operationTags: emmyOperations, maxPrimitive: 0x8C, legacyPrimitives: saplingTransactionLegacy, endorsementSlot: true},
+	{Name: "Florence", Hash: "PsFLorenaUUuikDWvMDr6fGBRG8kt3e3D3fHoXK1j1BFRxeSH4i", operationTags: emmyOperations, maxPrimitive: 0x8C, legacyPrimitives: saplingTransactionLegacy, endorsementSlot: true},
+	{Name: "Granada", Hash: "PtGRANADsDU8R9daYKAgWnQYAJ64omN1o3KMGVCykShA97vQbvV", operationTags: emmyOperations, maxPrimitive: 0x8C, legacyPrimitives: saplingTransactionLegacy, endorsementSlot: true, escapeVote: true},
+	{Name: "Hangzhou", Hash: "PtHangz2aRngywmSRGGvrcTyMbbdpWdpFKuS4uMWxg2RaH9i1qx", operationTags: hangzhouOperations, maxPrimitive: 0x92, legacyPrimitives: saplingTransactionLegacy, endorsementSlot: true, escapeVote: true},
+	{Name: "Ithaca", Hash: "Psithaca2MLRFYargivpo7YvUr7wUDqyxrdhC5CQq78mRvimz6A", operationTags: tenderbakeOperations, maxPrimitive: 0x93, legacyPrimitives: saplingTransactionLegacy, tenderbake: true, escapeVote: true},
+	{Name: "Kathmandu", Hash: "PtKathmankSpLLDALzWw7CGD2j2MtyveTwboEYokqUCP4a1LxMg", operationTags: kathmanduOperations, maxPrimitive: 0x97, legacyPrimitives: ticketLegacy, tenderbake: true, toggleVote: true},
+	{Name: "Lima", Hash: "PtLimaPtLMwfNinJi9rCfDPWea8dFgTZ1MeJ9f1m2SRic6ayiwW", operationTags: limaOperations, maxPrimitive: 0x9A, tenderbake: true, toggleVote: true},
+	{Name: "Mumbai", Hash: "PtMumbai2TmsJHNGRkD8v8YDbtao7BLUC3wjASn1inAKLFCjaH1", operationTags: mumbaiOperations, maxPrimitive: 0x9C, tenderbake: true, toggleVote: true, originationProof: true, cementCommitment: true},
+	{Name: "Nairobi", Hash: "PtNairobiyssHuh87hEhfVBGCVrK3WnS8Z2FT4ymB5tAa4r1nQf", operationTags: mumbaiOperations, maxPrimitive: 0x9C, tenderbake: true, toggleVote: true, cementCommitment: true},
+	{Name: "Oxford", Hash: "ProxfordYmVfjWnRcgjWH36fW6PArwqykTFzotUxRs6gmTcZDuH", operationTags: oxfordOperations, maxPrimitive: 0x9D, tenderbake: true, perBlockVotes: true, rollupWhitelist: true},
+	{Name: "Paris", Hash: "PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ", operationTags: parisOperations, maxPrimitive: 0x9D, tenderbake: true, perBlockVotes: true, rollupWhitelist: true},
+	{Name: "ParisC", Hash: "PsParisCZo7KAh1Z1smVd9ZMZ1HHn5gkzbM94V3PLCpknFWhUAi", operationTags: parisOperations, maxPrimitive: 0x9D, tenderbake: true, perBlockVotes: true, rollupWhitelist: true},
+	{Name: "Quebec", Hash: "PsQuebecnLByd3JwTiGadoG4nGWi3HYiLXUjkibeFV8dCFeVMUg", operationTags: parisOperations, maxPrimitive: 0x9D, tenderbake: true, perBlockVotes: true, rollupWhitelist: true},
+}
+
+// latestProtocol is the most recent protocol supported.
+var latestProtocol = protocols[len(protocols)-1]
+
+/*
+DefaultProtocol returns the protocol used by Encode, EncodeAndSign, Decode and DecodeSigned, the most recent
+protocol supported (Quebec). Use ProtocolByHash or ProtocolFromBlock to forge for the protocol of a chain.
+*/
+func DefaultProtocol() *Protocol {
+	return latestProtocol
+}
+
+func init() {
+	for _, p := range protocols {
+		p.load()
+	}
+}
+
+// operationTags copies base, adding the tags of kinds and removing the tags of the kinds removed.
+func operationTags(base, kinds map[rpc.Kind]byte, removed ...rpc.Kind) map[rpc.Kind]byte {
+	tags := make(map[rpc.Kind]byte, len(base)+len(kinds))
+	for kind, tag := range base {
+		tags[kind] = tag
+	}
+
+	for kind, tag := range kinds {
+		tags[kind] = tag
+	}
+
+	for _, kind := range removed {
+		delete(tags, kind)
+	}
+
+	return tags
+}
+
+// load builds the reverse operation tags and the primitives of the protocol.
+func (p *Protocol) load() {
+	p.operationKinds = make(map[byte]rpc.Kind, len(p.operationTags))
+	for kind, tag := range p.operationTags {
+		p.operationKinds[tag] = kind
+	}
+
+	p.primitiveTags = make(map[string]byte, len(primitiveTags))
+	p.primitiveNames = make(map[byte]string, len(primitiveTags))
+	for prim, tag := range primitiveTags {
+		if tag > p.maxPrimitive {
+			continue
+		}
+
+		if name, ok := p.legacyPrimitives[tag]; ok {
+			prim = name
+		}
+
+		p.primitiveTags[prim] = tag
+		p.primitiveNames[tag] = prim
+	}
+}
+
+/*
+ProtocolByHash returns the protocol with the hash passed.
+
+Parameters:
+
+	hash:
+		The protocol hash, e.g. PsCARTHAGazKbHtnKfLzQg3kms52kSRpgnDY982a9oYsSXRLQEb.
+*/
+func ProtocolByHash(hash string) (*Protocol, error) {
+	for _, p := range protocols {
+		if p.Hash == hash {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported protocol '%s'", hash)
+}
+
+/*
+MustProtocol returns the protocol with the hash passed and panics if the protocol isn't supported.
+
+Parameters:
+
+	hash:
+		The protocol hash, e.g. PsCARTHAGazKbHtnKfLzQg3kms52kSRpgnDY982a9oYsSXRLQEb.
+*/
+func MustProtocol(hash string) *Protocol {
+	p, err := ProtocolByHash(hash)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+/*
+ProtocolFromBlock returns the protocol operations should be forged for on top of block.
+
+Note:
+	Operations are applied by the next protocol of the block, which differs from its protocol on the
+	last block of a voting period activating a new protocol. Metadata.NextProtocol is used when the block
+	has metadata.
+
+Parameters:
+
+	block:
+		The block to forge operations on, e.g. the head of the chain.
+*/
+func ProtocolFromBlock(block *rpc.Block) (*Protocol, error) {
+	if block == nil {
+		return nil, errors.New("failed to get protocol: block is nil")
+	}
+
+	hash := block.Protocol
+	if block.Metadata.NextProtocol != "" {
+		hash = block.Metadata.NextProtocol
+	}
+
+	p, err := ProtocolByHash(hash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get protocol")
+	}
+
+	return p, nil
+}
+
+// String returns the name of the protocol.
+func (p *Protocol) String() string {
+	return p.Name
+}
+
+func (p *Protocol) forgeTag(kind rpc.Kind) ([]byte, error) {
+	tag, ok := p.operationTags[kind]
+	if !ok {
+		return []byte{}, fmt.Errorf("kind '%s' is not supported by %s", kind, p.Name)
+	}
+
+	return []byte{tag}, nil
+}
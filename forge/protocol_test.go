@@ -0,0 +1,372 @@
+package forge
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/goat-systems/go-tezos/v3/b58"
+	"github.com/goat-systems/go-tezos/v3/internal/testutils"
+	"github.com/goat-systems/go-tezos/v3/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fastjson"
+)
+
+const (
+	carthage  = "PsCARTHAGazKbHtnKfLzQg3kms52kSRpgnDY982a9oYsSXRLQEb"
+	edo       = "PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA"
+	granada   = "PtGRANADsDU8R9daYKAgWnQYAJ64omN1o3KMGVCykShA97vQbvV"
+	hangzhou  = "PtHangz2aRngywmSRGGvrcTyMbbdpWdpFKuS4uMWxg2RaH9i1qx"
+	ithaca    = "Psithaca2MLRFYargivpo7YvUr7wUDqyxrdhC5CQq78mRvimz6A"
+	kathmandu = "PtKathmankSpLLDALzWw7CGD2j2MtyveTwboEYokqUCP4a1LxMg"
+	lima      = "PtLimaPtLMwfNinJi9rCfDPWea8dFgTZ1MeJ9f1m2SRic6ayiwW"
+	oxford    = "ProxfordYmVfjWnRcgjWH36fW6PArwqykTFzotUxRs6gmTcZDuH"
+	quebec    = "PsQuebecnLByd3JwTiGadoG4nGWi3HYiLXUjkibeFV8dCFeVMUg"
+)
+
+func Test_ProtocolByHash(t *testing.T) {
+	for _, p := range protocols {
+		_, err := b58.DecodeAs(p.Hash, b58.ProtocolHash)
+		testutils.CheckErr(t, false, "", err)
+
+		found, err := ProtocolByHash(p.Hash)
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, p, found)
+	}
+
+	_, err := ProtocolByHash("Pt24m4xiPbLDhVgVfABUjirbmda3yohdN82Sp9FeuAXJ4eV9otd")
+	testutils.CheckErr(t, true, "unsupported protocol 'Pt24m4xiPbLDhVgVfABUjirbmda3yohdN82Sp9FeuAXJ4eV9otd'", err)
+
+	assert.Equal(t, "Quebec", DefaultProtocol().String())
+	assert.Equal(t, "Quebec", latestProtocol.String())
+}
+
+func Test_ProtocolByHash_Mainnet(t *testing.T) {
+	mainnet := map[string]string{
+		"PsBABY5HQTSkA4297zNHfsZNKtxULfL18y95qb3m53QJiXGmrbU": "Babylon",
+		"PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS": "Babylon",
+		"PsCARTHAGazKbHtnKfLzQg3kms52kSRpgnDY982a9oYsSXRLQEb": "Carthage",
+		"PsDELPH1Kxsxt8f9eWbxQeRxkjfbxoqM52jvs5Y5fBxWWh4ifpo": "Delphi",
+		"PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA": "Edo",
+		"PsFLorenaUUuikDWvMDr6fGBRG8kt3e3D3fHoXK1j1BFRxeSH4i": "Florence",
+		"PtGRANADsDU8R9daYKAgWnQYAJ64omN1o3KMGVCykShA97vQbvV": "Granada",
+		"PtHangz2aRngywmSRGGvrcTyMbbdpWdpFKuS4uMWxg2RaH9i1qx": "Hangzhou",
+		"Psithaca2MLRFYargivpo7YvUr7wUDqyxrdhC5CQq78mRvimz6A": "Ithaca",
+		"PtKathmankSpLLDALzWw7CGD2j2MtyveTwboEYokqUCP4a1LxMg": "Kathmandu",
+		"PtLimaPtLMwfNinJi9rCfDPWea8dFgTZ1MeJ9f1m2SRic6ayiwW": "Lima",
+		"PtMumbai2TmsJHNGRkD8v8YDbtao7BLUC3wjASn1inAKLFCjaH1": "Mumbai",
+		"PtNairobiyssHuh87hEhfVBGCVrK3WnS8Z2FT4ymB5tAa4r1nQf": "Nairobi",
+		"ProxfordYmVfjWnRcgjWH36fW6PArwqykTFzotUxRs6gmTcZDuH": "Oxford",
+		"PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ": "Paris",
+		"PsParisCZo7KAh1Z1smVd9ZMZ1HHn5gkzbM94V3PLCpknFWhUAi": "ParisC",
+		"PsQuebecnLByd3JwTiGadoG4nGWi3HYiLXUjkibeFV8dCFeVMUg": "Quebec",
+	}
+
+	for hash, name := range mainnet {
+		p, err := ProtocolFromBlock(&rpc.Block{Protocol: hash})
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, name, p.String())
+	}
+}
+
+func Test_Protocol_TxRollups(t *testing.T) {
+	// the tag of tx_rollup_origination
+	op := "a3a2eb9e7d3b1e8dd3b1e2e3c1b8b9e2f1d6e3c1b8b9e2f1d6e3c1b8b9e2f1d696"
+
+	for _, hash := range []string{kathmandu, lima, "PtNairobiyssHuh87hEhfVBGCVrK3WnS8Z2FT4ymB5tAa4r1nQf"} {
+		p := MustProtocol(hash)
+		_, _, err := p.Decode(op)
+		testutils.CheckErr(t, true, "failed to unforge tx_rollup_origination: unsupported kind", err)
+
+		_, err = p.Encode("", rpc.Content{Kind: rpc.TXROLLUPORIGINATION})
+		testutils.CheckErr(t, true, "unsupported kind 'tx_rollup_origination'", err)
+	}
+
+	_, _, err := MustProtocol(oxford).Decode(op)
+	testutils.CheckErr(t, true, "unsupported operation tag 150", err)
+}
+
+func Test_ProtocolFromBlock(t *testing.T) {
+	type want struct {
+		wantErr     bool
+		containsErr string
+		name        string
+	}
+
+	cases := []struct {
+		name  string
+		input *rpc.Block
+		want  want
+	}{
+		{
+			"is successful with protocol",
+			&rpc.Block{Protocol: quebec},
+			want{false, "", "Quebec"},
+		},
+		{
+			"is successful with next protocol",
+			&rpc.Block{Protocol: hangzhou, Metadata: rpc.Metadata{NextProtocol: ithaca}},
+			want{false, "", "Ithaca"},
+		},
+		{
+			"handles unsupported protocol",
+			&rpc.Block{Protocol: "PtYuensgYBb3G3x1hLLbCmcav8ue8Kyd2khADcL5LsT5R1hcXex"},
+			want{true, "failed to get protocol: unsupported protocol", ""},
+		},
+		{
+			"handles nil block",
+			nil,
+			want{true, "block is nil", ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ProtocolFromBlock(tt.input)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			if !tt.want.wantErr {
+				assert.Equal(t, tt.want.name, p.Name)
+			}
+		})
+	}
+}
+
+func Test_Protocol_Encode(t *testing.T) {
+	branch := "BLyvCRkxuTXkx1KeGvrcEXiPYj4p1tFxzvFDhoHE7SFKtmP1rbk"
+	signature := b58.MustEncode(b58.GenericSignature, make([]byte, 64))
+	payloadHash := b58.MustEncode(b58.BlockPayloadHash, make([]byte, 32))
+	zeroes := hex.EncodeToString(make([]byte, 32))
+
+	inlined := func(level int) *rpc.InlinedEndorsement {
+		return &rpc.InlinedEndorsement{
+			Branch:     branch,
+			Operations: &rpc.InlinedEndorsementOperations{Kind: "endorsement", Level: level},
+			Signature:  signature,
+		}
+	}
+
+	header := func(vote bool) *rpc.BlockHeader {
+		return &rpc.BlockHeader{
+			Level:                     1000,
+			Proto:                     1,
+			Predecessor:               branch,
+			Timestamp:                 time.Unix(1600000000, 0).UTC(),
+			OperationsHash:            b58.MustEncode(b58.OperationListListHash, make([]byte, 32)),
+			Fitness:                   []string{"01", "000000000000a0b1"},
+			Context:                   b58.MustEncode(b58.ContextHash, make([]byte, 32)),
+			ProofOfWorkNonce:          "0102030405060708",
+			LiquidityBakingEscapeVote: vote,
+			Signature:                 signature,
+		}
+	}
+
+	tenderbakeHeader := func(toggleVote, adaptiveIssuanceVote string) *rpc.BlockHeader {
+		h := header(false)
+		h.PayloadHash, h.PayloadRound = payloadHash, 2
+		h.LiquidityBakingToggleVote, h.AdaptiveIssuanceVote = toggleVote, adaptiveIssuanceVote
+		return h
+	}
+
+	consensus := func(kind rpc.Kind) rpc.Content {
+		return rpc.Content{Kind: kind, Slot: 7, Level: 1234, Round: 1, BlockPayloadHash: payloadHash}
+	}
+
+	type input struct {
+		protocol string
+		contents rpc.Contents
+	}
+
+	type want struct {
+		wantErr     bool
+		containsErr string
+		suffix      string
+	}
+
+	cases := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			"is successful with endorsement on Emmy",
+			input{hangzhou, rpc.Contents{{Kind: rpc.ENDORSEMENT, Level: 1234}}},
+			want{false, "", "00000004d2"},
+		},
+		{
+			"is successful with endorsement on Tenderbake",
+			input{ithaca, rpc.Contents{consensus(rpc.ENDORSEMENT)}},
+			want{false, "", "15" + "0007" + "000004d2" + "00000001" + zeroes},
+		},
+		{
+			"is successful with preendorsement",
+			input{lima, rpc.Contents{consensus(rpc.PREENDORSEMENT)}},
+			want{false, "", "14" + "0007" + "000004d2" + "00000001" + zeroes},
+		},
+		{
+			"is successful with attestation",
+			input{quebec, rpc.Contents{consensus(rpc.ATTESTATION)}},
+			want{false, "", "15" + "0007" + "000004d2" + "00000001" + zeroes},
+		},
+		{
+			"is successful with preattestation",
+			input{oxford, rpc.Contents{consensus(rpc.PREATTESTATION)}},
+			want{false, "", "14" + "0007" + "000004d2" + "00000001" + zeroes},
+		},
+		{
+			"handles endorsement on Oxford",
+			input{oxford, rpc.Contents{consensus(rpc.ENDORSEMENT)}},
+			want{true, "kind 'endorsement' is not supported by Oxford", ""},
+		},
+		{
+			"handles attestation before Oxford",
+			input{lima, rpc.Contents{consensus(rpc.ATTESTATION)}},
+			want{true, "kind 'attestation' is not supported by Lima", ""},
+		},
+		{
+			"handles attestation without block payload hash",
+			input{quebec, rpc.Contents{{Kind: rpc.ATTESTATION, Level: 1234}}},
+			want{true, "invalid input", ""},
+		},
+		{
+			"is successful with double endorsement evidence without slot",
+			input{carthage, rpc.Contents{{Kind: rpc.DOUBLEENDORSEMENTEVIDENCE, Op1: inlined(10), Op2: inlined(10), Slot: 3}}},
+			want{false, "", "0000000a" + hex.EncodeToString(make([]byte, 64))},
+		},
+		{
+			"is successful with double endorsement evidence with slot",
+			input{edo, rpc.Contents{{Kind: rpc.DOUBLEENDORSEMENTEVIDENCE, Op1: inlined(10), Op2: inlined(10), Slot: 3}}},
+			want{false, "", "0000000a" + hex.EncodeToString(make([]byte, 64)) + "0003"},
+		},
+		{
+			"is successful with double baking evidence with escape vote",
+			input{granada, rpc.Contents{{Kind: rpc.DOUBLEBAKINGEVIDENCE, Bh1: header(true), Bh2: header(true)}}},
+			want{false, "", "00ff" + hex.EncodeToString(make([]byte, 64))},
+		},
+		{
+			"is successful with double endorsement evidence on Tenderbake",
+			input{ithaca, rpc.Contents{{Kind: rpc.DOUBLEENDORSEMENTEVIDENCE, Op1: &rpc.InlinedEndorsement{
+				Branch:     branch,
+				Operations: &rpc.InlinedEndorsementOperations{Kind: "endorsement", Slot: 7, Level: 10, Round: 1, BlockPayloadHash: payloadHash},
+				Signature:  signature,
+			}, Op2: &rpc.InlinedEndorsement{
+				Branch:     branch,
+				Operations: &rpc.InlinedEndorsementOperations{Kind: "endorsement", Slot: 7, Level: 10, Round: 2, BlockPayloadHash: payloadHash},
+				Signature:  signature,
+			}}}},
+			want{false, "", "15" + "0007" + "0000000a" + "00000002" + zeroes + hex.EncodeToString(make([]byte, 64))},
+		},
+		{
+			"is successful with double baking evidence with escape vote on Tenderbake",
+			input{ithaca, rpc.Contents{{Kind: rpc.DOUBLEBAKINGEVIDENCE, Bh1: tenderbakeHeader("", ""), Bh2: tenderbakeHeader("", "")}}},
+			want{false, "", zeroes + "00000002" + "0102030405060708" + "00" + "00" + hex.EncodeToString(make([]byte, 64))},
+		},
+		{
+			"is successful with double baking evidence with toggle vote",
+			input{kathmandu, rpc.Contents{{Kind: rpc.DOUBLEBAKINGEVIDENCE, Bh1: tenderbakeHeader("pass", ""), Bh2: tenderbakeHeader("pass", "")}}},
+			want{false, "", "00" + "02" + hex.EncodeToString(make([]byte, 64))},
+		},
+		{
+			"is successful with double baking evidence with per block votes",
+			input{quebec, rpc.Contents{{Kind: rpc.DOUBLEBAKINGEVIDENCE, Bh1: tenderbakeHeader("pass", "off"), Bh2: tenderbakeHeader("pass", "off")}}},
+			want{false, "", "00" + "06" + hex.EncodeToString(make([]byte, 64))},
+		},
+		{
+			"handles invalid adaptive issuance vote",
+			input{quebec, rpc.Contents{{Kind: rpc.DOUBLEBAKINGEVIDENCE, Bh1: tenderbakeHeader("pass", ""), Bh2: tenderbakeHeader("pass", "")}}},
+			want{true, "failed to forge adaptive_issuance_vote: invalid vote ''", ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			p := MustProtocol(tt.input.protocol)
+			op, err := p.Encode(branch, tt.input.contents...)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			if tt.want.wantErr {
+				return
+			}
+
+			assert.Regexp(t, tt.want.suffix+"$", op)
+
+			_, contents, err := p.Decode(op)
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, tt.input.contents[0].Kind, contents[0].Kind)
+
+			reforged, err := p.Encode(branch, contents...)
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, op, reforged)
+		})
+	}
+}
+
+func Test_Protocol_forgeMicheline(t *testing.T) {
+	type input struct {
+		protocol  string
+		micheline string
+	}
+
+	type want struct {
+		wantErr     bool
+		containsErr string
+		forged      string
+	}
+
+	cases := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			"handles SUB_MUTEZ before Ithaca",
+			input{hangzhou, `{"prim":"SUB_MUTEZ"}`},
+			want{true, "unknown prim 'SUB_MUTEZ' in Hangzhou", ""},
+		},
+		{
+			"is successful with SUB_MUTEZ on Ithaca",
+			input{ithaca, `{"prim":"SUB_MUTEZ"}`},
+			want{false, "", "0393"},
+		},
+		{
+			"is successful with legacy sapling_transaction",
+			input{edo, `{"prim":"sapling_transaction","args":[{"int":"8"}]}`},
+			want{false, "", "0584" + "0008"},
+		},
+		{
+			"is successful with sapling_transaction",
+			input{kathmandu, `{"prim":"sapling_transaction","args":[{"int":"8"}]}`},
+			want{false, "", "0596" + "0008"},
+		},
+		{
+			"is successful with legacy TICKET",
+			input{kathmandu, `{"prim":"TICKET"}`},
+			want{false, "", "0388"},
+		},
+		{
+			"is successful with TICKET",
+			input{lima, `{"prim":"TICKET"}`},
+			want{false, "", "039a"},
+		},
+		{
+			"handles deprecated TICKET before Lima",
+			input{kathmandu, `{"prim":"TICKET_DEPRECATED"}`},
+			want{true, "unknown prim 'TICKET_DEPRECATED' in Kathmandu", ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			p := MustProtocol(tt.input.protocol)
+			forged, err := p.forgeMicheline(fastjson.MustParse(tt.input.micheline))
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			if tt.want.wantErr {
+				return
+			}
+
+			assert.Equal(t, tt.want.forged, hex.EncodeToString(forged))
+
+			unforged, err := unforgeMicheline(forged, p)
+			testutils.CheckErr(t, false, "", err)
+			assert.JSONEq(t, tt.input.micheline, string(*unforged))
+		})
+	}
+}
@@ -3,8 +3,7 @@ module github.com/goat-systems/go-tezos/v3
 go 1.14
 
 require (
-	github.com/btcsuite/btcutil v1.0.2
-	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/btcsuite/btcd v0.22.1
 	github.com/go-playground/validator v9.31.0+incompatible
 	github.com/go-playground/validator/v10 v10.2.0
 	github.com/kr/pretty v0.1.0 // indirect
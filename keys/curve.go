@@ -2,6 +2,8 @@ package keys
 
 import (
 	"fmt"
+
+	"github.com/goat-systems/go-tezos/v3/b58"
 )
 
 // ECKind is the key type
@@ -17,10 +19,11 @@ const (
 )
 
 type iCurve interface {
-	addressPrefix() []byte
-	publicKeyPrefix() []byte
-	privateKeyPrefix() []byte
-	signaturePrefix() []byte
+	addressPrefix() b58.Prefix
+	publicKeyPrefix() b58.Prefix
+	privateKeyPrefix() b58.Prefix
+	signaturePrefix() b58.Prefix
+	encryptedPrivateKeyPrefix() b58.Prefix
 	getECKind() ECKind
 	getPrivateKey(v []byte) []byte
 	getPublicKey(privateKey []byte) ([]byte, error)
@@ -53,3 +56,22 @@ func getCurveByPrefix(prefix string) (iCurve, error) {
 
 	return nil, fmt.Errorf("failed to find curve with prefix '%s'", prefix)
 }
+
+/*
+KindFromPrefix returns the curve of a base58 encoded key, signature or public key hash based on its prefix.
+Example:
+	KindFromPrefix("edpkvEoAbkdaGALxi2FfeefB8hUkMZ4J1UVwkzyumx2GvbVpkYUHnm") // Ed25519
+*/
+func KindFromPrefix(v string) (ECKind, error) {
+	for _, l := range []int{5, 4, 3} {
+		if len(v) < l {
+			continue
+		}
+
+		if curve, err := getCurveByPrefix(v[:l]); err == nil {
+			return curve.getECKind(), nil
+		}
+	}
+
+	return "", fmt.Errorf("failed to find curve of '%s'", v)
+}
@@ -3,6 +3,7 @@ package keys
 import (
 	"crypto/ed25519"
 
+	"github.com/goat-systems/go-tezos/v3/b58"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/blake2b"
 )
@@ -12,20 +13,24 @@ var _ iCurve = &ed25519Curve{}
 // https://tools.ietf.org/html/rfc8032
 type ed25519Curve struct{}
 
-func (e *ed25519Curve) addressPrefix() []byte {
-	return []byte{6, 161, 159}
+func (e *ed25519Curve) addressPrefix() b58.Prefix {
+	return b58.Ed25519PublicKeyHash
 }
 
-func (e *ed25519Curve) publicKeyPrefix() []byte {
-	return []byte{13, 15, 37, 217}
+func (e *ed25519Curve) publicKeyPrefix() b58.Prefix {
+	return b58.Ed25519PublicKey
 }
 
-func (e *ed25519Curve) privateKeyPrefix() []byte {
-	return []byte{43, 246, 78, 7}
+func (e *ed25519Curve) privateKeyPrefix() b58.Prefix {
+	return b58.Ed25519SecretKey
 }
 
-func (e *ed25519Curve) signaturePrefix() []byte {
-	return []byte{9, 245, 205, 134, 18}
+func (e *ed25519Curve) signaturePrefix() b58.Prefix {
+	return b58.Ed25519Signature
+}
+
+func (e *ed25519Curve) encryptedPrivateKeyPrefix() b58.Prefix {
+	return b58.Ed25519EncryptedSeed
 }
 
 func (e *ed25519Curve) getECKind() ECKind {
@@ -60,15 +65,15 @@ func (e *ed25519Curve) sign(msg []byte, privateKey []byte) (Signature, error) {
 
 	return Signature{
 		Bytes:  ed25519.Sign(ed25519.PrivateKey(privateKey), hash.Sum([]byte{})),
-		Prefix: e.signaturePrefix(),
+		Prefix: e.signaturePrefix().Bytes,
 	}, nil
 }
 
 func (e *ed25519Curve) verify(msg []byte, signature []byte, pubKey []byte) bool {
-	hash, err := blake2b.New256(msg)
-	if err != nil {
+	if len(pubKey) != ed25519.PublicKeySize {
 		return false
 	}
 
-	return ed25519.Verify(ed25519.PublicKey(pubKey), hash.Sum([]byte{}), signature)
+	hash := blake2b.Sum256(msg)
+	return ed25519.Verify(ed25519.PublicKey(pubKey), hash[:], signature)
 }
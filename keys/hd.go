@@ -0,0 +1,193 @@
+package keys
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/go-playground/validator"
+	"github.com/pkg/errors"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// DefaultDerivationPath is the BIP44 derivation path of the first account used by most Tezos wallets.
+const DefaultDerivationPath = "m/44'/1729'/0'/0'"
+
+const hardenedOffset uint32 = 0x80000000
+
+/*
+DeriveAccountsInput is the input for the keys.DeriveAccounts function.
+
+Note:
+	DerivationPath defaults to DefaultDerivationPath. Accounts are enumerated by
+	incrementing the account (third) element of the path, e.g. m/44'/1729'/0'/0',
+	m/44'/1729'/1'/0', m/44'/1729'/2'/0'...
+
+Function:
+	func DeriveAccounts(input DeriveAccountsInput) ([]Key, error) {}
+*/
+type DeriveAccountsInput struct {
+	Mnemonic       string `validate:"required"`
+	Password       string
+	DerivationPath string
+	Count          int    `validate:"required,min=1"`
+	Kind           ECKind `validate:"required"`
+}
+
+// DeriveAccounts derives the first Count accounts of a mnemonic.
+func DeriveAccounts(input DeriveAccountsInput) ([]Key, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return []Key{}, errors.Wrap(err, "invalid input")
+	}
+
+	if input.DerivationPath == "" {
+		input.DerivationPath = DefaultDerivationPath
+	}
+
+	path, err := parseDerivationPath(input.DerivationPath)
+	if err != nil {
+		return []Key{}, err
+	}
+	if len(path) < 3 {
+		return []Key{}, errors.Errorf("invalid derivation path '%s': missing account element", input.DerivationPath)
+	}
+
+	seed, err := bip39.NewSeedWithErrorChecking(input.Mnemonic, input.Password)
+	if err != nil {
+		return []Key{}, err
+	}
+
+	var keys []Key
+	for i := 0; i < input.Count; i++ {
+		accountPath := append([]uint32{}, path...)
+		accountPath[2] = path[2] + uint32(i)
+
+		privKey, err := deriveKey(seed, accountPath, input.Kind)
+		if err != nil {
+			return []Key{}, errors.Wrapf(err, "failed to derive account %d", i)
+		}
+
+		k, err := key(privKey, input.Kind)
+		if err != nil {
+			return []Key{}, errors.Wrapf(err, "failed to derive account %d", i)
+		}
+		keys = append(keys, k)
+	}
+
+	return keys, nil
+}
+
+// parseDerivationPath parses a derivation path such as m/44'/1729'/0'/0'. Hardened elements are marked with ' or h.
+func parseDerivationPath(path string) ([]uint32, error) {
+	elements := strings.Split(strings.TrimSpace(path), "/")
+	if len(elements) == 0 || elements[0] != "m" {
+		return []uint32{}, errors.Errorf("invalid derivation path '%s': must start with m", path)
+	}
+
+	var indexes []uint32
+	for _, element := range elements[1:] {
+		var offset uint32
+		if strings.HasSuffix(element, "'") || strings.HasSuffix(element, "h") {
+			element = element[:len(element)-1]
+			offset = hardenedOffset
+		}
+
+		i, err := strconv.ParseUint(element, 10, 32)
+		if err != nil || uint32(i) >= hardenedOffset {
+			return []uint32{}, errors.Errorf("invalid derivation path '%s': invalid element '%s'", path, element)
+		}
+
+		indexes = append(indexes, uint32(i)+offset)
+	}
+
+	return indexes, nil
+}
+
+// deriveKey derives a private key from a BIP39 seed using SLIP-10 (https://github.com/satoshilabs/slips/blob/master/slip-0010.md),
+// which is equivalent to BIP32 for secp256k1.
+func deriveKey(seed []byte, path []uint32, kind ECKind) ([]byte, error) {
+	var (
+		curveSeed string
+		n         *big.Int
+	)
+	switch kind {
+	case Ed25519:
+		curveSeed = "ed25519 seed"
+	case Secp256k1:
+		curveSeed = "Bitcoin seed"
+		n = btcec.S256().N
+	case NistP256:
+		curveSeed = "Nist256p1 seed"
+		n = elliptic.P256().Params().N
+	default:
+		return []byte{}, errors.Errorf("unsupported curve '%s'", kind)
+	}
+
+	i := hmacSHA512([]byte(curveSeed), seed)
+	for n != nil && !inOrder(i[:32], n) {
+		i = hmacSHA512([]byte(curveSeed), i)
+	}
+	privKey, chainCode := i[:32], i[32:]
+
+	for _, index := range path {
+		data := make([]byte, 0, 37)
+		if index >= hardenedOffset {
+			data = append(data, 0)
+			data = append(data, privKey...)
+		} else {
+			if kind == Ed25519 {
+				return []byte{}, errors.New("ed25519 only supports hardened derivation")
+			}
+
+			pubKey, err := getCurve(kind).getPublicKey(privKey)
+			if err != nil {
+				return []byte{}, err
+			}
+			data = append(data, pubKey...)
+		}
+		data = append(data, ser32(index)...)
+
+		for {
+			i = hmacSHA512(chainCode, data)
+			if n == nil {
+				privKey, chainCode = i[:32], i[32:]
+				break
+			}
+
+			child := new(big.Int).SetBytes(i[:32])
+			child.Add(child, new(big.Int).SetBytes(privKey))
+			child.Mod(child, n)
+			if inOrder(i[:32], n) && child.Sign() != 0 {
+				privKey, chainCode = padScalar(child), i[32:]
+				break
+			}
+
+			data = append(append([]byte{1}, i[32:]...), ser32(index)...)
+		}
+	}
+
+	return privKey, nil
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	h := hmac.New(sha512.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func ser32(i uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, i)
+	return b
+}
+
+func inOrder(v []byte, n *big.Int) bool {
+	i := new(big.Int).SetBytes(v)
+	return i.Sign() != 0 && i.Cmp(n) < 0
+}
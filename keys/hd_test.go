@@ -0,0 +1,113 @@
+package keys
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v3/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_deriveKey(t *testing.T) {
+	// https://github.com/satoshilabs/slips/blob/master/slip-0010.md test vector 1
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+	type want struct {
+		wantErr     bool
+		containsErr string
+		privateKey  string
+	}
+
+	cases := []struct {
+		name string
+		path string
+		kind ECKind
+		want want
+	}{
+		{
+			"is successful with ed25519 master",
+			"m",
+			Ed25519,
+			want{false, "", "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7"},
+		},
+		{
+			"is successful with ed25519 hardened",
+			"m/0'/1'",
+			Ed25519,
+			want{false, "", "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2"},
+		},
+		{
+			"handles ed25519 non hardened",
+			"m/0'/1",
+			Ed25519,
+			want{true, "ed25519 only supports hardened derivation", ""},
+		},
+		{
+			"is successful with secp256k1 hardened",
+			"m/0h",
+			Secp256k1,
+			want{false, "", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		},
+		{
+			"is successful with secp256k1 non hardened",
+			"m/0'/1",
+			Secp256k1,
+			want{false, "", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		},
+		{
+			"is successful with nistp256 hardened",
+			"m/0'",
+			NistP256,
+			want{false, "", "6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c"},
+		},
+		{
+			"handles invalid path",
+			"44'/1729'",
+			Ed25519,
+			want{true, "must start with m", ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := parseDerivationPath(tt.path)
+			if err == nil {
+				var privateKey []byte
+				privateKey, err = deriveKey(seed, path, tt.kind)
+				assert.Equal(t, tt.want.privateKey, hex.EncodeToString(privateKey))
+			}
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+		})
+	}
+}
+
+func Test_DeriveAccounts(t *testing.T) {
+	mnemonic := "normal dash crumble neutral reflect parrot know stairs culture fault check whale flock dog scout"
+
+	keys, err := DeriveAccounts(DeriveAccountsInput{
+		Mnemonic: mnemonic,
+		Count:    3,
+		Kind:     Ed25519,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, keys, 3)
+
+	for i, path := range []string{"m/44'/1729'/0'/0'", "m/44'/1729'/1'/0'", "m/44'/1729'/2'/0'"} {
+		key, err := NewKey(NewKeyInput{
+			Mnemonic:       mnemonic,
+			DerivationPath: path,
+			Kind:           Ed25519,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, key.GetSecretKey(), keys[i].GetSecretKey())
+	}
+	assert.NotEqual(t, keys[0].PubKey.GetPublicKeyHash(), keys[1].PubKey.GetPublicKeyHash())
+
+	_, err = DeriveAccounts(DeriveAccountsInput{
+		Mnemonic:       mnemonic,
+		DerivationPath: "m/44'",
+		Count:          1,
+		Kind:           Ed25519,
+	})
+	testutils.CheckErr(t, true, "missing account element", err)
+}
@@ -8,7 +8,7 @@ import (
 	"fmt"
 
 	"github.com/go-playground/validator"
-	tzcrypt "github.com/goat-systems/go-tezos/v3/internal/crypto"
+	"github.com/goat-systems/go-tezos/v3/b58"
 	"github.com/pkg/errors"
 	"github.com/tyler-smith/go-bip39"
 	"golang.org/x/crypto/nacl/secretbox"
@@ -16,10 +16,11 @@ import (
 )
 
 type fromMnemonicInput struct {
-	Mnemonic string `validate:"required"`
-	Email    string
-	Password string
-	Kind     ECKind `validate:"required"`
+	Mnemonic       string `validate:"required"`
+	Email          string
+	Password       string
+	DerivationPath string
+	Kind           ECKind `validate:"required"`
 }
 
 /*
@@ -33,17 +34,24 @@ Note:
 		* Mnemonic
 		* Mnemonic & Password
 		* Mnemonic & Password & Email
+		* Mnemonic & DerivationPath
+		* Mnemonic & Password & DerivationPath
+
+	Without a DerivationPath the key is derived from the first 32 bytes of the seed, as
+	legacy fundraiser wallets do. With a DerivationPath (e.g. m/44'/1729'/0'/0') the key is
+	derived with SLIP-10 for Ed25519 and NistP256, and BIP32 for Secp256k1.
 
 Function:
 	func NewKey(input NewKeyInput) (Key, error) {}
 */
 type NewKeyInput struct {
-	Bytes         []byte
-	EncodedString string
-	Esk           string
-	Password      string
-	Mnemonic      string
-	Email         string
+	Bytes          []byte
+	EncodedString  string
+	Esk            string
+	Password       string
+	Mnemonic       string
+	Email          string
+	DerivationPath string
 
 	Kind ECKind `validate:"required"`
 }
@@ -54,7 +62,10 @@ VerifyInput is the input for the key.Verify function.
 Note:
 	You can verify with the following combinations:
 		* BytesData & BytesSignature
-		* Data & Signature
+		* Data & Signature (base58 encoded with an edsig, spsig1, p2sig or sig prefix)
+
+	The data is watermarked the same way as with SignInput before verification. Watermark defaults
+	to WatermarkGenericOperation, use WatermarkNone to verify the data as it is.
 
 Function:
 	func Verify(input VerifyInput) bool {}
@@ -64,6 +75,8 @@ type VerifyInput struct {
 	BytesSignature []byte
 	Data           string
 	Signature      string
+	Watermark      Watermark
+	ChainID        string
 }
 
 /*
@@ -75,12 +88,17 @@ Note:
 			or
 		* Bytes
 
+	Watermark selects the bytes prepended before signing and defaults to WatermarkGenericOperation (0x03).
+	Block and consensus operation watermarks also require the ChainID.
+
 Function:
-	func FromMnemonic(input FromMnemonicInput) (Key, error) {}
+	func Sign(input SignInput) (Signature, error) {}
 */
 type SignInput struct {
-	Message string
-	Bytes   []byte
+	Message   string
+	Bytes     []byte
+	Watermark Watermark
+	ChainID   string
 }
 
 // Key is the cryptographic key to a Tezos Wallet
@@ -123,11 +141,18 @@ func NewKey(input NewKeyInput) (Key, error) {
 			return key(v, input.Kind)
 		}
 
-		fmt.Println("HERE")
-
 		//base58
 		if curve, err := getCurveByPrefix(input.EncodedString[0:4]); err == nil {
-			return key(tzcrypt.B58cdecode(input.EncodedString, curve.privateKeyPrefix()), curve.getECKind())
+			prefixes := []b58.Prefix{curve.privateKeyPrefix()}
+			if curve.getECKind() == Ed25519 {
+				prefixes = append(prefixes, b58.Ed25519Seed)
+			}
+
+			_, v, err := b58.DecodePrefix(input.EncodedString, prefixes...)
+			if err != nil {
+				return Key{}, errors.Wrap(err, "failed to decode secret key")
+			}
+			return key(v, curve.getECKind())
 		}
 	}
 
@@ -138,10 +163,11 @@ func NewKey(input NewKeyInput) (Key, error) {
 
 	if input.Mnemonic != "" {
 		return fromMnemonic(fromMnemonicInput{
-			Mnemonic: input.Mnemonic,
-			Email:    input.Email,
-			Password: input.Password,
-			Kind:     input.Kind,
+			Mnemonic:       input.Mnemonic,
+			Email:          input.Email,
+			Password:       input.Password,
+			DerivationPath: input.DerivationPath,
+			Kind:           input.Kind,
 		})
 	}
 
@@ -149,6 +175,10 @@ func NewKey(input NewKeyInput) (Key, error) {
 }
 
 func key(v []byte, kind ECKind) (Key, error) {
+	if len(v) < 32 {
+		return Key{}, errors.New("invalid bytes length")
+	}
+
 	curve := getCurve(kind)
 	pubKey, err := newPubKey(curve.getPrivateKey(v), kind)
 	if err != nil {
@@ -172,33 +202,58 @@ GetSecretKey will return the base58 encoded key with the secret key prefix. This
 Example: edskRpfRbhVr7SjmVpMK1kzTDrSzuCKroxjQAsfJn94X7LgbpqJLvRDHfNHFT9KbCZAXVVhMmkQGz4APscezMbJFov5ZNPSY9H
 */
 func (k *Key) GetSecretKey() string {
-	return tzcrypt.B58cencode(k.privKey, k.curve.privateKeyPrefix())
+	return b58.MustEncode(k.curve.privateKeyPrefix(), k.privKey)
+}
+
+/*
+GetEncryptedSecretKey will return the base58 encoded secret key encrypted with the password passed, in the
+same format as octez-client (pbkdf2 + secretbox with a random salt).
+Example: edesk1fddn27MaLcQVEdZpAYiyGQNm6UjtWiBfNP2ZenTy3CFsoSVJgeHM9pP9cvLJ2r5Xp2quQ5mYexW1LRKee2
+*/
+func (k *Key) GetEncryptedSecretKey(password string) (string, error) {
+	if password == "" {
+		return "", errors.New("failed to encrypt secret key: password is empty")
+	}
+
+	salt := make([]byte, 8)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errors.Wrap(err, "failed to encrypt secret key: could not generate salt")
+	}
+
+	// ed25519 secret keys are encrypted as their 32 byte seed
+	secret := k.privKey
+	if len(secret) > 32 {
+		secret = secret[:32]
+	}
+
+	byteKey := eskKey(password, salt)
+	var emptyNonceBytes [24]byte
+	esm := secretbox.Seal([]byte{}, secret, &emptyNonceBytes, &byteKey)
+
+	return b58.Encode(k.curve.encryptedPrivateKeyPrefix(), append(salt, esm...))
 }
 
 // Sign will either sign a hex encoded string or bytes with Key
 func (k *Key) Sign(input SignInput) (Signature, error) {
-	if input.Bytes != nil {
-		return k.curve.sign(checkAndAddWaterMark(input.Bytes), k.privKey)
-	} else if input.Message != "" {
-		bytes, err := hex.DecodeString(input.Message)
+	msg := input.Bytes
+	if msg == nil {
+		if input.Message == "" {
+			return Signature{}, errors.New("missing Bytes or Message in input")
+		}
+
+		var err error
+		msg, err = hex.DecodeString(input.Message)
 		if err != nil {
 			return Signature{}, errors.Wrap(err, "failed to hex decode message")
 		}
-
-		return k.curve.sign(checkAndAddWaterMark(bytes), k.privKey)
 	}
 
-	return Signature{}, errors.New("missing Bytes or Message in input")
-}
-
-func checkAndAddWaterMark(v []byte) []byte {
-	if v != nil {
-		if v[0] != byte(3) {
-			v = append([]byte{3}, v...)
-		}
+	msg, err := AddWatermark(msg, input.Watermark, input.ChainID)
+	if err != nil {
+		return Signature{}, errors.Wrap(err, "failed to watermark message")
 	}
 
-	return v
+	return k.curve.sign(msg, k.privKey)
 }
 
 // Verify will verify the authenticity of the public key, signature and data.
@@ -207,31 +262,23 @@ func (k *Key) Verify(input VerifyInput) bool {
 }
 
 func fromEsk(esk string, password string) (Key, error) {
-	curve, err := getCurveByPrefix(esk[:5])
+	kind, err := KindFromPrefix(esk)
 	if err != nil {
 		return Key{}, err
 	}
+	curve := getCurve(kind)
 
 	// Convert key from base58 to []byte
-	b58c, err := tzcrypt.Decode(esk)
+	esb, err := b58.DecodeAs(esk, curve.encryptedPrivateKeyPrefix())
 	if err != nil {
-		return Key{}, errors.Wrap(err, "encrypted key is not base58")
+		return Key{}, errors.Wrap(err, "failed to decode encrypted key")
 	}
 
-	// Strip off prefix and extract parts
-	esb := b58c[5:]
+	// Extract parts
 	salt := esb[:8]
 	esm := esb[8:] // encrypted key
 
-	// Convert string pw to []byte
-	passWd := []byte(password)
-
-	// Derive a key from password, salt and number of iterations
-	pbkdf2key := pbkdf2.Key(passWd, salt, 32768, 32, sha512.New)
-	var byteKey [32]byte
-	for i := range pbkdf2key {
-		byteKey[i] = pbkdf2key[i]
-	}
+	byteKey := eskKey(password, salt)
 
 	var out []byte
 	var emptyNonceBytes [24]byte
@@ -244,6 +291,14 @@ func fromEsk(esk string, password string) (Key, error) {
 	return key(unencSecret, curve.getECKind())
 }
 
+// eskKey derives the secretbox key of an encrypted secret key from a password and salt.
+func eskKey(password string, salt []byte) [32]byte {
+	pbkdf2key := pbkdf2.Key([]byte(password), salt, 32768, 32, sha512.New)
+	var byteKey [32]byte
+	copy(byteKey[:], pbkdf2key)
+	return byteKey
+}
+
 // fromMnemonic generates a new Key based off the mnemonic input passed.
 func fromMnemonic(input fromMnemonicInput) (Key, error) {
 	err := validator.New().Struct(input)
@@ -256,5 +311,19 @@ func fromMnemonic(input fromMnemonicInput) (Key, error) {
 		return Key{}, err
 	}
 
+	if input.DerivationPath != "" {
+		path, err := parseDerivationPath(input.DerivationPath)
+		if err != nil {
+			return Key{}, err
+		}
+
+		privKey, err := deriveKey(seed, path, input.Kind)
+		if err != nil {
+			return Key{}, errors.Wrap(err, "failed to derive key")
+		}
+
+		return key(privKey, input.Kind)
+	}
+
 	return key(seed, input.Kind)
 }
@@ -66,6 +66,34 @@ func Test_NewKey(t *testing.T) {
 				"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1",
 			},
 		},
+		{
+			"is successful with secp256k1 base58",
+			NewKeyInput{
+				Kind:          Secp256k1,
+				EncodedString: "spsk2rBDDeUqakQ42nBHDGQTtP3GErb6AahHPwF9bhca3Q5KA5HESE",
+			},
+			want{
+				false,
+				"",
+				"spsk2rBDDeUqakQ42nBHDGQTtP3GErb6AahHPwF9bhca3Q5KA5HESE",
+				"sppk7aqSksZan1AGXuKtCz9UBLZZ77e3ZWGpFxR7ig1Z17GneEhSSbH",
+				"tz2Ch1abG7FNiibmV26Uzgdsnfni9XGrk5wD",
+			},
+		},
+		{
+			"is successful with nistp256 base58",
+			NewKeyInput{
+				Kind:          NistP256,
+				EncodedString: "p2sk2obfVMEuPUnadAConLWk7Tf4Dt3n4svSgJwrgpamRqJXvaYcg1",
+			},
+			want{
+				false,
+				"",
+				"p2sk2obfVMEuPUnadAConLWk7Tf4Dt3n4svSgJwrgpamRqJXvaYcg1",
+				"p2pk66tTYL5EvahKAXncbtbRPBkAnxo3CszzUho5wPCgWauBMyvybuB",
+				"tz3Lfm6CyfSTZ7EgMckptZZGiPxzs9GK59At",
+			},
+		},
 	}
 
 	for _, tt := range cases {
@@ -79,3 +107,127 @@ func Test_NewKey(t *testing.T) {
 	}
 
 }
+
+func Test_Sign(t *testing.T) {
+	type want struct {
+		wantErr     bool
+		containsErr string
+		signature   string
+	}
+
+	cases := []struct {
+		name  string
+		key   NewKeyInput
+		input SignInput
+		want  want
+	}{
+		{
+			"is successful with secp256k1",
+			NewKeyInput{
+				Kind:          Secp256k1,
+				EncodedString: "spsk2rBDDeUqakQ42nBHDGQTtP3GErb6AahHPwF9bhca3Q5KA5HESE",
+			},
+			SignInput{
+				Message: "1234",
+			},
+			want{
+				false,
+				"",
+				"spsig194cg549ti3fNuGqvQs4dZEn4aJHCbXHde4kc4dDaCs5y4nCwp5uMUp4DbyGuTjisaTU2UV1v7vy7CybSGJJS4ur88uBWT",
+			},
+		},
+		{
+			"is successful with nistp256",
+			NewKeyInput{
+				Kind:          NistP256,
+				EncodedString: "p2sk2obfVMEuPUnadAConLWk7Tf4Dt3n4svSgJwrgpamRqJXvaYcg1",
+			},
+			SignInput{
+				Message: "1234",
+			},
+			want{
+				false,
+				"",
+				"p2sigQo2SWectj3FPa9Av6PkRYjdAXf5c2WqkBJLxXXHpFjeYDcx2NBVrLaczCMrMXA8vRfni7fRZLXuSLgo8vVCSRjRXqybnr",
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := NewKey(tt.key)
+			assert.Nil(t, err)
+
+			signature, err := key.Sign(tt.input)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.signature, signature.ToBase58())
+			assert.True(t, key.Verify(VerifyInput{
+				BytesData:      []byte{0x12, 0x34},
+				BytesSignature: signature.Bytes,
+			}))
+		})
+	}
+}
+
+func Test_GetEncryptedSecretKey(t *testing.T) {
+	cases := []struct {
+		name     string
+		key      NewKeyInput
+		password string
+		prefix   string
+	}{
+		{
+			"is successful with ed25519",
+			NewKeyInput{
+				Kind:          Ed25519,
+				EncodedString: "edskRxB2DmoyZSyvhsqaJmw5CK6zYT7dbkUfEVSiQeWU1gw3ZMnC99QMMXru3imsbUrLhvuHktrymvNqhMxkhz7Y4LJAtevW5V",
+			},
+			"password12345##",
+			"edesk",
+		},
+		{
+			"is successful with secp256k1",
+			NewKeyInput{
+				Kind:          Secp256k1,
+				EncodedString: "spsk2rBDDeUqakQ42nBHDGQTtP3GErb6AahHPwF9bhca3Q5KA5HESE",
+			},
+			"password12345##",
+			"spesk",
+		},
+		{
+			"is successful with nistp256",
+			NewKeyInput{
+				Kind:          NistP256,
+				EncodedString: "p2sk2obfVMEuPUnadAConLWk7Tf4Dt3n4svSgJwrgpamRqJXvaYcg1",
+			},
+			"password12345##",
+			"p2esk",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := NewKey(tt.key)
+			assert.Nil(t, err)
+
+			esk, err := key.GetEncryptedSecretKey(tt.password)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.prefix, esk[:5])
+
+			decrypted, err := NewKey(NewKeyInput{
+				Esk:      esk,
+				Password: tt.password,
+				Kind:     tt.key.Kind,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, key.GetSecretKey(), decrypted.GetSecretKey())
+
+			_, err = NewKey(NewKeyInput{
+				Esk:      esk,
+				Password: "wrong password",
+				Kind:     tt.key.Kind,
+			})
+			testutils.CheckErr(t, true, "invalid password", err)
+		})
+	}
+}
@@ -1,46 +1,152 @@
 package keys
 
 import (
-	"crypto/ed25519"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/goat-systems/go-tezos/v3/b58"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
 )
 
 var _ iCurve = &nistP256Curve{}
 
+// https://nvlpubs.nist.gov/nistpubs/FIPS/NIST.FIPS.186-4.pdf
 type nistP256Curve struct{}
 
-func (e *nistP256Curve) addressPrefix() []byte {
-	return []byte{6, 161, 161}
+func (e *nistP256Curve) addressPrefix() b58.Prefix {
+	return b58.P256PublicKeyHash
+}
+
+func (e *nistP256Curve) publicKeyPrefix() b58.Prefix {
+	return b58.P256PublicKey
 }
 
-func (e *nistP256Curve) publicKeyPrefix() []byte {
-	return []byte{3, 254, 226, 86}
+func (e *nistP256Curve) privateKeyPrefix() b58.Prefix {
+	return b58.P256SecretKey
 }
 
-func (e *nistP256Curve) privateKeyPrefix() []byte {
-	return []byte{17, 162, 224, 201}
+func (e *nistP256Curve) signaturePrefix() b58.Prefix {
+	return b58.P256Signature
 }
 
-func (e *nistP256Curve) signaturePrefix() []byte {
-	return []byte{13, 115, 101, 19, 63}
+func (e *nistP256Curve) encryptedPrivateKeyPrefix() b58.Prefix {
+	return b58.P256EncryptedSecretKey
 }
 
 func (e *nistP256Curve) getECKind() ECKind {
-	return Ed25519
+	return NistP256
 }
 
 func (e *nistP256Curve) getPrivateKey(v []byte) []byte {
-	ed25519.GenerateKey(nil)
-	return []byte{}
+	return v[:32]
 }
 
 func (e *nistP256Curve) getPublicKey(privateKey []byte) ([]byte, error) {
-	return []byte{}, nil
+	if err := validScalar(privateKey, elliptic.P256().Params().N); err != nil {
+		return []byte{}, err
+	}
+
+	x, y := elliptic.P256().ScalarBaseMult(privateKey)
+	return elliptic.MarshalCompressed(elliptic.P256(), x, y), nil
 }
 
 func (e *nistP256Curve) sign(msg []byte, privateKey []byte) (Signature, error) {
-	return Signature{}, nil
+	curve := elliptic.P256()
+	n := curve.Params().N
+	if err := validScalar(privateKey, n); err != nil {
+		return Signature{}, err
+	}
+
+	hash := blake2b.Sum256(msg)
+	d := new(big.Int).SetBytes(privateKey)
+	z := new(big.Int).SetBytes(hash[:])
+
+	nonce := nonceRFC6979(d, hash[:], n)
+	for i := 0; i < 16; i++ {
+		k := nonce()
+		r, _ := curve.ScalarBaseMult(padScalar(k))
+		r.Mod(r, n)
+		if r.Sign() == 0 {
+			continue
+		}
+
+		s := new(big.Int).Mul(d, r)
+		s.Add(s, z)
+		s.Mul(s, new(big.Int).ModInverse(k, n))
+		s.Mod(s, n)
+		if s.Sign() == 0 {
+			continue
+		}
+
+		return Signature{
+			Bytes:  append(padScalar(r), padScalar(s)...),
+			Prefix: e.signaturePrefix().Bytes,
+		}, nil
+	}
+
+	return Signature{}, errors.New("failed to sign operation bytes: could not find a valid nonce")
 }
 
-func (e *nistP256Curve) verify(v []byte, signature []byte, pubKey []byte) bool {
-	return false
+func (e *nistP256Curve) verify(msg []byte, signature []byte, pubKey []byte) bool {
+	if len(signature) != 64 {
+		return false
+	}
+
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), pubKey)
+	if x == nil {
+		return false
+	}
+
+	hash := blake2b.Sum256(msg)
+	return ecdsa.Verify(
+		&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y},
+		hash[:],
+		new(big.Int).SetBytes(signature[:32]),
+		new(big.Int).SetBytes(signature[32:]),
+	)
+}
+
+// nonceRFC6979 returns a generator of deterministic nonce candidates for a private key and 32 byte hash
+// as described in https://tools.ietf.org/html/rfc6979#section-3.2 with HMAC-SHA256.
+func nonceRFC6979(d *big.Int, hash []byte, n *big.Int) func() *big.Int {
+	mac := func(k, m []byte) []byte {
+		h := hmac.New(sha256.New, k)
+		h.Write(m)
+		return h.Sum(nil)
+	}
+
+	h := new(big.Int).SetBytes(hash)
+	if h.Cmp(n) >= 0 {
+		h.Sub(h, n)
+	}
+	bx := append(padScalar(d), padScalar(h)...)
+
+	v := bytes.Repeat([]byte{1}, 32)
+	k := make([]byte, 32)
+	k = mac(k, append(append(append([]byte{}, v...), 0x00), bx...))
+	v = mac(k, v)
+	k = mac(k, append(append(append([]byte{}, v...), 0x01), bx...))
+	v = mac(k, v)
+
+	first := true
+	return func() *big.Int {
+		for {
+			if !first {
+				k = mac(k, append(append([]byte{}, v...), 0x00))
+				v = mac(k, v)
+			}
+			first = false
+
+			v = mac(k, v)
+			candidate := new(big.Int).SetBytes(v)
+			if candidate.Sign() > 0 && candidate.Cmp(n) < 0 {
+				return candidate
+			}
+		}
+	}
 }
@@ -6,7 +6,7 @@ import (
 	"encoding/hex"
 
 	"github.com/go-playground/validator"
-	tzcrypt "github.com/goat-systems/go-tezos/v3/internal/crypto"
+	"github.com/goat-systems/go-tezos/v3/b58"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/blake2b"
 )
@@ -53,20 +53,26 @@ func NewPubKey(input NewPubKeyInput) (PubKey, error) {
 	}
 
 	if input.Bytes != nil {
-		return newPubKey(input.Bytes, input.Kind)
+		return pubKeyFromBytes(input.Bytes, input.Kind)
 	}
 
 	if v, err := hex.DecodeString(input.String); err == nil {
-		return newPubKey(v, input.Kind)
+		return pubKeyFromBytes(v, input.Kind)
 	}
 
 	if v, err := base64.StdEncoding.DecodeString(input.String); err == nil {
-		return newPubKey(v, input.Kind)
+		return pubKeyFromBytes(v, input.Kind)
 	}
 
 	//base58
-	if curve, err := getCurveByPrefix(input.String[0:4]); err == nil {
-		newPubKey(tzcrypt.B58cdecode(input.String, curve.privateKeyPrefix()), curve.getECKind())
+	if len(input.String) > 4 {
+		if curve, err := getCurveByPrefix(input.String[0:4]); err == nil {
+			v, err := b58.DecodeAs(input.String, curve.publicKeyPrefix())
+			if err != nil {
+				return PubKey{}, errors.Wrap(err, "failed to decode public key")
+			}
+			return pubKeyFromBytes(v, curve.getECKind())
+		}
 	}
 
 	return PubKey{}, errors.New("unsupported encoding: not hex: not base64: not base58")
@@ -83,6 +89,15 @@ func newPubKey(v []byte, kind ECKind) (PubKey, error) {
 		return PubKey{}, err
 	}
 
+	return pubKeyFromBytes(pk, kind)
+}
+
+func pubKeyFromBytes(pk []byte, kind ECKind) (PubKey, error) {
+	curve := getCurve(kind)
+	if (kind == Ed25519 && len(pk) != 32) || (kind != Ed25519 && len(pk) != 33) {
+		return PubKey{}, errors.Errorf("invalid public key length %d for %s", len(pk), kind)
+	}
+
 	hash, err := blake2b.New(20, []byte{})
 	if err != nil {
 		return PubKey{}, errors.Wrapf(err, "could not generate public hash from public key %s", string(pk))
@@ -95,7 +110,7 @@ func newPubKey(v []byte, kind ECKind) (PubKey, error) {
 	return PubKey{
 		curve:   curve,
 		pubKey:  pk,
-		address: tzcrypt.B58cencode(hash.Sum(nil), curve.addressPrefix()),
+		address: b58.MustEncode(curve.addressPrefix(), hash.Sum(nil)),
 	}, nil
 }
 
@@ -110,7 +125,7 @@ Example:
 	edskRpfRbhVr7SjmVpMK1kzTDrSzuCKroxjQAsfJn94X7LgbpqJLvRDHfNHFT9KbCZAXVVhMmkQGz4APscezMbJFov5ZNPSY9H
 */
 func (p *PubKey) GetPublicKey() string {
-	return tzcrypt.B58cencode(p.pubKey, p.curve.publicKeyPrefix())
+	return b58.MustEncode(p.curve.publicKeyPrefix(), p.pubKey)
 }
 
 /*
@@ -122,12 +137,33 @@ func (p *PubKey) GetPublicKeyHash() string {
 	return p.address
 }
 
-// Verify will verify the authenticity of the public key, signature and data.
+/*
+Verify will verify the authenticity of the public key, signature and data. Signature may be encoded with
+the curve specific (edsig, spsig1, p2sig) or generic (sig) prefix.
+*/
 func (p *PubKey) Verify(input VerifyInput) bool {
+	var data, signature []byte
 	if input.BytesData != nil && input.BytesSignature != nil {
-		return p.curve.verify(input.BytesData, input.BytesSignature, p.pubKey)
+		data, signature = input.BytesData, input.BytesSignature
 	} else if input.Data != "" && input.Signature != "" {
-		return p.curve.verify([]byte(input.Data), tzcrypt.B58cdecode(input.Signature, p.curve.signaturePrefix()), p.pubKey)
+		sig, err := ParseSignature(input.Signature)
+		if err != nil {
+			return false
+		}
+
+		// curve specific signatures must match the curve of the public key, generic ones can't be checked
+		if kind, ok := sig.Kind(); ok && kind != p.curve.getECKind() {
+			return false
+		}
+		data, signature = []byte(input.Data), sig.Bytes
+	} else {
+		return false
 	}
-	return false
+
+	data, err := AddWatermark(data, input.Watermark, input.ChainID)
+	if err != nil {
+		return false
+	}
+
+	return p.curve.verify(data, signature, p.pubKey)
 }
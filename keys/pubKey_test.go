@@ -0,0 +1,87 @@
+package keys
+
+import (
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v3/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewPubKey(t *testing.T) {
+	type want struct {
+		wantErr     bool
+		containsErr string
+		publicKey   string
+		address     string
+	}
+
+	cases := []struct {
+		name  string
+		input NewPubKeyInput
+		want  want
+	}{
+		{
+			"is successful with ed25519 base58",
+			NewPubKeyInput{
+				Kind:   Ed25519,
+				String: "edpkvEoAbkdaGALxi2FfeefB8hUkMZ4J1UVwkzyumx2GvbVpkYUHnm",
+			},
+			want{
+				false,
+				"",
+				"edpkvEoAbkdaGALxi2FfeefB8hUkMZ4J1UVwkzyumx2GvbVpkYUHnm",
+				"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1",
+			},
+		},
+		{
+			"is successful with secp256k1 base58",
+			NewPubKeyInput{
+				Kind:   Secp256k1,
+				String: "sppk7aqSksZan1AGXuKtCz9UBLZZ77e3ZWGpFxR7ig1Z17GneEhSSbH",
+			},
+			want{
+				false,
+				"",
+				"sppk7aqSksZan1AGXuKtCz9UBLZZ77e3ZWGpFxR7ig1Z17GneEhSSbH",
+				"tz2Ch1abG7FNiibmV26Uzgdsnfni9XGrk5wD",
+			},
+		},
+		{
+			"is successful with nistp256 base58",
+			NewPubKeyInput{
+				Kind:   NistP256,
+				String: "p2pk66tTYL5EvahKAXncbtbRPBkAnxo3CszzUho5wPCgWauBMyvybuB",
+			},
+			want{
+				false,
+				"",
+				"p2pk66tTYL5EvahKAXncbtbRPBkAnxo3CszzUho5wPCgWauBMyvybuB",
+				"tz3Lfm6CyfSTZ7EgMckptZZGiPxzs9GK59At",
+			},
+		},
+		{
+			"handles invalid public key length",
+			NewPubKeyInput{
+				Kind:  NistP256,
+				Bytes: []byte{1, 2, 3},
+			},
+			want{
+				true,
+				"invalid public key length",
+				"",
+				"",
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			pubKey, err := NewPubKey(tt.input)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			if !tt.want.wantErr {
+				assert.Equal(t, tt.want.publicKey, pubKey.GetPublicKey())
+				assert.Equal(t, tt.want.address, pubKey.GetPublicKeyHash())
+			}
+		})
+	}
+}
@@ -2,10 +2,16 @@ package keys
 
 import (
 	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/goat-systems/go-tezos/v3/b58"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
 )
 
 var _ iCurve = &secp256k1Curve{}
 
+// https://www.secg.org/sec2-v2.pdf
 type secp256k1Curve struct{}
 
 func order() *big.Int {
@@ -18,20 +24,24 @@ func maxS() *big.Int {
 	return i
 }
 
-func (e *secp256k1Curve) addressPrefix() []byte {
-	return []byte{6, 161, 161}
+func (e *secp256k1Curve) addressPrefix() b58.Prefix {
+	return b58.Secp256k1PublicKeyHash
+}
+
+func (e *secp256k1Curve) publicKeyPrefix() b58.Prefix {
+	return b58.Secp256k1PublicKey
 }
 
-func (e *secp256k1Curve) publicKeyPrefix() []byte {
-	return []byte{3, 254, 226, 86}
+func (e *secp256k1Curve) privateKeyPrefix() b58.Prefix {
+	return b58.Secp256k1SecretKey
 }
 
-func (e *secp256k1Curve) privateKeyPrefix() []byte {
-	return []byte{17, 162, 224, 201}
+func (e *secp256k1Curve) signaturePrefix() b58.Prefix {
+	return b58.Secp256k1Signature
 }
 
-func (e *secp256k1Curve) signaturePrefix() []byte {
-	return []byte{13, 115, 101, 19, 63}
+func (e *secp256k1Curve) encryptedPrivateKeyPrefix() b58.Prefix {
+	return b58.Secp256k1EncryptedSecretKey
 }
 
 func (e *secp256k1Curve) getECKind() ECKind {
@@ -43,13 +53,79 @@ func (e *secp256k1Curve) getPrivateKey(v []byte) []byte {
 }
 
 func (e *secp256k1Curve) getPublicKey(privateKey []byte) ([]byte, error) {
-	return []byte{}, nil
+	if err := validScalar(privateKey, order()); err != nil {
+		return []byte{}, err
+	}
+
+	_, pubKey := btcec.PrivKeyFromBytes(btcec.S256(), privateKey)
+	return pubKey.SerializeCompressed(), nil
 }
 
 func (e *secp256k1Curve) sign(msg []byte, privateKey []byte) (Signature, error) {
-	return Signature{}, nil
+	if err := validScalar(privateKey, order()); err != nil {
+		return Signature{}, err
+	}
+
+	hash := blake2b.Sum256(msg)
+	privKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), privateKey)
+
+	// btcec signs deterministically according to RFC6979
+	sig, err := privKey.Sign(hash[:])
+	if err != nil {
+		return Signature{}, errors.Wrap(err, "failed to sign operation bytes")
+	}
+
+	// the node only accepts signatures in the lower half of the curve order
+	if sig.S.Cmp(maxS()) > 0 {
+		sig.S = new(big.Int).Sub(order(), sig.S)
+	}
+
+	return Signature{
+		Bytes:  append(padScalar(sig.R), padScalar(sig.S)...),
+		Prefix: e.signaturePrefix().Bytes,
+	}, nil
+}
+
+func (e *secp256k1Curve) verify(msg []byte, signature []byte, pubKey []byte) bool {
+	if len(signature) != 64 {
+		return false
+	}
+
+	pk, err := btcec.ParsePubKey(pubKey, btcec.S256())
+	if err != nil {
+		return false
+	}
+
+	sig := &btcec.Signature{
+		R: new(big.Int).SetBytes(signature[:32]),
+		S: new(big.Int).SetBytes(signature[32:]),
+	}
+	if sig.S.Cmp(maxS()) > 0 {
+		return false
+	}
+
+	hash := blake2b.Sum256(msg)
+	return sig.Verify(hash[:], pk)
+}
+
+// validScalar checks that a private key is a non zero scalar lower than the curve order.
+func validScalar(privateKey []byte, n *big.Int) error {
+	if len(privateKey) != 32 {
+		return errors.Errorf("invalid private key length %d: expected 32", len(privateKey))
+	}
+
+	d := new(big.Int).SetBytes(privateKey)
+	if d.Sign() == 0 || d.Cmp(n) >= 0 {
+		return errors.New("invalid private key: out of curve order range")
+	}
+
+	return nil
 }
 
-func (e *secp256k1Curve) verify(v []byte, signature []byte, pubKey []byte) bool {
-	return false
+// padScalar left pads the big endian representation of a scalar to 32 bytes.
+func padScalar(i *big.Int) []byte {
+	out := make([]byte, 32)
+	b := i.Bytes()
+	copy(out[32-len(b):], b)
+	return out
 }
@@ -1,9 +1,11 @@
 package keys
 
 import (
+	"bytes"
 	"encoding/hex"
 
-	"github.com/goat-systems/go-tezos/v3/internal/crypto"
+	"github.com/goat-systems/go-tezos/v3/b58"
+	"github.com/pkg/errors"
 )
 
 // Signature represents the signature of an operation
@@ -12,6 +14,59 @@ type Signature struct {
 	Prefix []byte
 }
 
+/*
+ParseSignature decodes a base58 encoded signature with a curve specific (edsig, spsig1, p2sig) or
+generic (sig) prefix.
+
+Parameters:
+
+	signature:
+		The base58 encoded signature.
+*/
+func ParseSignature(signature string) (Signature, error) {
+	prefixes := []b58.Prefix{b58.GenericSignature}
+	for _, kind := range []ECKind{Ed25519, Secp256k1, NistP256} {
+		prefixes = append(prefixes, getCurve(kind).signaturePrefix())
+	}
+
+	prefix, v, err := b58.DecodePrefix(signature, prefixes...)
+	if err != nil {
+		return Signature{}, errors.Wrap(err, "failed to parse signature")
+	}
+
+	return Signature{
+		Bytes:  v,
+		Prefix: prefix.Bytes,
+	}, nil
+}
+
+// Kind returns the curve of the signature, or false if the signature is generic.
+func (s *Signature) Kind() (ECKind, bool) {
+	for _, kind := range []ECKind{Ed25519, Secp256k1, NistP256} {
+		if bytes.Equal(s.Prefix, getCurve(kind).signaturePrefix().Bytes) {
+			return kind, true
+		}
+	}
+
+	return "", false
+}
+
+// ToGeneric returns the signature with the generic (sig) prefix.
+func (s *Signature) ToGeneric() Signature {
+	return Signature{
+		Bytes:  s.Bytes,
+		Prefix: b58.GenericSignature.Bytes,
+	}
+}
+
+// ToCurve returns the signature with the prefix of the curve passed (edsig, spsig1 or p2sig).
+func (s *Signature) ToCurve(kind ECKind) Signature {
+	return Signature{
+		Bytes:  s.Bytes,
+		Prefix: getCurve(kind).signaturePrefix().Bytes,
+	}
+}
+
 // ToBytes returns the signature as bytes
 func (s *Signature) ToBytes() []byte {
 	return s.Bytes
@@ -19,7 +74,7 @@ func (s *Signature) ToBytes() []byte {
 
 // ToBase58 returns the signature as a base58 encoded string with the correct prefix
 func (s *Signature) ToBase58() string {
-	return crypto.B58cencode(s.Bytes, s.Prefix)
+	return b58.EncodeCheck(append(append([]byte{}, s.Prefix...), s.Bytes...))
 }
 
 // ToHex returns the signature encoded to hex
@@ -0,0 +1,89 @@
+package keys
+
+import (
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v3/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseSignature(t *testing.T) {
+	type want struct {
+		wantErr     bool
+		containsErr string
+		kind        ECKind
+		generic     bool
+	}
+
+	cases := []struct {
+		name      string
+		signature string
+		want      want
+	}{
+		{
+			"is successful with spsig1",
+			"spsig194cg549ti3fNuGqvQs4dZEn4aJHCbXHde4kc4dDaCs5y4nCwp5uMUp4DbyGuTjisaTU2UV1v7vy7CybSGJJS4ur88uBWT",
+			want{false, "", Secp256k1, false},
+		},
+		{
+			"is successful with p2sig",
+			"p2sigQo2SWectj3FPa9Av6PkRYjdAXf5c2WqkBJLxXXHpFjeYDcx2NBVrLaczCMrMXA8vRfni7fRZLXuSLgo8vVCSRjRXqybnr",
+			want{false, "", NistP256, false},
+		},
+		{
+			"handles invalid checksum",
+			"p2sigQo2SWectj3FPa9Av6PkRYjdAXf5c2WqkBJLxXXHpFjeYDcx2NBVrLaczCMrMXA8vRfni7fRZLXuSLgo8vVCSRjRXqybnR",
+			want{true, "failed to parse signature", "", false},
+		},
+		{
+			"handles unknown prefix",
+			"edpkvEoAbkdaGALxi2FfeefB8hUkMZ4J1UVwkzyumx2GvbVpkYUHnm",
+			want{true, "unknown base58 prefix", "", false},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, err := ParseSignature(tt.signature)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			if !tt.want.wantErr {
+				kind, ok := sig.Kind()
+				assert.Equal(t, tt.want.kind, kind)
+				assert.Equal(t, !tt.want.generic, ok)
+				assert.Equal(t, tt.signature, sig.ToBase58())
+
+				generic := sig.ToGeneric()
+				assert.Equal(t, "sig", generic.ToBase58()[:3])
+				_, ok = generic.Kind()
+				assert.False(t, ok)
+
+				parsed, err := ParseSignature(generic.ToBase58())
+				assert.Nil(t, err)
+				assert.Equal(t, sig.Bytes, parsed.Bytes)
+
+				curve := parsed.ToCurve(kind)
+				assert.Equal(t, tt.signature, curve.ToBase58())
+			}
+		})
+	}
+}
+
+func Test_Verify_Signature(t *testing.T) {
+	key, err := NewKey(NewKeyInput{
+		Kind:          Secp256k1,
+		EncodedString: "spsk2rBDDeUqakQ42nBHDGQTtP3GErb6AahHPwF9bhca3Q5KA5HESE",
+	})
+	assert.Nil(t, err)
+
+	signature, err := key.Sign(SignInput{Bytes: []byte("data")})
+	assert.Nil(t, err)
+
+	generic := signature.ToGeneric()
+	p256 := signature.ToCurve(NistP256)
+
+	assert.True(t, key.PubKey.Verify(VerifyInput{Data: "data", Signature: signature.ToBase58()}))
+	assert.True(t, key.PubKey.Verify(VerifyInput{Data: "data", Signature: generic.ToBase58()}))
+	assert.False(t, key.PubKey.Verify(VerifyInput{Data: "data", Signature: p256.ToBase58()}))
+	assert.False(t, key.PubKey.Verify(VerifyInput{Data: "other", Signature: generic.ToBase58()}))
+	assert.False(t, key.PubKey.Verify(VerifyInput{Data: "data", Signature: "invalid"}))
+}
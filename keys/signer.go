@@ -0,0 +1,32 @@
+package keys
+
+/*
+Signer is implemented by anything that can sign on behalf of a Tezos account, such as a Key held in memory or
+a remote signer.
+
+Note:
+	SignBytes signs the bytes passed as they are. The bytes must already be prefixed with the watermark
+	expected by the node (e.g. 0x03 for operations).
+*/
+type Signer interface {
+	PublicKey() (PubKey, error)
+	PublicKeyHash() string
+	SignBytes(msg []byte) (Signature, error)
+}
+
+var _ Signer = &Key{}
+
+// PublicKey returns the public key of the Key.
+func (k *Key) PublicKey() (PubKey, error) {
+	return k.PubKey, nil
+}
+
+// PublicKeyHash returns the public key hash (address) of the Key.
+func (k *Key) PublicKeyHash() string {
+	return k.PubKey.GetPublicKeyHash()
+}
+
+// SignBytes signs already watermarked bytes with the Key.
+func (k *Key) SignBytes(msg []byte) (Signature, error) {
+	return k.curve.sign(msg, k.privKey)
+}
@@ -0,0 +1,91 @@
+package keys
+
+import (
+	"github.com/goat-systems/go-tezos/v3/b58"
+	"github.com/pkg/errors"
+)
+
+// Watermark is the kind of data being signed, which determines the bytes prepended to it before signing.
+type Watermark string
+
+const (
+	// WatermarkGenericOperation is used for manager and voting operations (0x03). It is the default watermark.
+	WatermarkGenericOperation Watermark = "GenericOperation"
+	// WatermarkBlock is used for Emmy block headers (0x01 + chain id).
+	WatermarkBlock Watermark = "Block"
+	// WatermarkEndorsement is used for Emmy endorsements (0x02 + chain id).
+	WatermarkEndorsement Watermark = "Endorsement"
+	// WatermarkTenderbakeBlock is used for Tenderbake block headers (0x11 + chain id).
+	WatermarkTenderbakeBlock Watermark = "TenderbakeBlock"
+	// WatermarkPreendorsement is used for Tenderbake preendorsements (0x12 + chain id).
+	WatermarkPreendorsement Watermark = "Preendorsement"
+	// WatermarkTenderbakeEndorsement is used for Tenderbake endorsements (0x13 + chain id).
+	WatermarkTenderbakeEndorsement Watermark = "TenderbakeEndorsement"
+	// WatermarkMichelsonData is used for packed Michelson data checked by CHECK_SIGNATURE (0x05).
+	WatermarkMichelsonData Watermark = "MichelsonData"
+	// WatermarkNone signs the bytes as they are.
+	WatermarkNone Watermark = "None"
+)
+
+var watermarkBytes = map[Watermark]byte{
+	WatermarkBlock:                 0x01,
+	WatermarkEndorsement:           0x02,
+	WatermarkGenericOperation:      0x03,
+	WatermarkMichelsonData:         0x05,
+	WatermarkTenderbakeBlock:       0x11,
+	WatermarkPreendorsement:        0x12,
+	WatermarkTenderbakeEndorsement: 0x13,
+}
+
+// requiresChainID reports whether the watermark is followed by the chain id.
+func (w Watermark) requiresChainID() bool {
+	switch w {
+	case WatermarkBlock, WatermarkEndorsement, WatermarkTenderbakeBlock, WatermarkPreendorsement, WatermarkTenderbakeEndorsement:
+		return true
+	}
+	return false
+}
+
+/*
+AddWatermark returns msg prefixed with the watermark passed, as checked by the node.
+
+Parameters:
+
+	msg:
+		The bytes to watermark.
+
+	watermark:
+		The kind of data being signed. Defaults to WatermarkGenericOperation.
+
+	chainID:
+		The base58 chain id (e.g. NetXdQprcVkpaWU), required for block and consensus operation watermarks.
+*/
+func AddWatermark(msg []byte, watermark Watermark, chainID string) ([]byte, error) {
+	if watermark == "" {
+		watermark = WatermarkGenericOperation
+	}
+
+	if watermark == WatermarkNone {
+		return msg, nil
+	}
+
+	b, ok := watermarkBytes[watermark]
+	if !ok {
+		return nil, errors.Errorf("unsupported watermark '%s'", watermark)
+	}
+
+	out := []byte{b}
+	if watermark.requiresChainID() {
+		if chainID == "" {
+			return nil, errors.Errorf("watermark '%s' requires a chain id", watermark)
+		}
+
+		id, err := b58.DecodeAs(chainID, b58.ChainID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid chain id '%s'", chainID)
+		}
+		out = append(out, id...)
+	}
+
+	return append(out, msg...), nil
+}
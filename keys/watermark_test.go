@@ -0,0 +1,82 @@
+package keys
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v3/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AddWatermark(t *testing.T) {
+	type want struct {
+		wantErr     bool
+		containsErr string
+		bytes       string
+	}
+
+	cases := []struct {
+		name      string
+		watermark Watermark
+		chainID   string
+		want      want
+	}{
+		{"is successful with default", "", "", want{false, "", "031234"}},
+		{"is successful with generic operation", WatermarkGenericOperation, "", want{false, "", "031234"}},
+		{"is successful with michelson data", WatermarkMichelsonData, "", want{false, "", "051234"}},
+		{"is successful with none", WatermarkNone, "", want{false, "", "1234"}},
+		{"is successful with block", WatermarkBlock, "NetXdQprcVkpaWU", want{false, "", "017a06a7701234"}},
+		{"is successful with endorsement", WatermarkEndorsement, "NetXdQprcVkpaWU", want{false, "", "027a06a7701234"}},
+		{"is successful with tenderbake block", WatermarkTenderbakeBlock, "NetXdQprcVkpaWU", want{false, "", "117a06a7701234"}},
+		{"is successful with preendorsement", WatermarkPreendorsement, "NetXdQprcVkpaWU", want{false, "", "127a06a7701234"}},
+		{"is successful with tenderbake endorsement", WatermarkTenderbakeEndorsement, "NetXdQprcVkpaWU", want{false, "", "137a06a7701234"}},
+		{"handles missing chain id", WatermarkBlock, "", want{true, "requires a chain id", ""}},
+		{"handles invalid chain id", WatermarkBlock, "BLyvCRkxuTXkx1KeGvrcEXiPYj4p1tFxzvFDhoHE7SFKtmP1rbk", want{true, "invalid chain id", ""}},
+		{"handles unsupported watermark", Watermark("Unknown"), "", want{true, "unsupported watermark", ""}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := AddWatermark([]byte{0x12, 0x34}, tt.watermark, tt.chainID)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.bytes, hex.EncodeToString(v))
+		})
+	}
+}
+
+func Test_Sign_Watermark(t *testing.T) {
+	key, err := NewKey(NewKeyInput{
+		Kind:          Ed25519,
+		EncodedString: "edskRxB2DmoyZSyvhsqaJmw5CK6zYT7dbkUfEVSiQeWU1gw3ZMnC99QMMXru3imsbUrLhvuHktrymvNqhMxkhz7Y4LJAtevW5V",
+	})
+	assert.Nil(t, err)
+
+	signature, err := key.Sign(SignInput{
+		Bytes:     []byte{0x12, 0x34},
+		Watermark: WatermarkTenderbakeBlock,
+		ChainID:   "NetXdQprcVkpaWU",
+	})
+	assert.Nil(t, err)
+
+	assert.True(t, key.Verify(VerifyInput{
+		BytesData:      []byte{0x12, 0x34},
+		BytesSignature: signature.Bytes,
+		Watermark:      WatermarkTenderbakeBlock,
+		ChainID:        "NetXdQprcVkpaWU",
+	}))
+	assert.True(t, key.Verify(VerifyInput{
+		BytesData:      []byte{0x11, 0x7a, 0x06, 0xa7, 0x70, 0x12, 0x34},
+		BytesSignature: signature.Bytes,
+		Watermark:      WatermarkNone,
+	}))
+	assert.False(t, key.Verify(VerifyInput{
+		BytesData:      []byte{0x12, 0x34},
+		BytesSignature: signature.Bytes,
+	}))
+
+	_, err = key.Sign(SignInput{
+		Bytes:     []byte{0x12, 0x34},
+		Watermark: WatermarkEndorsement,
+	})
+	testutils.CheckErr(t, true, "requires a chain id", err)
+}
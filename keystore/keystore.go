@@ -0,0 +1,392 @@
+package keystore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/goat-systems/go-tezos/v3/keys"
+	"github.com/goat-systems/go-tezos/v3/signer"
+	"github.com/pkg/errors"
+)
+
+const (
+	secretKeysFile      = "secret_keys"
+	publicKeysFile      = "public_keys"
+	publicKeyHashesFile = "public_key_hashs"
+
+	unencryptedScheme = "unencrypted:"
+	encryptedScheme   = "encrypted:"
+)
+
+// PasswordFunc is called to get the password of an encrypted secret key.
+type PasswordFunc func(alias string) (string, error)
+
+type entry struct {
+	Name  string          `json:"name"`
+	Value json.RawMessage `json:"value"`
+}
+
+type publicKeyValue struct {
+	Locator string `json:"locator"`
+	Key     string `json:"key,omitempty"`
+}
+
+/*
+Keystore reads and writes an octez-client wallet directory (e.g. ~/.tezos-client) so keys can be shared between
+Go services and octez-client.
+
+Files:
+	secret_keys:      [{"name": "alias", "value": "unencrypted:edsk..." | "encrypted:edesk..." | "http://signer/tz1..."}]
+	public_keys:      [{"name": "alias", "value": {"locator": "unencrypted:edpk...", "key": "edpk..."}}]
+	public_key_hashs: [{"name": "alias", "value": "tz1..."}]
+*/
+type Keystore struct {
+	mu              sync.RWMutex
+	dir             string
+	secretKeys      []entry
+	publicKeys      []entry
+	publicKeyHashes []entry
+}
+
+/*
+New returns a Keystore for the wallet directory passed, loading the aliases already present.
+
+Parameters:
+
+	dir:
+		The octez-client base directory, e.g. ~/.tezos-client.
+*/
+func New(dir string) (*Keystore, error) {
+	k := &Keystore{dir: dir}
+
+	for file, entries := range map[string]*[]entry{
+		secretKeysFile:      &k.secretKeys,
+		publicKeysFile:      &k.publicKeys,
+		publicKeyHashesFile: &k.publicKeyHashes,
+	} {
+		v, err := ioutil.ReadFile(filepath.Join(dir, file))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "failed to read '%s'", file)
+		}
+
+		if err := json.Unmarshal(v, entries); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal '%s'", file)
+		}
+	}
+
+	return k, nil
+}
+
+// Aliases returns the aliases of all the public key hashes in the Keystore.
+func (k *Keystore) Aliases() []string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	var aliases []string
+	for _, e := range k.publicKeyHashes {
+		aliases = append(aliases, e.Name)
+	}
+	sort.Strings(aliases)
+
+	return aliases
+}
+
+// PublicKeyHash returns the public key hash (address) of an alias.
+func (k *Keystore) PublicKeyHash(alias string) (string, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	var pkh string
+	if err := k.find(k.publicKeyHashes, alias, &pkh); err != nil {
+		return "", errors.Wrap(err, "failed to get public key hash")
+	}
+
+	return pkh, nil
+}
+
+// PublicKey returns the public key of an alias.
+func (k *Keystore) PublicKey(alias string) (keys.PubKey, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	var raw json.RawMessage
+	if err := k.find(k.publicKeys, alias, &raw); err != nil {
+		return keys.PubKey{}, errors.Wrap(err, "failed to get public key")
+	}
+
+	// older octez-client versions store the locator directly
+	var value publicKeyValue
+	if err := json.Unmarshal(raw, &value); err != nil {
+		if err := json.Unmarshal(raw, &value.Locator); err != nil {
+			return keys.PubKey{}, errors.Wrapf(err, "failed to unmarshal public key of '%s'", alias)
+		}
+	}
+
+	pk := value.Key
+	if pk == "" {
+		if !strings.HasPrefix(value.Locator, unencryptedScheme) {
+			return keys.PubKey{}, fmt.Errorf("failed to get public key: unsupported locator '%s'", value.Locator)
+		}
+		pk = strings.TrimPrefix(value.Locator, unencryptedScheme)
+	}
+
+	kind, err := keys.KindFromPrefix(pk)
+	if err != nil {
+		return keys.PubKey{}, errors.Wrap(err, "failed to get public key")
+	}
+
+	return keys.NewPubKey(keys.NewPubKeyInput{
+		String: pk,
+		Kind:   kind,
+	})
+}
+
+/*
+Key returns the secret key of an alias. Encrypted keys are decrypted with the password returned by password.
+
+Parameters:
+
+	alias:
+		The alias of the key.
+
+	password:
+		Called with the alias if the secret key is encrypted. May be nil if no key is encrypted.
+*/
+func (k *Keystore) Key(alias string, password PasswordFunc) (keys.Key, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	var uri string
+	if err := k.find(k.secretKeys, alias, &uri); err != nil {
+		return keys.Key{}, errors.Wrap(err, "failed to get secret key")
+	}
+
+	switch {
+	case strings.HasPrefix(uri, unencryptedScheme):
+		sk := strings.TrimPrefix(uri, unencryptedScheme)
+		kind, err := keys.KindFromPrefix(sk)
+		if err != nil {
+			return keys.Key{}, errors.Wrap(err, "failed to get secret key")
+		}
+
+		return keys.NewKey(keys.NewKeyInput{
+			EncodedString: sk,
+			Kind:          kind,
+		})
+	case strings.HasPrefix(uri, encryptedScheme):
+		esk := strings.TrimPrefix(uri, encryptedScheme)
+		kind, err := keys.KindFromPrefix(esk)
+		if err != nil {
+			return keys.Key{}, errors.Wrap(err, "failed to get secret key")
+		}
+
+		if password == nil {
+			return keys.Key{}, fmt.Errorf("failed to get secret key: '%s' is encrypted and no password func was passed", alias)
+		}
+
+		pw, err := password(alias)
+		if err != nil {
+			return keys.Key{}, errors.Wrapf(err, "failed to get password for '%s'", alias)
+		}
+
+		return keys.NewKey(keys.NewKeyInput{
+			Esk:      esk,
+			Password: pw,
+			Kind:     kind,
+		})
+	}
+
+	return keys.Key{}, fmt.Errorf("failed to get secret key: unsupported secret key uri for '%s'", alias)
+}
+
+/*
+Signer returns a keys.Signer for an alias. Secret keys held by a remote signer (http:// or https:// uris)
+return a signer.Remote, other secret keys are resolved with Key.
+
+Parameters:
+
+	alias:
+		The alias of the key.
+
+	password:
+		Called with the alias if the secret key is encrypted. May be nil if no key is encrypted.
+*/
+func (k *Keystore) Signer(alias string, password PasswordFunc) (keys.Signer, error) {
+	k.mu.RLock()
+	var uri string
+	err := k.find(k.secretKeys, alias, &uri)
+	k.mu.RUnlock()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get signer")
+	}
+
+	if strings.HasPrefix(uri, "http://") || strings.HasPrefix(uri, "https://") {
+		i := strings.LastIndex(uri, "/")
+		return signer.NewRemote(signer.NewRemoteInput{
+			Host:          uri[:i],
+			PublicKeyHash: uri[i+1:],
+		})
+	}
+
+	key, err := k.Key(alias, password)
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+/*
+Import adds a key to the Keystore under alias and writes the wallet files. The secret key is stored encrypted
+if password is not empty.
+
+Parameters:
+
+	alias:
+		The alias of the key.
+
+	key:
+		The key to import.
+
+	password:
+		The password to encrypt the secret key with, or empty to store it unencrypted.
+
+	force:
+		Overwrites an existing alias if true.
+*/
+func (k *Keystore) Import(alias string, key keys.Key, password string, force bool) error {
+	uri := fmt.Sprintf("%s%s", unencryptedScheme, key.GetSecretKey())
+	if password != "" {
+		esk, err := key.GetEncryptedSecretKey(password)
+		if err != nil {
+			return errors.Wrapf(err, "failed to import '%s'", alias)
+		}
+		uri = fmt.Sprintf("%s%s", encryptedScheme, esk)
+	}
+
+	return k.add(alias, &uri, key.PubKey, force)
+}
+
+/*
+ImportPublicKey adds a public key without its secret key to the Keystore under alias and writes the wallet files.
+
+Parameters:
+
+	alias:
+		The alias of the key.
+
+	pubKey:
+		The public key to import.
+
+	force:
+		Overwrites an existing alias if true.
+*/
+func (k *Keystore) ImportPublicKey(alias string, pubKey keys.PubKey, force bool) error {
+	return k.add(alias, nil, pubKey, force)
+}
+
+// Remove removes an alias from the Keystore and writes the wallet files.
+func (k *Keystore) Remove(alias string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.secretKeys = remove(k.secretKeys, alias)
+	k.publicKeys = remove(k.publicKeys, alias)
+	k.publicKeyHashes = remove(k.publicKeyHashes, alias)
+
+	return k.save()
+}
+
+func (k *Keystore) add(alias string, secretKey *string, pubKey keys.PubKey, force bool) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if !force {
+		for _, e := range k.publicKeyHashes {
+			if e.Name == alias {
+				return fmt.Errorf("failed to import '%s': alias already exists", alias)
+			}
+		}
+	}
+
+	k.secretKeys = remove(k.secretKeys, alias)
+	if secretKey != nil {
+		v, _ := json.Marshal(*secretKey)
+		k.secretKeys = append(k.secretKeys, entry{Name: alias, Value: v})
+	}
+
+	v, _ := json.Marshal(publicKeyValue{
+		Locator: fmt.Sprintf("%s%s", unencryptedScheme, pubKey.GetPublicKey()),
+		Key:     pubKey.GetPublicKey(),
+	})
+	k.publicKeys = append(remove(k.publicKeys, alias), entry{Name: alias, Value: v})
+
+	v, _ = json.Marshal(pubKey.GetPublicKeyHash())
+	k.publicKeyHashes = append(remove(k.publicKeyHashes, alias), entry{Name: alias, Value: v})
+
+	return k.save()
+}
+
+func (k *Keystore) find(entries []entry, alias string, v interface{}) error {
+	for _, e := range entries {
+		if e.Name == alias {
+			if err := json.Unmarshal(e.Value, v); err != nil {
+				return errors.Wrapf(err, "failed to unmarshal '%s'", alias)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown alias '%s'", alias)
+}
+
+func (k *Keystore) save() error {
+	if err := os.MkdirAll(k.dir, 0700); err != nil {
+		return errors.Wrap(err, "failed to create wallet directory")
+	}
+
+	for file, entries := range map[string][]entry{
+		secretKeysFile:      k.secretKeys,
+		publicKeysFile:      k.publicKeys,
+		publicKeyHashesFile: k.publicKeyHashes,
+	} {
+		if entries == nil {
+			entries = []entry{}
+		}
+
+		v, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal '%s'", file)
+		}
+
+		tmp := filepath.Join(k.dir, fmt.Sprintf(".%s.tmp", file))
+		if err := ioutil.WriteFile(tmp, v, 0600); err != nil {
+			return errors.Wrapf(err, "failed to write '%s'", file)
+		}
+
+		if err := os.Rename(tmp, filepath.Join(k.dir, file)); err != nil {
+			return errors.Wrapf(err, "failed to write '%s'", file)
+		}
+	}
+
+	return nil
+}
+
+func remove(entries []entry, alias string) []entry {
+	out := []entry{}
+	for _, e := range entries {
+		if e.Name != alias {
+			out = append(out, e)
+		}
+	}
+
+	return out
+}
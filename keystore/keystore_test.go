@@ -0,0 +1,123 @@
+package keystore
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v3/internal/testutils"
+	"github.com/goat-systems/go-tezos/v3/keys"
+	"github.com/goat-systems/go-tezos/v3/signer"
+	"github.com/stretchr/testify/assert"
+)
+
+func password(pw string) PasswordFunc {
+	return func(alias string) (string, error) {
+		return pw, nil
+	}
+}
+
+func Test_Key(t *testing.T) {
+	ks, err := New(".test-fixtures/wallet")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"alice", "baker", "bob"}, ks.Aliases())
+
+	type want struct {
+		wantErr     bool
+		containsErr string
+		address     string
+	}
+
+	cases := []struct {
+		name     string
+		alias    string
+		password PasswordFunc
+		want     want
+	}{
+		{"is successful with unencrypted key", "alice", nil, want{false, "", "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"}},
+		{"is successful with encrypted key", "bob", password("password12345##"), want{false, "", "tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo"}},
+		{"handles wrong password", "bob", password("wrong"), want{true, "invalid password", ""}},
+		{"handles missing password func", "bob", nil, want{true, "no password func", ""}},
+		{"handles password func error", "bob", func(string) (string, error) { return "", errors.New("cancelled") }, want{true, "cancelled", ""}},
+		{"handles remote key", "baker", nil, want{true, "unsupported secret key uri", ""}},
+		{"handles unknown alias", "carol", nil, want{true, "unknown alias 'carol'", ""}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ks.Key(tt.alias, tt.password)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			if !tt.want.wantErr {
+				assert.Equal(t, tt.want.address, key.PubKey.GetPublicKeyHash())
+
+				pkh, err := ks.PublicKeyHash(tt.alias)
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want.address, pkh)
+
+				pubKey, err := ks.PublicKey(tt.alias)
+				assert.Nil(t, err)
+				assert.Equal(t, key.PubKey.GetPublicKey(), pubKey.GetPublicKey())
+			}
+		})
+	}
+}
+
+func Test_Signer(t *testing.T) {
+	ks, err := New(".test-fixtures/wallet")
+	assert.Nil(t, err)
+
+	s, err := ks.Signer("baker", nil)
+	assert.Nil(t, err)
+	assert.IsType(t, &signer.Remote{}, s)
+	assert.Equal(t, "tz2Ch1abG7FNiibmV26Uzgdsnfni9XGrk5wD", s.PublicKeyHash())
+
+	s, err = ks.Signer("alice", nil)
+	assert.Nil(t, err)
+	assert.IsType(t, &keys.Key{}, s)
+	assert.Equal(t, "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1", s.PublicKeyHash())
+}
+
+func Test_Import(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wallet")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ks, err := New(dir)
+	assert.Nil(t, err)
+
+	key, err := keys.NewKey(keys.NewKeyInput{
+		EncodedString: "p2sk2obfVMEuPUnadAConLWk7Tf4Dt3n4svSgJwrgpamRqJXvaYcg1",
+		Kind:          keys.NistP256,
+	})
+	assert.Nil(t, err)
+
+	assert.Nil(t, ks.Import("plain", key, "", false))
+	assert.Nil(t, ks.Import("secret", key, "password", false))
+	testutils.CheckErr(t, true, "alias already exists", ks.Import("plain", key, "", false))
+	assert.Nil(t, ks.ImportPublicKey("watch", key.PubKey, false))
+
+	reloaded, err := New(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"plain", "secret", "watch"}, reloaded.Aliases())
+
+	plain, err := reloaded.Key("plain", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, key.GetSecretKey(), plain.GetSecretKey())
+
+	secret, err := reloaded.Key("secret", password("password"))
+	assert.Nil(t, err)
+	assert.Equal(t, key.GetSecretKey(), secret.GetSecretKey())
+
+	_, err = reloaded.Key("watch", nil)
+	testutils.CheckErr(t, true, "unknown alias 'watch'", err)
+
+	pubKey, err := reloaded.PublicKey("watch")
+	assert.Nil(t, err)
+	assert.Equal(t, key.PubKey.GetPublicKeyHash(), pubKey.GetPublicKeyHash())
+
+	assert.Nil(t, reloaded.Remove("plain"))
+	reloaded, err = New(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"secret", "watch"}, reloaded.Aliases())
+}
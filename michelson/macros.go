@@ -0,0 +1,264 @@
+package michelson
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	comparisons = "(EQ|NEQ|LT|GT|LE|GE)"
+
+	regexCMP       = regexp.MustCompile("^CMP" + comparisons + "$")
+	regexIF        = regexp.MustCompile("^IF" + comparisons + "$")
+	regexIFCMP     = regexp.MustCompile("^IFCMP" + comparisons + "$")
+	regexASSERT    = regexp.MustCompile("^ASSERT_" + comparisons + "$")
+	regexASSERTCMP = regexp.MustCompile("^ASSERT_CMP" + comparisons + "$")
+	regexDIP       = regexp.MustCompile("^DI(I+)P$")
+	regexDUP       = regexp.MustCompile("^DU(U+)P$")
+	regexCR        = regexp.MustCompile("^C([AD]{2,})R$")
+	regexPAIR      = regexp.MustCompile("^P[PAI]{3,}R$")
+	regexUNPAIR    = regexp.MustCompile("^UNP[PAI]{3,}R$")
+	regexSETCR     = regexp.MustCompile("^SET_C([AD])R$")
+	regexMAPCR     = regexp.MustCompile("^MAP_C([AD])R$")
+)
+
+// pairTree is the shape of a P[PAI]+R macro: a pair of which each side is either a leaf or another pair.
+type pairTree struct {
+	left, right *pairTree
+}
+
+/*
+expandMacro expands a Michelson macro to the instructions it stands for. Prims that aren't macros are
+returned as they are. Annotations of a macro are set on the last instruction of its expansion.
+*/
+func expandMacro(n *node) (*node, error) {
+	if n.kind != kindPrim {
+		return n, nil
+	}
+
+	name := n.value
+	arity := func(expected int) error {
+		if len(n.args) != expected {
+			return errors.Errorf("macro '%s' expects %d arguments, got %d", name, expected, len(n.args))
+		}
+		return nil
+	}
+
+	var expanded *node
+	switch {
+	case name == "FAIL":
+		if err := arity(0); err != nil {
+			return nil, err
+		}
+		expanded = seq(prim("UNIT"), prim("FAILWITH"))
+	case regexCMP.MatchString(name):
+		if err := arity(0); err != nil {
+			return nil, err
+		}
+		expanded = seq(prim("COMPARE"), prim(name[3:]))
+	case regexIFCMP.MatchString(name):
+		if err := arity(2); err != nil {
+			return nil, err
+		}
+		expanded = seq(prim("COMPARE"), prim(name[5:]), prim("IF", n.args...))
+	case regexIF.MatchString(name):
+		if err := arity(2); err != nil {
+			return nil, err
+		}
+		expanded = seq(prim(name[2:]), prim("IF", n.args...))
+	case name == "ASSERT":
+		if err := arity(0); err != nil {
+			return nil, err
+		}
+		expanded = seq(prim("IF", seq(), failSeq()))
+	case regexASSERTCMP.MatchString(name):
+		if err := arity(0); err != nil {
+			return nil, err
+		}
+		expanded = seq(seq(prim("COMPARE"), prim(name[10:]), prim("IF", seq(), failSeq())))
+	case regexASSERT.MatchString(name):
+		if err := arity(0); err != nil {
+			return nil, err
+		}
+		expanded = seq(seq(prim(name[7:]), prim("IF", seq(), failSeq())))
+	case name == "ASSERT_NONE":
+		if err := arity(0); err != nil {
+			return nil, err
+		}
+		expanded = seq(prim("IF_NONE", seq(), failSeq()))
+	case name == "ASSERT_SOME":
+		if err := arity(0); err != nil {
+			return nil, err
+		}
+		expanded = seq(prim("IF_NONE", failSeq(), seq()))
+	case name == "ASSERT_LEFT":
+		if err := arity(0); err != nil {
+			return nil, err
+		}
+		expanded = seq(prim("IF_LEFT", seq(), failSeq()))
+	case name == "ASSERT_RIGHT":
+		if err := arity(0); err != nil {
+			return nil, err
+		}
+		expanded = seq(prim("IF_LEFT", failSeq(), seq()))
+	case name == "IF_SOME":
+		if err := arity(2); err != nil {
+			return nil, err
+		}
+		expanded = seq(prim("IF_NONE", n.args[1], n.args[0]))
+	case name == "IF_RIGHT":
+		if err := arity(2); err != nil {
+			return nil, err
+		}
+		expanded = seq(prim("IF_LEFT", n.args[1], n.args[0]))
+	case regexDIP.MatchString(name):
+		if err := arity(1); err != nil {
+			return nil, err
+		}
+		depth := len(regexDIP.FindStringSubmatch(name)[1]) + 1
+		expanded = seq(prim("DIP", intNode(depth), n.args[0]))
+	case regexDUP.MatchString(name):
+		if err := arity(0); err != nil {
+			return nil, err
+		}
+		depth := len(regexDUP.FindStringSubmatch(name)[1]) + 1
+		expanded = seq(prim("DUP", intNode(depth)))
+	case regexCR.MatchString(name):
+		if err := arity(0); err != nil {
+			return nil, err
+		}
+		expanded = seq()
+		for _, c := range regexCR.FindStringSubmatch(name)[1] {
+			expanded.args = append(expanded.args, prim("C"+string(c)+"R"))
+		}
+	case regexPAIR.MatchString(name):
+		if err := arity(0); err != nil {
+			return nil, err
+		}
+		tree, err := parsePairTree(name[:len(name)-1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid macro '%s'", name)
+		}
+		expanded = seq(expandPair(tree)...)
+	case regexUNPAIR.MatchString(name):
+		if err := arity(0); err != nil {
+			return nil, err
+		}
+		tree, err := parsePairTree(name[2 : len(name)-1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid macro '%s'", name)
+		}
+		expanded = seq(expandUnpair(tree)...)
+	case regexSETCR.MatchString(name):
+		if err := arity(0); err != nil {
+			return nil, err
+		}
+		if name == "SET_CAR" {
+			expanded = seq(prim("CDR"), prim("SWAP"), prim("PAIR"))
+		} else {
+			expanded = seq(prim("CAR"), prim("PAIR"))
+		}
+	case regexMAPCR.MatchString(name):
+		if err := arity(1); err != nil {
+			return nil, err
+		}
+		if name == "MAP_CAR" {
+			expanded = seq(prim("DUP"), prim("CDR"), prim("DIP", seq(prim("CAR"), n.args[0])), prim("SWAP"), prim("PAIR"))
+		} else {
+			expanded = seq(prim("DUP"), prim("CDR"), n.args[0], prim("SWAP"), prim("CAR"), prim("PAIR"))
+		}
+	default:
+		return n, nil
+	}
+
+	if len(n.annots) > 0 {
+		last := expanded.args[len(expanded.args)-1]
+		for last.kind == kindSeq && len(last.args) > 0 {
+			last = last.args[len(last.args)-1]
+		}
+		last.annots = append(last.annots, n.annots...)
+	}
+
+	return expanded, nil
+}
+
+func failSeq() *node {
+	return seq(seq(prim("UNIT"), prim("FAILWITH")))
+}
+
+func intNode(i int) *node {
+	return &node{kind: kindInt, value: strconv.Itoa(i)}
+}
+
+/*
+parsePairTree parses the letters before the R of a pair macro, where P starts a pair, A is a leaf on the
+left and I is a leaf on the right, e.g. PAPAIR is a pair of a leaf and of the pair PAI.
+*/
+func parsePairTree(s string) (*pairTree, error) {
+	var parse func(left bool) (*pairTree, error)
+	parse = func(left bool) (*pairTree, error) {
+		if s == "" {
+			return nil, errors.New("unexpected end of macro")
+		}
+
+		c := s[0]
+		s = s[1:]
+		switch {
+		case c == 'P':
+			l, err := parse(true)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := parse(false)
+			if err != nil {
+				return nil, err
+			}
+
+			return &pairTree{left: l, right: r}, nil
+		case c == 'A' && left, c == 'I' && !left:
+			return nil, nil
+		}
+
+		return nil, errors.Errorf("unexpected '%c'", c)
+	}
+
+	tree, err := parse(true)
+	if err != nil {
+		return nil, err
+	}
+
+	if s != "" {
+		return nil, errors.Errorf("unexpected '%s'", s)
+	}
+
+	return tree, nil
+}
+
+func expandPair(t *pairTree) []*node {
+	var instructions []*node
+	if t.left != nil {
+		instructions = append(instructions, expandPair(t.left)...)
+	}
+
+	if t.right != nil {
+		instructions = append(instructions, prim("DIP", seq(expandPair(t.right)...)))
+	}
+
+	return append(instructions, prim("PAIR"))
+}
+
+func expandUnpair(t *pairTree) []*node {
+	instructions := []*node{prim("UNPAIR")}
+	if t.right != nil {
+		instructions = append(instructions, prim("DIP", seq(expandUnpair(t.right)...)))
+	}
+
+	if t.left != nil {
+		instructions = append(instructions, expandUnpair(t.left)...)
+	}
+
+	return instructions
+}
@@ -0,0 +1,118 @@
+package michelson
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+type nodeKind int
+
+const (
+	kindInt nodeKind = iota
+	kindString
+	kindBytes
+	kindPrim
+	kindSeq
+)
+
+// node is a Micheline node. value holds the int, string, hex bytes or prim name of the node, and args
+// holds the args of a prim or the elements of a sequence.
+type node struct {
+	kind   nodeKind
+	value  string
+	args   []*node
+	annots []string
+}
+
+func prim(name string, args ...*node) *node {
+	return &node{kind: kindPrim, value: name, args: args}
+}
+
+func seq(elements ...*node) *node {
+	return &node{kind: kindSeq, args: elements}
+}
+
+func (n *node) isPrim(name string) bool {
+	return n.kind == kindPrim && n.value == name
+}
+
+// MarshalJSON implements json.Marshaler to marshal the node to Micheline JSON.
+func (n *node) MarshalJSON() ([]byte, error) {
+	switch n.kind {
+	case kindInt:
+		return marshal(struct {
+			Int string `json:"int"`
+		}{n.value})
+	case kindString:
+		return marshal(struct {
+			String string `json:"string"`
+		}{n.value})
+	case kindBytes:
+		return marshal(struct {
+			Bytes string `json:"bytes"`
+		}{n.value})
+	case kindPrim:
+		return marshal(struct {
+			Prim   string   `json:"prim"`
+			Args   []*node  `json:"args,omitempty"`
+			Annots []string `json:"annots,omitempty"`
+		}{n.value, n.args, n.annots})
+	}
+
+	elements := n.args
+	if elements == nil {
+		elements = []*node{}
+	}
+
+	return marshal(elements)
+}
+
+// marshal marshals v to JSON without escaping HTML characters, so that strings like "x<=0" stay as written.
+func marshal(v interface{}) ([]byte, error) {
+	buf := bytes.NewBuffer([]byte{})
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler to unmarshal the node from Micheline JSON.
+func (n *node) UnmarshalJSON(v []byte) error {
+	v = bytes.TrimSpace(v)
+	if len(v) > 0 && v[0] == '[' {
+		n.kind = kindSeq
+		return json.Unmarshal(v, &n.args)
+	}
+
+	var obj struct {
+		Prim   *string  `json:"prim"`
+		Args   []*node  `json:"args"`
+		Annots []string `json:"annots"`
+		Int    *string  `json:"int"`
+		String *string  `json:"string"`
+		Bytes  *string  `json:"bytes"`
+	}
+	if err := json.Unmarshal(v, &obj); err != nil {
+		return errors.Wrap(err, "invalid micheline")
+	}
+
+	switch {
+	case obj.Prim != nil:
+		n.kind, n.value, n.args, n.annots = kindPrim, *obj.Prim, obj.Args, obj.Annots
+	case obj.Int != nil:
+		n.kind, n.value = kindInt, *obj.Int
+	case obj.String != nil:
+		n.kind, n.value = kindString, *obj.String
+	case obj.Bytes != nil:
+		n.kind, n.value = kindBytes, *obj.Bytes
+	default:
+		return errors.Errorf("invalid micheline node '%s'", string(v))
+	}
+
+	return nil
+}
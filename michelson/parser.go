@@ -0,0 +1,413 @@
+package michelson
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/goat-systems/go-tezos/v3/rpc"
+	"github.com/pkg/errors"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenInt
+	tokenString
+	tokenBytes
+	tokenIdent
+	tokenAnnot
+	tokenOpenParen
+	tokenCloseParen
+	tokenOpenBrace
+	tokenCloseBrace
+	tokenSemicolon
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	line  int
+	col   int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of input"
+	}
+
+	return fmt.Sprintf("'%s'", t.value)
+}
+
+/*
+Parse parses Michelson source, e.g. the content of a .tz file or a data expression, into Micheline JSON.
+
+Note:
+	Macros (CMPEQ, IFCMPLT, ASSERT_SOME, DIIP, DUUP, CADR, PAPAIR, UNPAPAIR, SET_CAR, MAP_CDR, IF_SOME, FAIL...)
+	are expanded to the instructions they stand for. A script made of toplevel sections separated by ';'
+	(parameter, storage, code, views) is returned as a sequence.
+
+Parameters:
+
+	source:
+		The Michelson source, e.g. "parameter unit; storage unit; code { CDR; NIL operation; PAIR }".
+*/
+func Parse(source string) (*json.RawMessage, error) {
+	n, err := parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := marshal(n)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal micheline")
+	}
+
+	raw := json.RawMessage(v)
+	return &raw, nil
+}
+
+/*
+Script parses the Michelson source of a contract and of its initial storage into an rpc.Script that can be
+used to originate the contract.
+
+Parameters:
+
+	code:
+		The Michelson source of the contract, e.g. the content of a .tz file.
+
+	storage:
+		The Michelson source of the initial storage, e.g. "Pair 0 {}".
+*/
+func Script(code, storage string) (rpc.Script, error) {
+	c, err := Parse(code)
+	if err != nil {
+		return rpc.Script{}, errors.Wrap(err, "failed to parse code")
+	}
+
+	s, err := Parse(storage)
+	if err != nil {
+		return rpc.Script{}, errors.Wrap(err, "failed to parse storage")
+	}
+
+	return rpc.Script{
+		Code:    c,
+		Storage: s,
+	}, nil
+}
+
+func parse(source string) (*node, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse michelson")
+	}
+
+	p := &parser{tokens: tokens}
+	n, err := p.parseToplevel()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse michelson")
+	}
+
+	return n, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+
+	return t
+}
+
+func (p *parser) expect(kind tokenKind, value string) error {
+	if t := p.next(); t.kind != kind {
+		return errors.Errorf("expected '%s' but got %s at %d:%d", value, t, t.line, t.col)
+	}
+
+	return nil
+}
+
+// parseToplevel parses either a single expression or toplevel sections separated by ';'.
+func (p *parser) parseToplevel() (*node, error) {
+	var (
+		exprs     []*node
+		separated bool
+	)
+
+	for p.peek().kind != tokenEOF {
+		n, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, n)
+
+		if p.peek().kind == tokenSemicolon {
+			p.next()
+			separated = true
+			continue
+		}
+
+		if t := p.peek(); t.kind != tokenEOF {
+			return nil, errors.Errorf("unexpected %s at %d:%d", t, t.line, t.col)
+		}
+	}
+
+	if len(exprs) == 0 {
+		return nil, errors.New("empty source")
+	}
+
+	if len(exprs) == 1 && !separated {
+		return exprs[0], nil
+	}
+
+	return seq(exprs...), nil
+}
+
+// parseExpr parses a prim applied to its annotations and arguments, or a single argument.
+func (p *parser) parseExpr() (*node, error) {
+	t := p.peek()
+	if t.kind != tokenIdent {
+		return p.parseArg()
+	}
+	p.next()
+
+	n := prim(t.value)
+	for p.peek().kind == tokenAnnot {
+		n.annots = append(n.annots, p.next().value)
+	}
+
+	for {
+		switch p.peek().kind {
+		case tokenInt, tokenString, tokenBytes, tokenIdent, tokenOpenParen, tokenOpenBrace:
+			arg, err := p.parseArg()
+			if err != nil {
+				return nil, err
+			}
+			n.args = append(n.args, arg)
+			continue
+		}
+		break
+	}
+
+	expanded, err := expandMacro(n)
+	if err != nil {
+		return nil, errors.Wrapf(err, "at %d:%d", t.line, t.col)
+	}
+
+	return expanded, nil
+}
+
+func (p *parser) parseArg() (*node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenInt:
+		return &node{kind: kindInt, value: t.value}, nil
+	case tokenString:
+		return &node{kind: kindString, value: t.value}, nil
+	case tokenBytes:
+		return &node{kind: kindBytes, value: t.value}, nil
+	case tokenIdent:
+		return expandMacro(prim(t.value))
+	case tokenOpenParen:
+		n, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(tokenCloseParen, ")"); err != nil {
+			return nil, err
+		}
+
+		return n, nil
+	case tokenOpenBrace:
+		return p.parseSeq()
+	}
+
+	return nil, errors.Errorf("unexpected %s at %d:%d", t, t.line, t.col)
+}
+
+// parseSeq parses the elements of a sequence after its opening brace.
+func (p *parser) parseSeq() (*node, error) {
+	s := seq()
+	for {
+		if p.peek().kind == tokenCloseBrace {
+			p.next()
+			return s, nil
+		}
+
+		n, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		s.args = append(s.args, n)
+
+		switch t := p.next(); t.kind {
+		case tokenSemicolon:
+		case tokenCloseBrace:
+			return s, nil
+		default:
+			return nil, errors.Errorf("expected ';' or '}' but got %s at %d:%d", t, t.line, t.col)
+		}
+	}
+}
+
+func lex(source string) ([]token, error) {
+	var (
+		tokens    []token
+		line, col = 1, 1
+	)
+
+	advance := func(n int) {
+		for _, c := range source[:n] {
+			if c == '\n' {
+				line, col = line+1, 1
+			} else {
+				col++
+			}
+		}
+		source = source[n:]
+	}
+
+	for len(source) > 0 {
+		c := source[0]
+		t := token{line: line, col: col}
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			advance(1)
+			continue
+		case c == '#':
+			end := strings.IndexByte(source, '\n')
+			if end == -1 {
+				end = len(source)
+			}
+			advance(end)
+			continue
+		case strings.HasPrefix(source, "/*"):
+			end := strings.Index(source, "*/")
+			if end == -1 {
+				return nil, errors.Errorf("unterminated comment at %d:%d", line, col)
+			}
+			advance(end + 2)
+			continue
+		case c == '(' || c == ')' || c == '{' || c == '}' || c == ';':
+			t.kind = map[byte]tokenKind{
+				'(': tokenOpenParen,
+				')': tokenCloseParen,
+				'{': tokenOpenBrace,
+				'}': tokenCloseBrace,
+				';': tokenSemicolon,
+			}[c]
+			t.value = string(c)
+			advance(1)
+		case c == '"':
+			value, n, err := lexString(source)
+			if err != nil {
+				return nil, errors.Wrapf(err, "at %d:%d", line, col)
+			}
+			t.kind, t.value = tokenString, value
+			advance(n)
+		case strings.HasPrefix(source, "0x"):
+			n := 2 + span(source[2:], isHex)
+			if _, err := hex.DecodeString(source[2:n]); err != nil {
+				return nil, errors.Errorf("invalid bytes '%s' at %d:%d", source[:n], line, col)
+			}
+			t.kind, t.value = tokenBytes, strings.ToLower(source[2:n])
+			advance(n)
+		case isDigit(c) || (c == '-' && len(source) > 1 && isDigit(source[1])):
+			n := 1 + span(source[1:], isDigit)
+			t.kind, t.value = tokenInt, source[:n]
+			advance(n)
+		case isLetter(c) || c == '_':
+			n := span(source, isIdent)
+			t.kind, t.value = tokenIdent, source[:n]
+			advance(n)
+		case c == '@' || c == ':' || c == '%':
+			n := 1 + span(source[1:], isAnnot)
+			t.kind, t.value = tokenAnnot, source[:n]
+			advance(n)
+		default:
+			return nil, errors.Errorf("unexpected character '%c' at %d:%d", c, line, col)
+		}
+
+		if t.kind == tokenIdent || t.kind == tokenInt || t.kind == tokenBytes {
+			if len(source) > 0 && (isIdent(source[0]) || source[0] == '"') {
+				return nil, errors.Errorf("unexpected character '%c' at %d:%d", source[0], line, col)
+			}
+		}
+
+		tokens = append(tokens, t)
+	}
+
+	return append(tokens, token{kind: tokenEOF, line: line, col: col}), nil
+}
+
+// lexString reads a double quoted string and returns its unescaped value and its length in the source.
+func lexString(source string) (string, int, error) {
+	var buf strings.Builder
+	for i := 1; i < len(source); i++ {
+		switch c := source[i]; c {
+		case '"':
+			return buf.String(), i + 1, nil
+		case '\n', '\r':
+			return "", 0, errors.New("unterminated string")
+		case '\\':
+			i++
+			if i == len(source) {
+				return "", 0, errors.New("unterminated string")
+			}
+
+			escaped, ok := map[byte]byte{'n': '\n', 't': '\t', 'r': '\r', 'b': '\b', '\\': '\\', '"': '"'}[source[i]]
+			if !ok {
+				return "", 0, errors.Errorf("invalid escape sequence '\\%c'", source[i])
+			}
+			buf.WriteByte(escaped)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+
+	return "", 0, errors.New("unterminated string")
+}
+
+func span(s string, f func(byte) bool) int {
+	for i := 0; i < len(s); i++ {
+		if !f(s[i]) {
+			return i
+		}
+	}
+
+	return len(s)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isHex(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isIdent(c byte) bool {
+	return isLetter(c) || isDigit(c) || c == '_'
+}
+
+func isAnnot(c byte) bool {
+	return isIdent(c) || c == '.' || c == '%' || c == '@'
+}
@@ -0,0 +1,182 @@
+package michelson
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v3/forge"
+	"github.com/goat-systems/go-tezos/v3/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Parse(t *testing.T) {
+	type want struct {
+		wantErr     bool
+		containsErr string
+		micheline   string
+	}
+
+	cases := []struct {
+		name   string
+		source string
+		want   want
+	}{
+		{
+			"is successful with script",
+			`parameter (unit %abc);
+			storage unit;
+			code { CDR; NIL operation; PAIR }`,
+			want{false, "", `[{"prim":"parameter","args":[{"prim":"unit","annots":["%abc"]}]},{"prim":"storage","args":[{"prim":"unit"}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`},
+		},
+		{
+			"is successful with braced script and comments",
+			`{ # the parameter
+			  parameter (or (int %increment) (int %decrement)) ;
+			  /* the storage */
+			  storage int ;
+			  code { UNPAIR ; IF_LEFT { ADD } { SWAP ; SUB } ; NIL operation ; PAIR } }`,
+			want{false, "", `[{"prim":"parameter","args":[{"prim":"or","args":[{"prim":"int","annots":["%increment"]},{"prim":"int","annots":["%decrement"]}]}]},{"prim":"storage","args":[{"prim":"int"}]},{"prim":"code","args":[[{"prim":"UNPAIR"},{"prim":"IF_LEFT","args":[[{"prim":"ADD"}],[{"prim":"SWAP"},{"prim":"SUB"}]]},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`},
+		},
+		{
+			"is successful with data",
+			`Pair 1 (Pair "foo\n\"bar\"" 0xCAFE) { Elt -2 None } {}`,
+			want{false, "", `{"prim":"Pair","args":[{"int":"1"},{"prim":"Pair","args":[{"string":"foo\n\"bar\""},{"bytes":"cafe"}]},[{"prim":"Elt","args":[{"int":"-2"},{"prim":"None"}]}],[]]}`},
+		},
+		{
+			"is successful with instruction annotations",
+			`{ PUSH @amount mutez 100 ; DIP 2 { DROP } ; CAR %a @b }`,
+			want{false, "", `[{"prim":"PUSH","args":[{"prim":"mutez"},{"int":"100"}],"annots":["@amount"]},{"prim":"DIP","args":[{"int":"2"},[{"prim":"DROP"}]]},{"prim":"CAR","annots":["%a","@b"]}]`},
+		},
+		{
+			"is successful with comparison macros",
+			`{ CMPEQ ; IFGT { FAIL } {} ; IFCMPLT {} { UNIT ; DROP } }`,
+			want{false, "", `[[{"prim":"COMPARE"},{"prim":"EQ"}],[{"prim":"GT"},{"prim":"IF","args":[[[{"prim":"UNIT"},{"prim":"FAILWITH"}]],[]]}],[{"prim":"COMPARE"},{"prim":"LT"},{"prim":"IF","args":[[],[{"prim":"UNIT"},{"prim":"DROP"}]]}]]`},
+		},
+		{
+			"is successful with assert macros",
+			`{ ASSERT ; ASSERT_SOME ; ASSERT_CMPEQ }`,
+			want{false, "", `[[{"prim":"IF","args":[[],[[{"prim":"UNIT"},{"prim":"FAILWITH"}]]]}],[{"prim":"IF_NONE","args":[[[{"prim":"UNIT"},{"prim":"FAILWITH"}]],[]]}],[[{"prim":"COMPARE"},{"prim":"EQ"},{"prim":"IF","args":[[],[[{"prim":"UNIT"},{"prim":"FAILWITH"}]]]}]]]`},
+		},
+		{
+			"is successful with stack macros",
+			`{ DIIIP { DROP } ; DUUP ; CADR @x ; IF_SOME { DROP } { UNIT } }`,
+			want{false, "", `[[{"prim":"DIP","args":[{"int":"3"},[{"prim":"DROP"}]]}],[{"prim":"DUP","args":[{"int":"2"}]}],[{"prim":"CAR"},{"prim":"CDR","annots":["@x"]}],[{"prim":"IF_NONE","args":[[{"prim":"UNIT"}],[{"prim":"DROP"}]]}]]`},
+		},
+		{
+			"is successful with pair macros",
+			`{ PAPAIR ; PPAIIR ; UNPAPAIR ; UNPPAIIR }`,
+			want{false, "", `[[{"prim":"DIP","args":[[{"prim":"PAIR"}]]},{"prim":"PAIR"}],[{"prim":"PAIR"},{"prim":"PAIR"}],[{"prim":"UNPAIR"},{"prim":"DIP","args":[[{"prim":"UNPAIR"}]]}],[{"prim":"UNPAIR"},{"prim":"UNPAIR"}]]`},
+		},
+		{
+			"is successful with set and map macros",
+			`{ SET_CAR ; MAP_CDR { DROP ; PUSH nat 1 } }`,
+			want{false, "", `[[{"prim":"CDR"},{"prim":"SWAP"},{"prim":"PAIR"}],[{"prim":"DUP"},{"prim":"CDR"},[{"prim":"DROP"},{"prim":"PUSH","args":[{"prim":"nat"},{"int":"1"}]}],{"prim":"SWAP"},{"prim":"CAR"},{"prim":"PAIR"}]]`},
+		},
+		{
+			"handles invalid pair macro",
+			`{ PAAIR }`,
+			want{true, "invalid macro 'PAAIR'", ""},
+		},
+		{
+			"handles macro arguments",
+			`{ IFEQ {} }`,
+			want{true, "macro 'IFEQ' expects 2 arguments, got 1", ""},
+		},
+		{
+			"handles unterminated sequence",
+			`{ UNIT ; DROP`,
+			want{true, "expected ';' or '}' but got end of input at 1:14", ""},
+		},
+		{
+			"handles unterminated string",
+			`"foo`,
+			want{true, "unterminated string", ""},
+		},
+		{
+			"handles invalid escape",
+			`"\q"`,
+			want{true, `invalid escape sequence '\q'`, ""},
+		},
+		{
+			"handles invalid bytes",
+			`0xabc`,
+			want{true, "invalid bytes '0xabc' at 1:1", ""},
+		},
+		{
+			"handles unexpected character",
+			"{ UNIT ; $ }",
+			want{true, "unexpected character '$' at 1:10", ""},
+		},
+		{
+			"handles empty source",
+			" # nothing",
+			want{true, "empty source", ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			micheline, err := Parse(tt.source)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			if !tt.want.wantErr {
+				assert.JSONEq(t, tt.want.micheline, string(*micheline))
+			}
+		})
+	}
+}
+
+func Test_Parse_Forge(t *testing.T) {
+	cases := []struct {
+		name      string
+		source    string
+		typ       string
+		micheline string
+		packed    string
+	}{
+		{
+			"is successful with HTML characters",
+			`"x<=0 && y>0"`,
+			`{"prim":"string"}`,
+			`{"string":"x<=0 && y>0"}`,
+			"05010000000b783c3d3020262620793e30",
+		},
+		{
+			"is successful with escapes",
+			`"a\"b\\c"`,
+			`{"prim":"string"}`,
+			`{"string":"a\"b\\c"}`,
+			"0501000000056122625c63",
+		},
+		{
+			"is successful with code",
+			`{ PUSH string "amount <= 0" ; FAILWITH }`,
+			`{"prim":"lambda","args":[{"prim":"unit"},{"prim":"unit"}]}`,
+			`[{"prim":"PUSH","args":[{"prim":"string"},{"string":"amount <= 0"}]},{"prim":"FAILWITH"}]`,
+			"0502000000160743036801" + "0000000b616d6f756e74203c3d2030" + "0327",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			micheline, err := Parse(tt.source)
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, tt.micheline, string(*micheline))
+
+			typ := json.RawMessage(tt.typ)
+			packed, err := forge.Pack(micheline, &typ)
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, tt.packed, hex.EncodeToString(packed))
+		})
+	}
+}
+
+func Test_Script(t *testing.T) {
+	script, err := Script("parameter unit; storage nat; code { CDR; NIL operation; PAIR }", "42")
+	testutils.CheckErr(t, false, "", err)
+	assert.JSONEq(t, `[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"nat"}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`, string(*script.Code))
+	assert.JSONEq(t, `{"int":"42"}`, string(*script.Storage))
+
+	_, err = Script("parameter unit; storage nat; code { CDR; NIL operation; PAIR }", "Pair (")
+	testutils.CheckErr(t, true, "failed to parse storage", err)
+}
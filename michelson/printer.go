@@ -0,0 +1,157 @@
+package michelson
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// lineWidth is the width under which nodes are printed on a single line.
+const lineWidth = 80
+
+var toplevelSections = map[string]bool{
+	"parameter": true,
+	"storage":   true,
+	"code":      true,
+	"view":      true,
+}
+
+/*
+Print prints Micheline JSON as formatted Michelson.
+
+Note:
+	A script (a sequence of parameter, storage, code and view sections) is printed as the content of a .tz
+	file with one section per line. Sequences and prim arguments that don't fit on a line are broken across
+	lines and aligned the same way octez-client prints them. Macros aren't folded back.
+
+Parameters:
+
+	micheline:
+		The Micheline JSON, e.g. the code of rpc.Script.
+*/
+func Print(micheline *json.RawMessage) (string, error) {
+	if micheline == nil {
+		return "", errors.New("failed to print micheline: micheline is nil")
+	}
+
+	var n node
+	if err := json.Unmarshal(*micheline, &n); err != nil {
+		return "", errors.Wrap(err, "failed to print micheline")
+	}
+
+	if isScript(&n) {
+		var buf strings.Builder
+		for _, section := range n.args {
+			buf.WriteString(printNode(section, 0, false))
+			buf.WriteString(" ;\n")
+		}
+
+		return buf.String(), nil
+	}
+
+	return printNode(&n, 0, false), nil
+}
+
+func isScript(n *node) bool {
+	if n.kind != kindSeq || len(n.args) == 0 {
+		return false
+	}
+
+	for _, section := range n.args {
+		if section.kind != kindPrim || !toplevelSections[section.value] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// printNode prints a node starting at column indent. Prims with arguments or annotations are wrapped in
+// parentheses when they are an argument of another prim.
+func printNode(n *node, indent int, wrap bool) string {
+	switch n.kind {
+	case kindInt:
+		return n.value
+	case kindString:
+		return quote(n.value)
+	case kindBytes:
+		return "0x" + n.value
+	case kindSeq:
+		return printSeq(n, indent)
+	}
+
+	head := strings.Join(append([]string{n.value}, n.annots...), " ")
+	if len(n.args) == 0 {
+		if wrap && len(n.annots) > 0 {
+			return "(" + head + ")"
+		}
+		return head
+	}
+
+	if wrap {
+		indent++
+	}
+
+	argsIndent := indent + len(head) + 1
+	args := make([]string, len(n.args))
+	single := head
+	for i, arg := range n.args {
+		args[i] = printNode(arg, argsIndent, true)
+		single += " " + args[i]
+	}
+
+	out := single
+	if strings.Contains(single, "\n") || indent+len(single) > lineWidth {
+		out = head + " " + strings.Join(args, "\n"+strings.Repeat(" ", argsIndent))
+	}
+
+	if wrap {
+		return "(" + out + ")"
+	}
+
+	return out
+}
+
+func printSeq(n *node, indent int) string {
+	if len(n.args) == 0 {
+		return "{}"
+	}
+
+	elements := make([]string, len(n.args))
+	for i, element := range n.args {
+		elements[i] = printNode(element, indent+2, false)
+	}
+
+	single := "{ " + strings.Join(elements, " ; ") + " }"
+	if !strings.Contains(single, "\n") && indent+len(single) <= lineWidth {
+		return single
+	}
+
+	return "{ " + strings.Join(elements, " ;\n"+strings.Repeat(" ", indent+2)) + " }"
+}
+
+func quote(s string) string {
+	var buf strings.Builder
+	buf.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\b':
+			buf.WriteString(`\b`)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	buf.WriteByte('"')
+
+	return buf.String()
+}
@@ -0,0 +1,59 @@
+package michelson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v3/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Print(t *testing.T) {
+	type want struct {
+		wantErr     bool
+		containsErr string
+		michelson   string
+	}
+
+	cases := []struct {
+		name      string
+		micheline string
+		want      want
+	}{
+		{
+			"is successful with script",
+			`[{"prim":"parameter","args":[{"prim":"unit","annots":["%abc"]}]},{"prim":"storage","args":[{"prim":"unit"}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`,
+			want{false, "", "parameter (unit %abc) ;\nstorage unit ;\ncode { CDR ; NIL operation ; PAIR } ;\n"},
+		},
+		{
+			"is successful with data",
+			`{"prim":"Pair","args":[{"int":"-1"},{"prim":"Some","args":[{"string":"a \"b\"\n"}]},{"bytes":"cafe"},[]]}`,
+			want{false, "", `Pair -1 (Some "a \"b\"\n") 0xcafe {}`},
+		},
+		{
+			"is successful with long sequences",
+			`[{"prim":"IF_LEFT","args":[[{"prim":"PUSH","args":[{"prim":"string"},{"string":"a long string that doesn't fit on the line"}]},{"prim":"FAILWITH"}],[{"prim":"DROP"}]]},{"prim":"UNIT"}]`,
+			want{false, "", "{ IF_LEFT { PUSH string \"a long string that doesn't fit on the line\" ;\n            FAILWITH }\n          { DROP } ;\n  UNIT }"},
+		},
+		{
+			"handles invalid micheline",
+			`{"foo":"bar"}`,
+			want{true, "invalid micheline node", ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			micheline := json.RawMessage(tt.micheline)
+			michelson, err := Print(&micheline)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			if !tt.want.wantErr {
+				assert.Equal(t, tt.want.michelson, michelson)
+
+				reparsed, err := Parse(michelson)
+				testutils.CheckErr(t, false, "", err)
+				assert.JSONEq(t, tt.micheline, string(*reparsed))
+			}
+		})
+	}
+}
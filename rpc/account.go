@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -48,13 +49,18 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-balance
 */
 func (c *Client) Balance(input BalanceInput) (string, error) {
+	return c.BalanceContext(context.Background(), input)
+}
+
+// BalanceContext is like Balance but uses ctx for its requests to the node.
+func (c *Client) BalanceContext(ctx context.Context, input BalanceInput) (string, error) {
 	if err := input.validate(); err != nil {
 		return "", errors.Wrapf(err, "could not get balance for '%s'", input.Address)
 	}
 
 	var resp []byte
 	if input.Cycle != 0 {
-		snapshot, err := c.Cycle(input.Cycle)
+		snapshot, err := c.CycleContext(ctx, input.Cycle)
 		if err != nil {
 			return "", errors.Wrapf(err, "could not get balance for '%s' at cycle '%d'", input.Address, input.Cycle)
 		}
@@ -63,7 +69,7 @@ func (c *Client) Balance(input BalanceInput) (string, error) {
 	}
 
 	query := fmt.Sprintf("/chains/%s/blocks/%s/context/contracts/%s/balance", c.chain, input.Blockhash, input.Address)
-	resp, err := c.get(query)
+	resp, err := c.get(ctx, query)
 	if err != nil {
 		return "0", errors.Wrap(err, "failed to get balance")
 	}
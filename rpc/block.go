@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -15,12 +16,18 @@ type Kind string
 const (
 	// ENDORSEMENT kind
 	ENDORSEMENT Kind = "endorsement"
+	// PREENDORSEMENT kind
+	PREENDORSEMENT Kind = "preendorsement"
+	// ATTESTATION kind
+	ATTESTATION Kind = "attestation"
+	// PREATTESTATION kind
+	PREATTESTATION Kind = "preattestation"
 	// SEEDNONCEREVELATION kind
 	SEEDNONCEREVELATION Kind = "seed_nonce_revelation"
 	// DOUBLEENDORSEMENTEVIDENCE kind
 	DOUBLEENDORSEMENTEVIDENCE Kind = "double_endorsement_evidence"
 	// DOUBLEBAKINGEVIDENCE kind
-	DOUBLEBAKINGEVIDENCE Kind = "Double_baking_evidence"
+	DOUBLEBAKINGEVIDENCE Kind = "double_baking_evidence"
 	// ACTIVATEACCOUNT kind
 	ACTIVATEACCOUNT Kind = "activate_account"
 	// PROPOSALS kind
@@ -35,6 +42,44 @@ const (
 	ORIGINATION Kind = "origination"
 	// DELEGATION kind
 	DELEGATION Kind = "delegation"
+	// REGISTERGLOBALCONSTANT kind
+	REGISTERGLOBALCONSTANT Kind = "register_global_constant"
+	// SETDEPOSITSLIMIT kind
+	SETDEPOSITSLIMIT Kind = "set_deposits_limit"
+	// INCREASEPAIDSTORAGE kind
+	INCREASEPAIDSTORAGE Kind = "increase_paid_storage"
+	// UPDATECONSENSUSKEY kind
+	UPDATECONSENSUSKEY Kind = "update_consensus_key"
+	// DRAINDELEGATE kind
+	DRAINDELEGATE Kind = "drain_delegate"
+	// TRANSFERTICKET kind
+	TRANSFERTICKET Kind = "transfer_ticket"
+	// TXROLLUPORIGINATION kind
+	TXROLLUPORIGINATION Kind = "tx_rollup_origination"
+	// TXROLLUPSUBMITBATCH kind
+	TXROLLUPSUBMITBATCH Kind = "tx_rollup_submit_batch"
+	// TXROLLUPCOMMIT kind
+	TXROLLUPCOMMIT Kind = "tx_rollup_commit"
+	// TXROLLUPRETURNBOND kind
+	TXROLLUPRETURNBOND Kind = "tx_rollup_return_bond"
+	// TXROLLUPFINALIZECOMMITMENT kind
+	TXROLLUPFINALIZECOMMITMENT Kind = "tx_rollup_finalize_commitment"
+	// TXROLLUPREMOVECOMMITMENT kind
+	TXROLLUPREMOVECOMMITMENT Kind = "tx_rollup_remove_commitment"
+	// TXROLLUPREJECTION kind
+	TXROLLUPREJECTION Kind = "tx_rollup_rejection"
+	// TXROLLUPDISPATCHTICKETS kind
+	TXROLLUPDISPATCHTICKETS Kind = "tx_rollup_dispatch_tickets"
+	// SMARTROLLUPORIGINATE kind
+	SMARTROLLUPORIGINATE Kind = "smart_rollup_originate"
+	// SMARTROLLUPADDMESSAGES kind
+	SMARTROLLUPADDMESSAGES Kind = "smart_rollup_add_messages"
+	// SMARTROLLUPCEMENT kind
+	SMARTROLLUPCEMENT Kind = "smart_rollup_cement"
+	// SMARTROLLUPPUBLISH kind
+	SMARTROLLUPPUBLISH Kind = "smart_rollup_publish"
+	// SMARTROLLUPEXECUTEOUTBOXMESSAGE kind
+	SMARTROLLUPEXECUTEOUTBOXMESSAGE Kind = "smart_rollup_execute_outbox_message"
 )
 
 // BigMapDiffAction is an Action in a BigMapDiff
@@ -90,7 +135,16 @@ type Header struct {
 	Priority         int       `json:"priority"`
 	ProofOfWorkNonce string    `json:"proof_of_work_nonce"`
 	SeedNonceHash    string    `json:"seed_nonce_hash"`
-	Signature        string    `json:"signature"`
+	// PayloadHash and PayloadRound replace Priority in Tenderbake block headers (Ithaca onwards).
+	PayloadHash  string `json:"payload_hash,omitempty"`
+	PayloadRound int    `json:"payload_round,omitempty"`
+	// LiquidityBakingEscapeVote is only part of the block header from Granada to Ithaca.
+	LiquidityBakingEscapeVote bool `json:"liquidity_baking_escape_vote,omitempty"`
+	// LiquidityBakingToggleVote (on, off or pass) replaced LiquidityBakingEscapeVote in Jakarta and
+	// AdaptiveIssuanceVote (on, off or pass) is part of the block header from Oxford onwards.
+	LiquidityBakingToggleVote string `json:"liquidity_baking_toggle_vote,omitempty"`
+	AdaptiveIssuanceVote      string `json:"adaptive_issuance_vote,omitempty"`
+	Signature                 string `json:"signature"`
 }
 
 /*
@@ -117,6 +171,19 @@ type Metadata struct {
 	ConsumedGas            string                   `json:"consumed_gas"`
 	Deactivated            []string                 `json:"deactivated"`
 	BalanceUpdates         []BalanceUpdates         `json:"balance_updates"`
+	// Tenderbake metadata (Ithaca onwards)
+	Proposer                        string                    `json:"proposer,omitempty"`
+	ProposerConsensusKey            string                    `json:"proposer_consensus_key,omitempty"`
+	BakerConsensusKey               string                    `json:"baker_consensus_key,omitempty"`
+	LevelInfo                       *LevelInfo                `json:"level_info,omitempty"`
+	VotingPeriodInfo                *VotingPeriodInfo         `json:"voting_period_info,omitempty"`
+	ConsumedMilligas                string                    `json:"consumed_milligas,omitempty"`
+	LiquidityBakingEscapeEma        int                       `json:"liquidity_baking_escape_ema,omitempty"`
+	LiquidityBakingToggleEma        int                       `json:"liquidity_baking_toggle_ema,omitempty"`
+	AdaptiveIssuanceVoteEma         int                       `json:"adaptive_issuance_vote_ema,omitempty"`
+	AdaptiveIssuanceActivationCycle int                       `json:"adaptive_issuance_activation_cycle,omitempty"`
+	ImplicitOperationsResults       []ImplicitOperationResult `json:"implicit_operations_results,omitempty"`
+	DalAttestation                  string                    `json:"dal_attestation,omitempty"`
 }
 
 /*
@@ -169,6 +236,68 @@ type Level struct {
 	ExpectedCommitment   bool `json:"expected_commitment"`
 }
 
+/*
+LevelInfo represents the level_info in the metadata of a Tenderbake block
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type LevelInfo struct {
+	Level              int  `json:"level"`
+	LevelPosition      int  `json:"level_position"`
+	Cycle              int  `json:"cycle"`
+	CyclePosition      int  `json:"cycle_position"`
+	ExpectedCommitment bool `json:"expected_commitment"`
+}
+
+/*
+VotingPeriodInfo represents the voting_period_info in the metadata of a Tenderbake block
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type VotingPeriodInfo struct {
+	VotingPeriod VotingPeriod `json:"voting_period"`
+	Position     int          `json:"position"`
+	Remaining    int          `json:"remaining"`
+}
+
+/*
+VotingPeriod represents the voting_period of the voting_period_info in the metadata of a Tenderbake block
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type VotingPeriod struct {
+	Index         int    `json:"index"`
+	Kind          string `json:"kind"`
+	StartPosition int    `json:"start_position"`
+}
+
+/*
+ImplicitOperationResult represents the result of an operation applied by the protocol at the end of a Tenderbake
+block, e.g. the liquidity baking subsidy.
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type ImplicitOperationResult struct {
+	Kind Kind `json:"kind"`
+	OperationResults
+}
+
 /*
 BalanceUpdates represents the balance updates in a Tezos block
 
@@ -186,6 +315,7 @@ type BalanceUpdates struct {
 	Delegate string `json:"delegate,omitempty"`
 	Cycle    int    `json:"cycle,omitempty"`
 	Level    int    `json:"level,omitempty"`
+	Origin   string `json:"origin,omitempty"`
 }
 
 // ResultError are errors reported by OperationResults
@@ -249,6 +379,9 @@ Link:
 */
 type OrganizedContents struct {
 	Endorsements              []Endorsement
+	Preendorsements           []Preendorsement
+	Attestations              []Attestation
+	Preattestations           []Preattestation
 	SeedNonceRevelations      []SeedNonceRevelation
 	DoubleEndorsementEvidence []DoubleEndorsementEvidence
 	DoubleBakingEvidence      []DoubleBakingEvidence
@@ -259,6 +392,17 @@ type OrganizedContents struct {
 	Transactions              []Transaction
 	Originations              []Origination
 	Delegations               []Delegation
+	RegisterGlobalConstants   []RegisterGlobalConstant
+	SetDepositsLimits         []SetDepositsLimit
+	IncreasePaidStorages      []IncreasePaidStorage
+	UpdateConsensusKeys       []UpdateConsensusKey
+	DrainDelegates            []DrainDelegate
+	TransferTickets           []TransferTicket
+	SmartRollupOriginations   []SmartRollupOriginate
+	SmartRollupAddMessages    []SmartRollupAddMessages
+	SmartRollupCements        []SmartRollupCement
+	SmartRollupPublishes      []SmartRollupPublish
+	SmartRollupExecutions     []SmartRollupExecuteOutboxMessage
 }
 
 // ToContents converts OrganizedContents into Contents
@@ -268,6 +412,18 @@ func (o *OrganizedContents) ToContents() Contents {
 		contents = append(contents, endorsement.ToContent())
 	}
 
+	for _, preendorsement := range o.Preendorsements {
+		contents = append(contents, preendorsement.ToContent())
+	}
+
+	for _, attestation := range o.Attestations {
+		contents = append(contents, attestation.ToContent())
+	}
+
+	for _, preattestation := range o.Preattestations {
+		contents = append(contents, preattestation.ToContent())
+	}
+
 	for _, seedNonceRevelation := range o.SeedNonceRevelations {
 		contents = append(contents, seedNonceRevelation.ToContent())
 	}
@@ -307,6 +463,50 @@ func (o *OrganizedContents) ToContents() Contents {
 	for _, delegation := range o.Delegations {
 		contents = append(contents, delegation.ToContent())
 	}
+
+	for _, registerGlobalConstant := range o.RegisterGlobalConstants {
+		contents = append(contents, registerGlobalConstant.ToContent())
+	}
+
+	for _, setDepositsLimit := range o.SetDepositsLimits {
+		contents = append(contents, setDepositsLimit.ToContent())
+	}
+
+	for _, increasePaidStorage := range o.IncreasePaidStorages {
+		contents = append(contents, increasePaidStorage.ToContent())
+	}
+
+	for _, updateConsensusKey := range o.UpdateConsensusKeys {
+		contents = append(contents, updateConsensusKey.ToContent())
+	}
+
+	for _, drainDelegate := range o.DrainDelegates {
+		contents = append(contents, drainDelegate.ToContent())
+	}
+
+	for _, transferTicket := range o.TransferTickets {
+		contents = append(contents, transferTicket.ToContent())
+	}
+
+	for _, origination := range o.SmartRollupOriginations {
+		contents = append(contents, origination.ToContent())
+	}
+
+	for _, addMessages := range o.SmartRollupAddMessages {
+		contents = append(contents, addMessages.ToContent())
+	}
+
+	for _, cement := range o.SmartRollupCements {
+		contents = append(contents, cement.ToContent())
+	}
+
+	for _, publish := range o.SmartRollupPublishes {
+		contents = append(contents, publish.ToContent())
+	}
+
+	for _, execution := range o.SmartRollupExecutions {
+		contents = append(contents, execution.ToContent())
+	}
 	return contents
 }
 
@@ -328,6 +528,8 @@ type Content struct {
 	Nonce         string              `json:"nonce,omitempty"`
 	Op1           *InlinedEndorsement `json:"Op1,omitempty"`
 	Op2           *InlinedEndorsement `json:"Op2,omitempty"`
+	Slot          int                 `json:"slot,omitempty"`
+	Round         int                 `json:"round,omitempty"`
 	Pkh           string              `json:"pkh,omitempty"`
 	Secret        string              `json:"secret,omitempty"`
 	Bh1           *BlockHeader        `json:"bh1,omitempty"`
@@ -349,13 +551,42 @@ type Content struct {
 	Delegate      string              `json:"delegate,omitempty"`
 	Script        Script              `json:"script,omitempty"`
 	Parameters    *Parameters         `json:"parameters,omitempty"`
-	Metadata      *ContentsMetadata   `json:"metadata,omitempty"`
+	Value         *json.RawMessage    `json:"value,omitempty"`
+	Limit         string              `json:"limit,omitempty"`
+	Pk            string              `json:"pk,omitempty"`
+	ConsensusKey  string              `json:"consensus_key,omitempty"`
+	// Tenderbake consensus operations
+	BlockPayloadHash string `json:"block_payload_hash,omitempty"`
+	// transfer_ticket
+	TicketContents *json.RawMessage `json:"ticket_contents,omitempty"`
+	TicketTy       *json.RawMessage `json:"ticket_ty,omitempty"`
+	TicketTicketer string           `json:"ticket_ticketer,omitempty"`
+	TicketAmount   string           `json:"ticket_amount,omitempty"`
+	Entrypoint     string           `json:"entrypoint,omitempty"`
+	// smart rollup operations
+	PvmKind            string                 `json:"pvm_kind,omitempty"`
+	Kernel             string                 `json:"kernel,omitempty"`
+	OriginationProof   string                 `json:"origination_proof,omitempty"`
+	ParametersTy       *json.RawMessage       `json:"parameters_ty,omitempty"`
+	Whitelist          []string               `json:"whitelist,omitempty"`
+	Message            []string               `json:"message,omitempty"`
+	Rollup             string                 `json:"rollup,omitempty"`
+	Commitment         *SmartRollupCommitment `json:"commitment,omitempty"`
+	CementedCommitment string                 `json:"cemented_commitment,omitempty"`
+	OutputProof        string                 `json:"output_proof,omitempty"`
+	Metadata           *ContentsMetadata      `json:"metadata,omitempty"`
 }
 
 // MarshalJSON implements json.Marshaler in order to correctly marshal contents based of kind
-func (c *Content) MarshalJSON() ([]byte, error) {
+func (c Content) MarshalJSON() ([]byte, error) {
 	if c.Kind == ENDORSEMENT {
 		return json.Marshal(c.ToEndorsement())
+	} else if c.Kind == PREENDORSEMENT {
+		return json.Marshal(c.ToPreendorsement())
+	} else if c.Kind == ATTESTATION {
+		return json.Marshal(c.ToAttestation())
+	} else if c.Kind == PREATTESTATION {
+		return json.Marshal(c.ToPreattestation())
 	} else if c.Kind == SEEDNONCEREVELATION {
 		return json.Marshal(c.ToSeedNonceRevelations())
 	} else if c.Kind == DOUBLEENDORSEMENTEVIDENCE {
@@ -376,6 +607,28 @@ func (c *Content) MarshalJSON() ([]byte, error) {
 		return json.Marshal(c.ToOrigination())
 	} else if c.Kind == DELEGATION {
 		return json.Marshal(c.ToDelegation())
+	} else if c.Kind == REGISTERGLOBALCONSTANT {
+		return json.Marshal(c.ToRegisterGlobalConstant())
+	} else if c.Kind == SETDEPOSITSLIMIT {
+		return json.Marshal(c.ToSetDepositsLimit())
+	} else if c.Kind == INCREASEPAIDSTORAGE {
+		return json.Marshal(c.ToIncreasePaidStorage())
+	} else if c.Kind == UPDATECONSENSUSKEY {
+		return json.Marshal(c.ToUpdateConsensusKey())
+	} else if c.Kind == DRAINDELEGATE {
+		return json.Marshal(c.ToDrainDelegate())
+	} else if c.Kind == TRANSFERTICKET {
+		return json.Marshal(c.ToTransferTicket())
+	} else if c.Kind == SMARTROLLUPORIGINATE {
+		return json.Marshal(c.ToSmartRollupOriginate())
+	} else if c.Kind == SMARTROLLUPADDMESSAGES {
+		return json.Marshal(c.ToSmartRollupAddMessages())
+	} else if c.Kind == SMARTROLLUPCEMENT {
+		return json.Marshal(c.ToSmartRollupCement())
+	} else if c.Kind == SMARTROLLUPPUBLISH {
+		return json.Marshal(c.ToSmartRollupPublish())
+	} else if c.Kind == SMARTROLLUPEXECUTEOUTBOXMESSAGE {
+		return json.Marshal(c.ToSmartRollupExecuteOutboxMessage())
 	}
 
 	return nil, errors.New("failed to find content kind to marshal into")
@@ -387,6 +640,12 @@ func (c Contents) Organize() OrganizedContents {
 	for _, content := range c {
 		if content.Kind == ENDORSEMENT {
 			organizeContents.Endorsements = append(organizeContents.Endorsements, content.ToEndorsement())
+		} else if content.Kind == PREENDORSEMENT {
+			organizeContents.Preendorsements = append(organizeContents.Preendorsements, content.ToPreendorsement())
+		} else if content.Kind == ATTESTATION {
+			organizeContents.Attestations = append(organizeContents.Attestations, content.ToAttestation())
+		} else if content.Kind == PREATTESTATION {
+			organizeContents.Preattestations = append(organizeContents.Preattestations, content.ToPreattestation())
 		} else if content.Kind == SEEDNONCEREVELATION {
 			organizeContents.SeedNonceRevelations = append(organizeContents.SeedNonceRevelations, content.ToSeedNonceRevelations())
 		} else if content.Kind == DOUBLEENDORSEMENTEVIDENCE {
@@ -407,6 +666,28 @@ func (c Contents) Organize() OrganizedContents {
 			organizeContents.Originations = append(organizeContents.Originations, content.ToOrigination())
 		} else if content.Kind == DELEGATION {
 			organizeContents.Delegations = append(organizeContents.Delegations, content.ToDelegation())
+		} else if content.Kind == REGISTERGLOBALCONSTANT {
+			organizeContents.RegisterGlobalConstants = append(organizeContents.RegisterGlobalConstants, content.ToRegisterGlobalConstant())
+		} else if content.Kind == SETDEPOSITSLIMIT {
+			organizeContents.SetDepositsLimits = append(organizeContents.SetDepositsLimits, content.ToSetDepositsLimit())
+		} else if content.Kind == INCREASEPAIDSTORAGE {
+			organizeContents.IncreasePaidStorages = append(organizeContents.IncreasePaidStorages, content.ToIncreasePaidStorage())
+		} else if content.Kind == UPDATECONSENSUSKEY {
+			organizeContents.UpdateConsensusKeys = append(organizeContents.UpdateConsensusKeys, content.ToUpdateConsensusKey())
+		} else if content.Kind == DRAINDELEGATE {
+			organizeContents.DrainDelegates = append(organizeContents.DrainDelegates, content.ToDrainDelegate())
+		} else if content.Kind == TRANSFERTICKET {
+			organizeContents.TransferTickets = append(organizeContents.TransferTickets, content.ToTransferTicket())
+		} else if content.Kind == SMARTROLLUPORIGINATE {
+			organizeContents.SmartRollupOriginations = append(organizeContents.SmartRollupOriginations, content.ToSmartRollupOriginate())
+		} else if content.Kind == SMARTROLLUPADDMESSAGES {
+			organizeContents.SmartRollupAddMessages = append(organizeContents.SmartRollupAddMessages, content.ToSmartRollupAddMessages())
+		} else if content.Kind == SMARTROLLUPCEMENT {
+			organizeContents.SmartRollupCements = append(organizeContents.SmartRollupCements, content.ToSmartRollupCement())
+		} else if content.Kind == SMARTROLLUPPUBLISH {
+			organizeContents.SmartRollupPublishes = append(organizeContents.SmartRollupPublishes, content.ToSmartRollupPublish())
+		} else if content.Kind == SMARTROLLUPEXECUTEOUTBOXMESSAGE {
+			organizeContents.SmartRollupExecutions = append(organizeContents.SmartRollupExecutions, content.ToSmartRollupExecuteOutboxMessage())
 		}
 	}
 
@@ -442,6 +723,13 @@ type ContentsMetadata struct {
 	Slots                   []int                      `json:"slots,omitempty"`
 	OperationResults        *OperationResults          `json:"operation_result,omitempty"`
 	InternalOperationResult []InternalOperationResults `json:"internal_operation_results,omitempty"`
+	// drain_delegate
+	AllocatedDestinationContract bool `json:"allocated_destination_contract,omitempty"`
+	// Tenderbake consensus operations
+	ConsensusKey        string `json:"consensus_key,omitempty"`
+	EndorsementPower    int    `json:"endorsement_power,omitempty"`
+	PreendorsementPower int    `json:"preendorsement_power,omitempty"`
+	ConsensusPower      int    `json:"consensus_power,omitempty"`
 }
 
 /*
@@ -459,11 +747,21 @@ type OperationResults struct {
 	BalanceUpdates               []BalanceUpdates `json:"balance_updates,omitempty"`
 	OriginatedContracts          []string         `json:"originated_contracts,omitempty"`
 	ConsumedGas                  string           `json:"consumed_gas,omitempty"`
+	ConsumedMilligas             string           `json:"consumed_milligas,omitempty"`
 	StorageSize                  string           `json:"storage_size,omitempty"`
 	PaidStorageSizeDiff          string           `json:"paid_storage_size_diff,omitempty"`
 	Errors                       []Error         `json:"errors,omitempty"`
 	Storage                      *json.RawMessage `json:"storage,omitempty"`
 	AllocatedDestinationContract bool             `json:"allocated_destination_contract,omitempty"`
+	// results of register_global_constant and the smart rollup operations
+	GlobalAddress         string `json:"global_address,omitempty"`
+	Address               string `json:"address,omitempty"`
+	GenesisCommitmentHash string `json:"genesis_commitment_hash,omitempty"`
+	Size                  string `json:"size,omitempty"`
+	StakedHash            string `json:"staked_hash,omitempty"`
+	PublishedAtLevel      int    `json:"published_at_level,omitempty"`
+	InboxLevel            int    `json:"inbox_level,omitempty"`
+	CommitmentHash        string `json:"commitment_hash,omitempty"`
 }
 
 func (o *OperationResults) toOperationResultsReveal() OperationResultReveal {
@@ -535,16 +833,21 @@ func (c *Content) ToEndorsement() Endorsement {
 
 	if c.Metadata != nil {
 		metadata = &EndorsementMetadata{
-			BalanceUpdates: c.Metadata.BalanceUpdates,
-			Delegate:       c.Metadata.Delegate,
-			Slots:          c.Metadata.Slots,
+			BalanceUpdates:   c.Metadata.BalanceUpdates,
+			Delegate:         c.Metadata.Delegate,
+			Slots:            c.Metadata.Slots,
+			ConsensusKey:     c.Metadata.ConsensusKey,
+			EndorsementPower: c.Metadata.EndorsementPower,
 		}
 	}
 
 	return Endorsement{
-		Kind:     c.Kind,
-		Level:    c.Level,
-		Metadata: metadata,
+		Kind:             c.Kind,
+		Slot:             c.Slot,
+		Level:            c.Level,
+		Round:            c.Round,
+		BlockPayloadHash: c.BlockPayloadHash,
+		Metadata:         metadata,
 	}
 }
 
@@ -592,6 +895,7 @@ func (c *Content) ToDoubleEndorsementEvidence() DoubleEndorsementEvidence {
 		Kind:     c.Kind,
 		Op1:      op1,
 		Op2:      op2,
+		Slot:     c.Slot,
 		Metadata: metadata,
 	}
 }
@@ -792,9 +1096,13 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-block-id
 */
 type Endorsement struct {
-	Kind     Kind                 `json:"kind"`
-	Level    int                  `json:"level"`
-	Metadata *EndorsementMetadata `json:"metadata"`
+	Kind  Kind `json:"kind"`
+	Slot  int  `json:"slot,omitempty"`
+	Level int  `json:"level"`
+	// Round and BlockPayloadHash are only part of Tenderbake endorsements (Ithaca to Nairobi).
+	Round            int                  `json:"round,omitempty"`
+	BlockPayloadHash string               `json:"block_payload_hash,omitempty"`
+	Metadata         *EndorsementMetadata `json:"metadata"`
 }
 
 /*
@@ -807,9 +1115,29 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-block-id
 */
 type EndorsementMetadata struct {
-	BalanceUpdates []BalanceUpdates `json:"balance_updates"`
-	Delegate       string           `json:"delegate"`
-	Slots          []int            `json:"slots"`
+	BalanceUpdates   []BalanceUpdates `json:"balance_updates"`
+	Delegate         string           `json:"delegate"`
+	Slots            []int            `json:"slots"`
+	ConsensusKey     string           `json:"consensus_key,omitempty"`
+	EndorsementPower int              `json:"endorsement_power,omitempty"`
+}
+
+type endorsement Endorsement
+
+// tenderbakeEndorsement always has a slot and a round, which are 0 for the first slot and the first round.
+type tenderbakeEndorsement struct {
+	endorsement
+	Slot  int `json:"slot"`
+	Round int `json:"round"`
+}
+
+// MarshalJSON satisfies json.Marshaler, the slot and round of Tenderbake endorsements are marshaled even if 0.
+func (e Endorsement) MarshalJSON() ([]byte, error) {
+	if e.BlockPayloadHash == "" {
+		return json.Marshal(endorsement(e))
+	}
+
+	return json.Marshal(tenderbakeEndorsement{endorsement(e), e.Slot, e.Round})
 }
 
 // ToContent converts Endorsement to Content
@@ -818,16 +1146,21 @@ func (e *Endorsement) ToContent() Content {
 
 	if e.Metadata != nil {
 		metadata = &ContentsMetadata{
-			BalanceUpdates: e.Metadata.BalanceUpdates,
-			Delegate:       e.Metadata.Delegate,
-			Slots:          e.Metadata.Slots,
+			BalanceUpdates:   e.Metadata.BalanceUpdates,
+			Delegate:         e.Metadata.Delegate,
+			Slots:            e.Metadata.Slots,
+			ConsensusKey:     e.Metadata.ConsensusKey,
+			EndorsementPower: e.Metadata.EndorsementPower,
 		}
 	}
 
 	return Content{
-		Kind:     e.Kind,
-		Level:    e.Level,
-		Metadata: metadata,
+		Kind:             e.Kind,
+		Slot:             e.Slot,
+		Level:            e.Level,
+		Round:            e.Round,
+		BlockPayloadHash: e.BlockPayloadHash,
+		Metadata:         metadata,
 	}
 }
 
@@ -891,6 +1224,7 @@ type DoubleEndorsementEvidence struct {
 	Kind     Kind                               `json:"kind"`
 	Op1      *InlinedEndorsement                `json:"Op1"`
 	Op2      *InlinedEndorsement                `json:"Op2"`
+	Slot     int                                `json:"slot,omitempty"`
 	Metadata *DoubleEndorsementEvidenceMetadata `json:"metadata"`
 }
 
@@ -933,7 +1267,29 @@ Link:
 */
 type InlinedEndorsementOperations struct {
 	Kind  string `json:"kind"`
+	Slot  int    `json:"slot,omitempty"`
 	Level int    `json:"level"`
+	// Round and BlockPayloadHash are only part of Tenderbake endorsements (Ithaca to Nairobi).
+	Round            int    `json:"round,omitempty"`
+	BlockPayloadHash string `json:"block_payload_hash,omitempty"`
+}
+
+type inlinedEndorsementOperations InlinedEndorsementOperations
+
+// tenderbakeInlinedEndorsementOperations always has a slot and a round like tenderbakeEndorsement.
+type tenderbakeInlinedEndorsementOperations struct {
+	inlinedEndorsementOperations
+	Slot  int `json:"slot"`
+	Round int `json:"round"`
+}
+
+// MarshalJSON satisfies json.Marshaler, the slot and round of Tenderbake endorsements are marshaled even if 0.
+func (i InlinedEndorsementOperations) MarshalJSON() ([]byte, error) {
+	if i.BlockPayloadHash == "" {
+		return json.Marshal(inlinedEndorsementOperations(i))
+	}
+
+	return json.Marshal(tenderbakeInlinedEndorsementOperations{inlinedEndorsementOperations(i), i.Slot, i.Round})
 }
 
 // ToContent converts a DoubleEndorsementEvidence to Content
@@ -962,6 +1318,7 @@ func (d *DoubleEndorsementEvidence) ToContent() Content {
 		Kind:     d.Kind,
 		Op1:      op1,
 		Op2:      op2,
+		Slot:     d.Slot,
 		Metadata: metadata,
 	}
 }
@@ -1016,7 +1373,16 @@ type BlockHeader struct {
 	Priority         int       `json:"priority"`
 	ProofOfWorkNonce string    `json:"proof_of_work_nonce"`
 	SeedNonceHash    string    `json:"seed_nonce_hash"`
-	Signature        string    `json:"signature"`
+	// PayloadHash and PayloadRound replace Priority in Tenderbake block headers (Ithaca onwards).
+	PayloadHash  string `json:"payload_hash,omitempty"`
+	PayloadRound int    `json:"payload_round,omitempty"`
+	// LiquidityBakingEscapeVote is only part of the block header from Granada to Ithaca.
+	LiquidityBakingEscapeVote bool `json:"liquidity_baking_escape_vote,omitempty"`
+	// LiquidityBakingToggleVote (on, off or pass) replaced LiquidityBakingEscapeVote in Jakarta and
+	// AdaptiveIssuanceVote (on, off or pass) is part of the block header from Oxford onwards.
+	LiquidityBakingToggleVote string `json:"liquidity_baking_toggle_vote,omitempty"`
+	AdaptiveIssuanceVote      string `json:"adaptive_issuance_vote,omitempty"`
+	Signature                 string `json:"signature"`
 }
 
 // ToContent converts a DoubleBakingEvidence to Content
@@ -1867,7 +2233,12 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-chains-chain-id-blocks
 */
 func (c *Client) Head() (*Block, error) {
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/head", c.chain))
+	return c.HeadContext(context.Background())
+}
+
+// HeadContext is like Head but uses ctx for its requests to the node.
+func (c *Client) HeadContext(ctx context.Context) (*Block, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/head", c.chain))
 	if err != nil {
 		return &Block{}, errors.Wrapf(err, "could not get head block")
 	}
@@ -1896,12 +2267,17 @@ Parameters:
 		level = <int> : The block level.
 */
 func (c *Client) Block(id interface{}) (*Block, error) {
+	return c.BlockContext(context.Background(), id)
+}
+
+// BlockContext is like Block but uses ctx for its requests to the node.
+func (c *Client) BlockContext(ctx context.Context, id interface{}) (*Block, error) {
 	blockID, err := idToString(id)
 	if err != nil {
 		return &Block{}, errors.Wrapf(err, "could not get block '%s'", blockID)
 	}
 
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/%s", c.chain, blockID))
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s", c.chain, blockID))
 	if err != nil {
 		return &Block{}, errors.Wrapf(err, "could not get block '%s'", blockID)
 	}
@@ -1929,7 +2305,12 @@ Parameters:
 		The hash of block (height) of which you want to make the query.
 */
 func (c *Client) OperationHashes(blockhash string) ([][]string, error) {
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/%s/operation_hashes", c.chain, blockhash))
+	return c.OperationHashesContext(context.Background(), blockhash)
+}
+
+// OperationHashesContext is like OperationHashes but uses ctx for its requests to the node.
+func (c *Client) OperationHashesContext(ctx context.Context, blockhash string) ([][]string, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/operation_hashes", c.chain, blockhash))
 	if err != nil {
 		return [][]string{}, errors.Wrapf(err, "could not get operation hashes")
 	}
@@ -1957,7 +2338,12 @@ Parameters:
 		The hash of block (height) of which you want to make the query.
 */
 func (c *Client) BallotList(blockhash string) (BallotList, error) {
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/%s/votes/ballot_list", c.chain, blockhash))
+	return c.BallotListContext(context.Background(), blockhash)
+}
+
+// BallotListContext is like BallotList but uses ctx for its requests to the node.
+func (c *Client) BallotListContext(ctx context.Context, blockhash string) (BallotList, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/votes/ballot_list", c.chain, blockhash))
 	if err != nil {
 		return BallotList{}, errors.Wrapf(err, "failed to get ballot list")
 	}
@@ -1985,7 +2371,12 @@ Parameters:
 		The hash of block (height) of which you want to make the query.
 */
 func (c *Client) Ballots(blockhash string) (Ballots, error) {
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/%s/votes/ballots", c.chain, blockhash))
+	return c.BallotsContext(context.Background(), blockhash)
+}
+
+// BallotsContext is like Ballots but uses ctx for its requests to the node.
+func (c *Client) BallotsContext(ctx context.Context, blockhash string) (Ballots, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/votes/ballots", c.chain, blockhash))
 	if err != nil {
 		return Ballots{}, errors.Wrapf(err, "failed to get ballots")
 	}
@@ -2013,7 +2404,12 @@ Parameters:
 		The hash of block (height) of which you want to make the query.
 */
 func (c *Client) CurrentPeriodKind(blockhash string) (string, error) {
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/%s/votes/current_period_kind", c.chain, blockhash))
+	return c.CurrentPeriodKindContext(context.Background(), blockhash)
+}
+
+// CurrentPeriodKindContext is like CurrentPeriodKind but uses ctx for its requests to the node.
+func (c *Client) CurrentPeriodKindContext(ctx context.Context, blockhash string) (string, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/votes/current_period_kind", c.chain, blockhash))
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to get current period kind")
 	}
@@ -2041,7 +2437,12 @@ Parameters:
 		The hash of block (height) of which you want to make the query.
 */
 func (c *Client) CurrentProposal(blockhash string) (string, error) {
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/%s/votes/current_proposal", c.chain, blockhash))
+	return c.CurrentProposalContext(context.Background(), blockhash)
+}
+
+// CurrentProposalContext is like CurrentProposal but uses ctx for its requests to the node.
+func (c *Client) CurrentProposalContext(ctx context.Context, blockhash string) (string, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/votes/current_proposal", c.chain, blockhash))
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to get current proposal")
 	}
@@ -2069,7 +2470,12 @@ Parameters:
 		The hash of block (height) of which you want to make the query.
 */
 func (c *Client) CurrentQuorum(blockhash string) (int, error) {
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/%s/votes/current_quorum", c.chain, blockhash))
+	return c.CurrentQuorumContext(context.Background(), blockhash)
+}
+
+// CurrentQuorumContext is like CurrentQuorum but uses ctx for its requests to the node.
+func (c *Client) CurrentQuorumContext(ctx context.Context, blockhash string) (int, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/votes/current_quorum", c.chain, blockhash))
 	if err != nil {
 		return 0, errors.Wrapf(err, "failed to get current quorum")
 	}
@@ -2097,7 +2503,12 @@ Parameters:
 		The hash of block (height) of which you want to make the query.
 */
 func (c *Client) VoteListings(blockhash string) (Listings, error) {
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/%s/votes/listings", c.chain, blockhash))
+	return c.VoteListingsContext(context.Background(), blockhash)
+}
+
+// VoteListingsContext is like VoteListings but uses ctx for its requests to the node.
+func (c *Client) VoteListingsContext(ctx context.Context, blockhash string) (Listings, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/votes/listings", c.chain, blockhash))
 	if err != nil {
 		return Listings{}, errors.Wrapf(err, "failed to get listings")
 	}
@@ -2125,7 +2536,12 @@ Parameters:
 		The hash of block (height) of which you want to make the query.
 */
 func (c *Client) Proposals(blockhash string) (Proposals, error) {
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/%s/votes/proposals", c.chain, blockhash))
+	return c.ProposalsContext(context.Background(), blockhash)
+}
+
+// ProposalsContext is like Proposals but uses ctx for its requests to the node.
+func (c *Client) ProposalsContext(ctx context.Context, blockhash string) (Proposals, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/votes/proposals", c.chain, blockhash))
 	if err != nil {
 		return Proposals{}, errors.Wrapf(err, "failed to get proposals")
 	}
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -50,6 +51,20 @@ type InvalidBlock struct {
 	Errors Errors `json:"errors"`
 }
 
+/*
+Bootstrapped represents the bootstrap status of a chain.
+
+RPC:
+	/chains/<chain_id>/is_bootstrapped (GET)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-chains-chain-id-is-bootstrapped
+*/
+type Bootstrapped struct {
+	Bootstrapped bool   `json:"bootstrapped"`
+	SyncState    string `json:"sync_state"`
+}
+
 /*
 BlocksInput is the input for the goTezos.Blocks function.
 
@@ -82,7 +97,12 @@ Parameters:
 		Modifies the Blocks RPC query by passing optional URL parameters.
 */
 func (c *Client) Blocks(input BlocksInput) ([][]string, error) {
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks", c.chain), input.contructRPCOptions()...)
+	return c.BlocksContext(context.Background(), input)
+}
+
+// BlocksContext is like Blocks but uses ctx for its requests to the node.
+func (c *Client) BlocksContext(ctx context.Context, input BlocksInput) ([][]string, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks", c.chain), input.contructRPCOptions()...)
 	if err != nil {
 		return [][]string{}, errors.Wrap(err, "failed to get blocks")
 	}
@@ -132,7 +152,12 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-chains-chain-id-chain-id
 */
 func (c *Client) ChainID() (string, error) {
-	resp, err := c.get(fmt.Sprintf("/chains/%s/chain_id", c.chain))
+	return c.ChainIDContext(context.Background())
+}
+
+// ChainIDContext is like ChainID but uses ctx for its requests to the node.
+func (c *Client) ChainIDContext(ctx context.Context) (string, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/chain_id", c.chain))
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to get chain id")
 	}
@@ -146,6 +171,36 @@ func (c *Client) ChainID() (string, error) {
 	return chainID, nil
 }
 
+/*
+IsBootstrapped gets whether the node is bootstrapped and the synchronisation state of the chain, which is "synced",
+"unsynced" or "stuck".
+
+Path:
+	/chains/<chain_id>/is_bootstrapped (GET)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-chains-chain-id-is-bootstrapped
+*/
+func (c *Client) IsBootstrapped() (Bootstrapped, error) {
+	return c.IsBootstrappedContext(context.Background())
+}
+
+// IsBootstrappedContext is like IsBootstrapped but uses ctx for its requests to the node.
+func (c *Client) IsBootstrappedContext(ctx context.Context) (Bootstrapped, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/is_bootstrapped", c.chain))
+	if err != nil {
+		return Bootstrapped{}, errors.Wrap(err, "failed to get bootstrap status")
+	}
+
+	var bootstrapped Bootstrapped
+	err = json.Unmarshal(resp, &bootstrapped)
+	if err != nil {
+		return bootstrapped, errors.Wrap(err, "failed to unmarshal bootstrap status")
+	}
+
+	return bootstrapped, nil
+}
+
 /*
 Checkpoint gets the current checkpoint for this chain.
 
@@ -156,7 +211,12 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-chains-chain-id-checkpoint
 */
 func (c *Client) Checkpoint() (Checkpoint, error) {
-	resp, err := c.get(fmt.Sprintf("/chains/%s/checkpoint", c.chain))
+	return c.CheckpointContext(context.Background())
+}
+
+// CheckpointContext is like Checkpoint but uses ctx for its requests to the node.
+func (c *Client) CheckpointContext(ctx context.Context) (Checkpoint, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/checkpoint", c.chain))
 	if err != nil {
 		return Checkpoint{}, errors.Wrap(err, "failed to get checkpoint")
 	}
@@ -181,7 +241,12 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-chains-chain-id-invalid-blocks
 */
 func (c *Client) InvalidBlocks() ([]InvalidBlock, error) {
-	resp, err := c.get(fmt.Sprintf("/chains/%s/invalid_blocks", c.chain))
+	return c.InvalidBlocksContext(context.Background())
+}
+
+// InvalidBlocksContext is like InvalidBlocks but uses ctx for its requests to the node.
+func (c *Client) InvalidBlocksContext(ctx context.Context) ([]InvalidBlock, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/invalid_blocks", c.chain))
 	if err != nil {
 		return []InvalidBlock{}, errors.Wrap(err, "failed to get invalid blocks")
 	}
@@ -205,7 +270,12 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-chains-chain-id-invalid-blocks-block-hash
 */
 func (c *Client) InvalidBlock(blockHash string) (InvalidBlock, error) {
-	resp, err := c.get(fmt.Sprintf("/chains/%s/invalid_blocks/%s", c.chain, blockHash))
+	return c.InvalidBlockContext(context.Background(), blockHash)
+}
+
+// InvalidBlockContext is like InvalidBlock but uses ctx for its requests to the node.
+func (c *Client) InvalidBlockContext(ctx context.Context, blockHash string) (InvalidBlock, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/invalid_blocks/%s", c.chain, blockHash))
 	if err != nil {
 		return InvalidBlock{}, errors.Wrap(err, "failed to get invalid blocks")
 	}
@@ -229,7 +299,12 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#delete-chains-chain-id-invalid-blocks-block-hash
 */
 func (c *Client) DeleteInvalidBlock(blockHash string) error {
-	_, err := c.delete(fmt.Sprintf("/chains/%s/invalid_blocks/%s", c.chain, blockHash))
+	return c.DeleteInvalidBlockContext(context.Background(), blockHash)
+}
+
+// DeleteInvalidBlockContext is like DeleteInvalidBlock but uses ctx for its requests to the node.
+func (c *Client) DeleteInvalidBlockContext(ctx context.Context, blockHash string) error {
+	_, err := c.delete(ctx, fmt.Sprintf("/chains/%s/invalid_blocks/%s", c.chain, blockHash))
 	if err != nil {
 		return errors.Wrap(err, "failed to delete invalid blocks")
 	}
@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -24,12 +25,17 @@ var (
 /*
 Client contains a client (http.Client), network contents, and the host of the node. Gives access to
 RPC related functions.
+
+Note:
+	Every RPC function has a Context variant, e.g. HeadContext, that takes a context.Context which is attached to
+	the requests to the node, so that cancellation, deadlines and request scoped values propagate to them.
 */
 type Client struct {
 	client           client
 	chain            string
 	networkConstants *Constants
 	host             string
+	retryPolicy      RetryPolicy
 }
 
 /*
@@ -68,6 +74,11 @@ Parameters:
 		A Tezos node.
 */
 func New(host string) (*Client, error) {
+	return NewContext(context.Background(), host)
+}
+
+// NewContext is like New but uses ctx for the requests initializing the network constants.
+func NewContext(ctx context.Context, host string) (*Client, error) {
 	c := &Client{
 		client: &http.Client{
 			Timeout: time.Second * 10,
@@ -78,16 +89,17 @@ func New(host string) (*Client, error) {
 				TLSHandshakeTimeout: 10 * time.Second,
 			},
 		},
-		host:  cleanseHost(host),
-		chain: "main",
+		host:        cleanseHost(host),
+		chain:       "main",
+		retryPolicy: DefaultRetryPolicy(),
 	}
 
-	block, err := c.Head()
+	block, err := c.HeadContext(ctx)
 	if err != nil {
 		return c, errors.Wrap(err, "could not initialize library with network constants")
 	}
 
-	constants, err := c.Constants(block.Hash)
+	constants, err := c.ConstantsContext(ctx, block.Hash)
 	if err != nil {
 		return c, errors.Wrap(err, "could not initialize library with network constants")
 	}
@@ -125,56 +137,83 @@ func (c *Client) SetConstants(constants Constants) {
 	c.networkConstants = &constants
 }
 
-func (c *Client) post(path string, body []byte, opts ...rpcOptions) ([]byte, error) {
-	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s%s", c.host, path), bytes.NewBuffer(body))
+/*
+SetRetryPolicy overrides the RetryPolicy of the Client, DefaultRetryPolicy by default.
+
+Parameters:
+
+	policy:
+		The policy for retrying requests failing with a transient error.
+*/
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+func (c *Client) post(ctx context.Context, path string, body []byte, opts ...rpcOptions) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s%s", c.host, path), bytes.NewBuffer(body))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to construct request")
 	}
 
 	constructQueryParams(req, opts...)
 
-	return c.do(req)
+	return c.do(req, path)
 }
 
-func (c *Client) get(path string, opts ...rpcOptions) ([]byte, error) {
-	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", c.host, path), nil)
+func (c *Client) get(ctx context.Context, path string, opts ...rpcOptions) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.host, path), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to construct request")
 	}
 
 	constructQueryParams(req, opts...)
 
-	return c.do(req)
+	return c.do(req, path)
 }
 
-func (c *Client) delete(path string, opts ...rpcOptions) ([]byte, error) {
-	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s%s", c.host, path), nil)
+func (c *Client) delete(ctx context.Context, path string, opts ...rpcOptions) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, fmt.Sprintf("%s%s", c.host, path), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to construct request")
 	}
 
 	constructQueryParams(req, opts...)
 
-	return c.do(req)
+	return c.do(req, path)
 }
 
-func (c *Client) do(req *http.Request) ([]byte, error) {
+// do makes the request to the RPC path, without the host, retrying it as allowed by the retry policy.
+func (c *Client) do(req *http.Request, path string) ([]byte, error) {
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to complete request")
-	}
 
-	byts, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return byts, errors.Wrap(err, "could not read response body")
+	attempts := 1
+	if c.retryPolicy.allows(req.Method, path) {
+		attempts = c.retryPolicy.MaxAttempts
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return byts, fmt.Errorf("response returned code %d with body %s", resp.StatusCode, string(byts))
+	var (
+		byts []byte
+		err  error
+	)
+	for attempt := 1; ; attempt++ {
+		var retryable bool
+		byts, retryable, err = c.try(req)
+		if err == nil || !retryable || attempt >= attempts {
+			break
+		}
+
+		if serr := sleep(req.Context(), c.retryPolicy.backoff(attempt)); serr != nil {
+			return byts, errors.Wrapf(serr, "stopped retrying request after error '%s'", err.Error())
+		}
+
+		if req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to construct request")
+			}
+		}
 	}
 
-	err = handleRPCError(byts)
 	if err != nil {
 		return byts, err
 	}
@@ -184,6 +223,26 @@ func (c *Client) do(req *http.Request) ([]byte, error) {
 	return byts, nil
 }
 
+// try makes a single attempt of a request and returns if its error is retryable.
+func (c *Client) try(req *http.Request) ([]byte, bool, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, c.retryPolicy.retryableError(err), errors.Wrap(err, "failed to complete request")
+	}
+	defer resp.Body.Close()
+
+	byts, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return byts, c.retryPolicy.retryableError(err), errors.Wrap(err, "could not read response body")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return byts, c.retryPolicy.retryableStatus(resp.StatusCode), &statusError{code: resp.StatusCode, body: byts}
+	}
+
+	return byts, false, handleRPCError(byts)
+}
+
 func constructQueryParams(req *http.Request, opts ...rpcOptions) {
 	q := req.URL.Query()
 	for _, opt := range opts {
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -8,6 +9,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -167,7 +169,7 @@ func Test_post(t *testing.T) {
 			rpc, err := New(server.URL)
 			assert.Nil(t, err)
 
-			p, err := rpc.post(tt.input.post, tt.input.body, tt.input.opts...)
+			p, err := rpc.post(context.Background(), tt.input.post, tt.input.body, tt.input.opts...)
 			checkErr(t, tt.want.err, "", err)
 			assert.Equal(t, tt.want.resp, p)
 		})
@@ -251,7 +253,7 @@ func Test_get(t *testing.T) {
 			rpc, err := New(server.URL)
 			assert.Nil(t, err)
 
-			p, err := rpc.get(tt.input.get, tt.input.params...)
+			p, err := rpc.get(context.Background(), tt.input.get, tt.input.params...)
 			checkErr(t, tt.want.err, "", err)
 			assert.Equal(t, tt.want.resp, p)
 		})
@@ -339,7 +341,7 @@ func Test_do(t *testing.T) {
 			req, err := http.NewRequest(tt.input.method, fmt.Sprintf("%s%s", server.URL, tt.input.path), nil)
 			assert.Nil(t, err)
 
-			p, err := rpc.do(req)
+			p, err := rpc.do(req, tt.input.path)
 			if tt.want.err {
 				assert.NotNil(t, err)
 			} else {
@@ -351,6 +353,64 @@ func Test_do(t *testing.T) {
 	}
 }
 
+type contextKey string
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func Test_Context(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(newBlockMock().handler(readResponse(block), blankHandler)))
+	defer server.Close()
+
+	rpc, err := New(server.URL)
+	assert.Nil(t, err)
+
+	t.Run("propagates context values", func(t *testing.T) {
+		var value interface{}
+		rpc.SetClient(&http.Client{
+			Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				value = req.Context().Value(contextKey("trace"))
+				return http.DefaultTransport.RoundTrip(req)
+			}),
+		})
+
+		_, err := rpc.HeadContext(context.WithValue(context.Background(), contextKey("trace"), "some_trace"))
+		assert.Nil(t, err)
+		assert.Equal(t, "some_trace", value)
+	})
+
+	t.Run("handles canceled context", func(t *testing.T) {
+		rpc.SetClient(http.DefaultClient)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := rpc.BlockContext(ctx, 100)
+		checkErr(t, true, "context canceled", err)
+	})
+
+	t.Run("handles deadline exceeded", func(t *testing.T) {
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}))
+		defer slow.Close()
+
+		rpc := &Client{client: http.DefaultClient, host: slow.URL, chain: "main"}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := rpc.CounterContext(ctx, CounterInput{Blockhash: mockBlockHash, Address: mockAddressTz1})
+		checkErr(t, true, "context deadline exceeded", err)
+	})
+}
+
 func Test_handleRPCError(t *testing.T) {
 	cases := []struct {
 		name        string
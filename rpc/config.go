@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/pkg/errors"
@@ -30,7 +31,12 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-config-network-user-activated-protocol-overrides
 */
 func (c *Client) UserActivatedProtocolOverrides() (UserActivatedProtocolOverrides, error) {
-	resp, err := c.get("/config/network/user_activated_protocol_overrides")
+	return c.UserActivatedProtocolOverridesContext(context.Background())
+}
+
+// UserActivatedProtocolOverridesContext is like UserActivatedProtocolOverrides but uses ctx for its requests to the node.
+func (c *Client) UserActivatedProtocolOverridesContext(ctx context.Context) (UserActivatedProtocolOverrides, error) {
+	resp, err := c.get(ctx, "/config/network/user_activated_protocol_overrides")
 	if err != nil {
 		return UserActivatedProtocolOverrides{}, errors.Wrap(err, "failed to get blocks")
 	}
@@ -0,0 +1,216 @@
+package rpc
+
+/*
+Preendorsement represents a preendorsement in the $operation.alpha.operation_contents_and_result in the tezos block schema
+
+Note:
+	Preendorsements are part of Tenderbake from Ithaca to Nairobi and were renamed preattestations in Oxford.
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type Preendorsement struct {
+	Kind             Kind                    `json:"kind"`
+	Slot             int                     `json:"slot"`
+	Level            int                     `json:"level"`
+	Round            int                     `json:"round"`
+	BlockPayloadHash string                  `json:"block_payload_hash" validate:"required"`
+	Metadata         *PreendorsementMetadata `json:"metadata,omitempty"`
+}
+
+/*
+PreendorsementMetadata represents the metadata of a preendorsement in the $operation.alpha.operation_contents_and_result in the tezos block schema
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type PreendorsementMetadata struct {
+	BalanceUpdates      []BalanceUpdates `json:"balance_updates"`
+	Delegate            string           `json:"delegate"`
+	ConsensusKey        string           `json:"consensus_key,omitempty"`
+	PreendorsementPower int              `json:"preendorsement_power"`
+}
+
+// ToContent converts a Preendorsement to Content
+func (p *Preendorsement) ToContent() Content {
+	var metadata *ContentsMetadata
+
+	if p.Metadata != nil {
+		metadata = &ContentsMetadata{
+			BalanceUpdates:      p.Metadata.BalanceUpdates,
+			Delegate:            p.Metadata.Delegate,
+			ConsensusKey:        p.Metadata.ConsensusKey,
+			PreendorsementPower: p.Metadata.PreendorsementPower,
+		}
+	}
+
+	return Content{
+		Kind:             p.Kind,
+		Slot:             p.Slot,
+		Level:            p.Level,
+		Round:            p.Round,
+		BlockPayloadHash: p.BlockPayloadHash,
+		Metadata:         metadata,
+	}
+}
+
+// ToPreendorsement converts Content to Preendorsement.
+func (c *Content) ToPreendorsement() Preendorsement {
+	var metadata *PreendorsementMetadata
+
+	if c.Metadata != nil {
+		metadata = &PreendorsementMetadata{
+			BalanceUpdates:      c.Metadata.BalanceUpdates,
+			Delegate:            c.Metadata.Delegate,
+			ConsensusKey:        c.Metadata.ConsensusKey,
+			PreendorsementPower: c.Metadata.PreendorsementPower,
+		}
+	}
+
+	return Preendorsement{
+		Kind:             c.Kind,
+		Slot:             c.Slot,
+		Level:            c.Level,
+		Round:            c.Round,
+		BlockPayloadHash: c.BlockPayloadHash,
+		Metadata:         metadata,
+	}
+}
+
+/*
+Attestation represents an attestation in the $operation.alpha.operation_contents_and_result in the tezos block schema
+
+Note:
+	Attestations replaced endorsements in Oxford.
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type Attestation struct {
+	Kind             Kind                 `json:"kind"`
+	Slot             int                  `json:"slot"`
+	Level            int                  `json:"level"`
+	Round            int                  `json:"round"`
+	BlockPayloadHash string               `json:"block_payload_hash" validate:"required"`
+	Metadata         *AttestationMetadata `json:"metadata,omitempty"`
+}
+
+/*
+AttestationMetadata represents the metadata of an attestation or a preattestation in the $operation.alpha.operation_contents_and_result in the tezos block schema
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type AttestationMetadata struct {
+	BalanceUpdates []BalanceUpdates `json:"balance_updates"`
+	Delegate       string           `json:"delegate"`
+	ConsensusKey   string           `json:"consensus_key,omitempty"`
+	ConsensusPower int              `json:"consensus_power"`
+}
+
+func (c *Content) toAttestationMetadata() *AttestationMetadata {
+	if c.Metadata == nil {
+		return nil
+	}
+
+	return &AttestationMetadata{
+		BalanceUpdates: c.Metadata.BalanceUpdates,
+		Delegate:       c.Metadata.Delegate,
+		ConsensusKey:   c.Metadata.ConsensusKey,
+		ConsensusPower: c.Metadata.ConsensusPower,
+	}
+}
+
+func (a *AttestationMetadata) toContentsMetadata() *ContentsMetadata {
+	if a == nil {
+		return nil
+	}
+
+	return &ContentsMetadata{
+		BalanceUpdates: a.BalanceUpdates,
+		Delegate:       a.Delegate,
+		ConsensusKey:   a.ConsensusKey,
+		ConsensusPower: a.ConsensusPower,
+	}
+}
+
+// ToContent converts an Attestation to Content
+func (a *Attestation) ToContent() Content {
+	return Content{
+		Kind:             a.Kind,
+		Slot:             a.Slot,
+		Level:            a.Level,
+		Round:            a.Round,
+		BlockPayloadHash: a.BlockPayloadHash,
+		Metadata:         a.Metadata.toContentsMetadata(),
+	}
+}
+
+// ToAttestation converts Content to Attestation.
+func (c *Content) ToAttestation() Attestation {
+	return Attestation{
+		Kind:             c.Kind,
+		Slot:             c.Slot,
+		Level:            c.Level,
+		Round:            c.Round,
+		BlockPayloadHash: c.BlockPayloadHash,
+		Metadata:         c.toAttestationMetadata(),
+	}
+}
+
+/*
+Preattestation represents a preattestation in the $operation.alpha.operation_contents_and_result in the tezos block schema
+
+Note:
+	Preattestations replaced preendorsements in Oxford.
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type Preattestation struct {
+	Kind             Kind                 `json:"kind"`
+	Slot             int                  `json:"slot"`
+	Level            int                  `json:"level"`
+	Round            int                  `json:"round"`
+	BlockPayloadHash string               `json:"block_payload_hash" validate:"required"`
+	Metadata         *AttestationMetadata `json:"metadata,omitempty"`
+}
+
+// ToContent converts a Preattestation to Content
+func (p *Preattestation) ToContent() Content {
+	return Content{
+		Kind:             p.Kind,
+		Slot:             p.Slot,
+		Level:            p.Level,
+		Round:            p.Round,
+		BlockPayloadHash: p.BlockPayloadHash,
+		Metadata:         p.Metadata.toContentsMetadata(),
+	}
+}
+
+// ToPreattestation converts Content to Preattestation.
+func (c *Content) ToPreattestation() Preattestation {
+	return Preattestation{
+		Kind:             c.Kind,
+		Slot:             c.Slot,
+		Level:            c.Level,
+		Round:            c.Round,
+		BlockPayloadHash: c.BlockPayloadHash,
+		Metadata:         c.toAttestationMetadata(),
+	}
+}
@@ -0,0 +1,202 @@
+package rpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Attestation(t *testing.T) {
+	type want struct {
+		wantErr     bool
+		containsErr string
+		organized   OrganizedContents
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want
+	}{
+		{
+			"is successful with attestation",
+			`[{"kind":"attestation","slot":0,"level":5000,"round":0,"block_payload_hash":"vh2TyrWeZ2dydEy9ZjmvrjQvyCs5sdHZPypcZrXDUSM1tNuPermf","metadata":{"balance_updates":[],"delegate":"tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e","consensus_power":12}}]`,
+			want{
+				false,
+				"",
+				OrganizedContents{
+					Attestations: []Attestation{
+						{
+							Kind:             ATTESTATION,
+							Level:            5000,
+							BlockPayloadHash: "vh2TyrWeZ2dydEy9ZjmvrjQvyCs5sdHZPypcZrXDUSM1tNuPermf",
+							Metadata: &AttestationMetadata{
+								BalanceUpdates: []BalanceUpdates{},
+								Delegate:       "tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e",
+								ConsensusPower: 12,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"is successful with preendorsement",
+			`[{"kind":"preendorsement","slot":3,"level":5000,"round":1,"block_payload_hash":"vh2TyrWeZ2dydEy9ZjmvrjQvyCs5sdHZPypcZrXDUSM1tNuPermf","metadata":{"balance_updates":[],"delegate":"tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e","preendorsement_power":4}}]`,
+			want{
+				false,
+				"",
+				OrganizedContents{
+					Preendorsements: []Preendorsement{
+						{
+							Kind:             PREENDORSEMENT,
+							Slot:             3,
+							Level:            5000,
+							Round:            1,
+							BlockPayloadHash: "vh2TyrWeZ2dydEy9ZjmvrjQvyCs5sdHZPypcZrXDUSM1tNuPermf",
+							Metadata: &PreendorsementMetadata{
+								BalanceUpdates:      []BalanceUpdates{},
+								Delegate:            "tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e",
+								PreendorsementPower: 4,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"handles invalid round",
+			`[{"kind":"preattestation","round":"1"}]`,
+			want{
+				true,
+				"cannot unmarshal string",
+				OrganizedContents{},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var contents Contents
+			err := json.Unmarshal([]byte(tt.input), &contents)
+			checkErr(t, tt.wantErr, tt.containsErr, err)
+			if tt.wantErr {
+				return
+			}
+
+			organized := contents.Organize()
+			assert.Equal(t, tt.want.organized, organized)
+
+			v, err := json.Marshal(&organized)
+			checkErr(t, false, "", err)
+			assert.JSONEq(t, tt.input, string(v))
+		})
+	}
+}
+
+func Test_Block_Tenderbake(t *testing.T) {
+	v := []byte(`{
+		"protocol": "PsQuebecnLByd3JwTiGadoG4nGWi3HYiLXUjkibeFV8dCFeVMUg",
+		"header": {
+			"level": 5000,
+			"payload_hash": "vh2TyrWeZ2dydEy9ZjmvrjQvyCs5sdHZPypcZrXDUSM1tNuPermf",
+			"payload_round": 1,
+			"proof_of_work_nonce": "0102030405060708",
+			"liquidity_baking_toggle_vote": "pass",
+			"adaptive_issuance_vote": "on"
+		},
+		"metadata": {
+			"proposer": "tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e",
+			"baker": "tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e",
+			"level_info": {"level": 5000, "level_position": 4999, "cycle": 1, "cycle_position": 903, "expected_commitment": false},
+			"voting_period_info": {"voting_period": {"index": 0, "kind": "proposal", "start_position": 0}, "position": 4999, "remaining": 15000},
+			"consumed_milligas": "1000000",
+			"liquidity_baking_toggle_ema": 100,
+			"adaptive_issuance_vote_ema": 200,
+			"implicit_operations_results": [{"kind": "transaction", "status": "applied", "consumed_milligas": "100"}],
+			"balance_updates": [{"kind": "minted", "category": "baking rewards", "change": "-10", "origin": "block"}]
+		}
+	}`)
+
+	var block Block
+	err := json.Unmarshal(v, &block)
+	checkErr(t, false, "", err)
+
+	assert.Equal(t, "vh2TyrWeZ2dydEy9ZjmvrjQvyCs5sdHZPypcZrXDUSM1tNuPermf", block.Header.PayloadHash)
+	assert.Equal(t, 1, block.Header.PayloadRound)
+	assert.Equal(t, "pass", block.Header.LiquidityBakingToggleVote)
+	assert.Equal(t, "on", block.Header.AdaptiveIssuanceVote)
+
+	assert.Equal(t, "tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e", block.Metadata.Proposer)
+	assert.Equal(t, &LevelInfo{Level: 5000, LevelPosition: 4999, Cycle: 1, CyclePosition: 903}, block.Metadata.LevelInfo)
+	assert.Equal(t, &VotingPeriodInfo{VotingPeriod: VotingPeriod{Kind: "proposal"}, Position: 4999, Remaining: 15000}, block.Metadata.VotingPeriodInfo)
+	assert.Equal(t, "1000000", block.Metadata.ConsumedMilligas)
+	assert.Equal(t, 100, block.Metadata.LiquidityBakingToggleEma)
+	assert.Equal(t, 200, block.Metadata.AdaptiveIssuanceVoteEma)
+	assert.Equal(t, TRANSACTION, block.Metadata.ImplicitOperationsResults[0].Kind)
+	assert.Equal(t, "applied", block.Metadata.ImplicitOperationsResults[0].Status)
+	assert.Equal(t, "100", block.Metadata.ImplicitOperationsResults[0].ConsumedMilligas)
+	assert.Equal(t, "block", block.Metadata.BalanceUpdates[0].Origin)
+}
+
+func Test_Consensus_MarshalJSON(t *testing.T) {
+	const blockPayloadHash = "vh2TyrWeZ2dydEy9ZjmvrjQvyCs5sdHZPypcZrXDUSM1tNuPermf"
+
+	inlined := &InlinedEndorsement{
+		Branch:     mockBlockHash,
+		Operations: &InlinedEndorsementOperations{Kind: string(ENDORSEMENT), Level: 5000, BlockPayloadHash: blockPayloadHash},
+		Signature:  "sigwHRS2Pz6pbg7AibHYXMS8cqrBageUdbA6a3axWnkt7BwAfFxbi6LZMBTo9WHstV9ZFAt9RJwaxCnmH2PCHMmvRKoYpmfk",
+	}
+
+	cases := []struct {
+		name    string
+		content Content
+		want    string
+	}{
+		{
+			"is successful with endorsement at slot 0 and round 0",
+			Content{Kind: ENDORSEMENT, Level: 5000, BlockPayloadHash: blockPayloadHash},
+			`{"kind":"endorsement","level":5000,"block_payload_hash":"` + blockPayloadHash + `","metadata":null,"slot":0,"round":0}`,
+		},
+		{
+			"is successful with preendorsement at slot 0 and round 0",
+			Content{Kind: PREENDORSEMENT, Level: 5000, BlockPayloadHash: blockPayloadHash},
+			`{"kind":"preendorsement","slot":0,"level":5000,"round":0,"block_payload_hash":"` + blockPayloadHash + `"}`,
+		},
+		{
+			"is successful with attestation at slot 0 and round 0",
+			Content{Kind: ATTESTATION, Level: 5000, BlockPayloadHash: blockPayloadHash},
+			`{"kind":"attestation","slot":0,"level":5000,"round":0,"block_payload_hash":"` + blockPayloadHash + `"}`,
+		},
+		{
+			"is successful with preattestation at slot 0 and round 0",
+			Content{Kind: PREATTESTATION, Level: 5000, BlockPayloadHash: blockPayloadHash},
+			`{"kind":"preattestation","slot":0,"level":5000,"round":0,"block_payload_hash":"` + blockPayloadHash + `"}`,
+		},
+		{
+			"is successful with Emmy endorsement",
+			Content{Kind: ENDORSEMENT, Level: 5000},
+			`{"kind":"endorsement","level":5000,"metadata":null}`,
+		},
+		{
+			"is successful with double endorsement evidence at slot 0 and round 0",
+			Content{Kind: DOUBLEENDORSEMENTEVIDENCE, Op1: inlined, Op2: inlined},
+			`{"kind":"double_endorsement_evidence","Op1":{"branch":"` + mockBlockHash + `","operations":{"kind":"endorsement","level":5000,"block_payload_hash":"` + blockPayloadHash + `","slot":0,"round":0},"signature":"` + inlined.Signature + `"},"Op2":{"branch":"` + mockBlockHash + `","operations":{"kind":"endorsement","level":5000,"block_payload_hash":"` + blockPayloadHash + `","slot":0,"round":0},"signature":"` + inlined.Signature + `"},"metadata":null}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// a Content value, not only a pointer, is marshaled based on its kind
+			v, err := json.Marshal(tt.content)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, string(v))
+
+			var contents Contents
+			err = json.Unmarshal([]byte("["+string(v)+"]"), &contents)
+			assert.Nil(t, err)
+			assert.Equal(t, Contents{tt.content}, contents)
+		})
+	}
+}
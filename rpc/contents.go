@@ -0,0 +1,698 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+/*
+ManagerOperationMetadata represents the metadata of the manager operations added after Babylon in the
+$operation.alpha.operation_contents_and_result in the tezos block schema
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type ManagerOperationMetadata struct {
+	BalanceUpdates           []BalanceUpdates           `json:"balance_updates"`
+	OperationResult          *OperationResults          `json:"operation_result,omitempty"`
+	InternalOperationResults []InternalOperationResults `json:"internal_operation_results,omitempty"`
+}
+
+func (c *Content) toManagerOperationMetadata() *ManagerOperationMetadata {
+	if c.Metadata == nil {
+		return nil
+	}
+
+	return &ManagerOperationMetadata{
+		BalanceUpdates:           c.Metadata.BalanceUpdates,
+		OperationResult:          c.Metadata.OperationResults,
+		InternalOperationResults: c.Metadata.InternalOperationResult,
+	}
+}
+
+func (m *ManagerOperationMetadata) toContentsMetadata() *ContentsMetadata {
+	if m == nil {
+		return nil
+	}
+
+	return &ContentsMetadata{
+		BalanceUpdates:          m.BalanceUpdates,
+		OperationResults:        m.OperationResult,
+		InternalOperationResult: m.InternalOperationResults,
+	}
+}
+
+/*
+RegisterGlobalConstant represents a register_global_constant in the $operation.alpha.operation_contents_and_result in the tezos block schema
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type RegisterGlobalConstant struct {
+	Kind         Kind                      `json:"kind"`
+	Source       string                    `json:"source" validate:"required"`
+	Fee          string                    `json:"fee" validate:"required"`
+	Counter      string                    `json:"counter" validate:"required"`
+	GasLimit     string                    `json:"gas_limit" validate:"required"`
+	StorageLimit string                    `json:"storage_limit" validate:"required"`
+	Value        *json.RawMessage          `json:"value" validate:"required"`
+	Metadata     *ManagerOperationMetadata `json:"metadata,omitempty"`
+}
+
+// ToContent converts a RegisterGlobalConstant to Content
+func (r *RegisterGlobalConstant) ToContent() Content {
+	return Content{
+		Kind:         r.Kind,
+		Source:       r.Source,
+		Fee:          r.Fee,
+		Counter:      r.Counter,
+		GasLimit:     r.GasLimit,
+		StorageLimit: r.StorageLimit,
+		Value:        r.Value,
+		Metadata:     r.Metadata.toContentsMetadata(),
+	}
+}
+
+// ToRegisterGlobalConstant converts Content to RegisterGlobalConstant.
+func (c *Content) ToRegisterGlobalConstant() RegisterGlobalConstant {
+	return RegisterGlobalConstant{
+		Kind:         c.Kind,
+		Source:       c.Source,
+		Fee:          c.Fee,
+		Counter:      c.Counter,
+		GasLimit:     c.GasLimit,
+		StorageLimit: c.StorageLimit,
+		Value:        c.Value,
+		Metadata:     c.toManagerOperationMetadata(),
+	}
+}
+
+/*
+SetDepositsLimit represents a set_deposits_limit in the $operation.alpha.operation_contents_and_result in the tezos block schema
+
+Note:
+	An empty Limit removes the deposits limit of the delegate.
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type SetDepositsLimit struct {
+	Kind         Kind                      `json:"kind"`
+	Source       string                    `json:"source" validate:"required"`
+	Fee          string                    `json:"fee" validate:"required"`
+	Counter      string                    `json:"counter" validate:"required"`
+	GasLimit     string                    `json:"gas_limit" validate:"required"`
+	StorageLimit string                    `json:"storage_limit" validate:"required"`
+	Limit        string                    `json:"limit,omitempty"`
+	Metadata     *ManagerOperationMetadata `json:"metadata,omitempty"`
+}
+
+// ToContent converts a SetDepositsLimit to Content
+func (s *SetDepositsLimit) ToContent() Content {
+	return Content{
+		Kind:         s.Kind,
+		Source:       s.Source,
+		Fee:          s.Fee,
+		Counter:      s.Counter,
+		GasLimit:     s.GasLimit,
+		StorageLimit: s.StorageLimit,
+		Limit:        s.Limit,
+		Metadata:     s.Metadata.toContentsMetadata(),
+	}
+}
+
+// ToSetDepositsLimit converts Content to SetDepositsLimit.
+func (c *Content) ToSetDepositsLimit() SetDepositsLimit {
+	return SetDepositsLimit{
+		Kind:         c.Kind,
+		Source:       c.Source,
+		Fee:          c.Fee,
+		Counter:      c.Counter,
+		GasLimit:     c.GasLimit,
+		StorageLimit: c.StorageLimit,
+		Limit:        c.Limit,
+		Metadata:     c.toManagerOperationMetadata(),
+	}
+}
+
+/*
+IncreasePaidStorage represents an increase_paid_storage in the $operation.alpha.operation_contents_and_result in the tezos block schema
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type IncreasePaidStorage struct {
+	Kind         Kind                      `json:"kind"`
+	Source       string                    `json:"source" validate:"required"`
+	Fee          string                    `json:"fee" validate:"required"`
+	Counter      string                    `json:"counter" validate:"required"`
+	GasLimit     string                    `json:"gas_limit" validate:"required"`
+	StorageLimit string                    `json:"storage_limit" validate:"required"`
+	Amount       string                    `json:"amount" validate:"required"`
+	Destination  string                    `json:"destination" validate:"required"`
+	Metadata     *ManagerOperationMetadata `json:"metadata,omitempty"`
+}
+
+// ToContent converts an IncreasePaidStorage to Content
+func (i *IncreasePaidStorage) ToContent() Content {
+	return Content{
+		Kind:         i.Kind,
+		Source:       i.Source,
+		Fee:          i.Fee,
+		Counter:      i.Counter,
+		GasLimit:     i.GasLimit,
+		StorageLimit: i.StorageLimit,
+		Amount:       i.Amount,
+		Destination:  i.Destination,
+		Metadata:     i.Metadata.toContentsMetadata(),
+	}
+}
+
+// ToIncreasePaidStorage converts Content to IncreasePaidStorage.
+func (c *Content) ToIncreasePaidStorage() IncreasePaidStorage {
+	return IncreasePaidStorage{
+		Kind:         c.Kind,
+		Source:       c.Source,
+		Fee:          c.Fee,
+		Counter:      c.Counter,
+		GasLimit:     c.GasLimit,
+		StorageLimit: c.StorageLimit,
+		Amount:       c.Amount,
+		Destination:  c.Destination,
+		Metadata:     c.toManagerOperationMetadata(),
+	}
+}
+
+/*
+UpdateConsensusKey represents an update_consensus_key in the $operation.alpha.operation_contents_and_result in the tezos block schema
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type UpdateConsensusKey struct {
+	Kind         Kind                      `json:"kind"`
+	Source       string                    `json:"source" validate:"required"`
+	Fee          string                    `json:"fee" validate:"required"`
+	Counter      string                    `json:"counter" validate:"required"`
+	GasLimit     string                    `json:"gas_limit" validate:"required"`
+	StorageLimit string                    `json:"storage_limit" validate:"required"`
+	Pk           string                    `json:"pk" validate:"required"`
+	Metadata     *ManagerOperationMetadata `json:"metadata,omitempty"`
+}
+
+// ToContent converts an UpdateConsensusKey to Content
+func (u *UpdateConsensusKey) ToContent() Content {
+	return Content{
+		Kind:         u.Kind,
+		Source:       u.Source,
+		Fee:          u.Fee,
+		Counter:      u.Counter,
+		GasLimit:     u.GasLimit,
+		StorageLimit: u.StorageLimit,
+		Pk:           u.Pk,
+		Metadata:     u.Metadata.toContentsMetadata(),
+	}
+}
+
+// ToUpdateConsensusKey converts Content to UpdateConsensusKey.
+func (c *Content) ToUpdateConsensusKey() UpdateConsensusKey {
+	return UpdateConsensusKey{
+		Kind:         c.Kind,
+		Source:       c.Source,
+		Fee:          c.Fee,
+		Counter:      c.Counter,
+		GasLimit:     c.GasLimit,
+		StorageLimit: c.StorageLimit,
+		Pk:           c.Pk,
+		Metadata:     c.toManagerOperationMetadata(),
+	}
+}
+
+/*
+DrainDelegate represents a drain_delegate in the $operation.alpha.operation_contents_and_result in the tezos block schema
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type DrainDelegate struct {
+	Kind         Kind                   `json:"kind"`
+	ConsensusKey string                 `json:"consensus_key" validate:"required"`
+	Delegate     string                 `json:"delegate" validate:"required"`
+	Destination  string                 `json:"destination" validate:"required"`
+	Metadata     *DrainDelegateMetadata `json:"metadata,omitempty"`
+}
+
+/*
+DrainDelegateMetadata represents the metadata of a drain_delegate in the $operation.alpha.operation_contents_and_result in the tezos block schema
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type DrainDelegateMetadata struct {
+	BalanceUpdates               []BalanceUpdates `json:"balance_updates"`
+	AllocatedDestinationContract bool             `json:"allocated_destination_contract,omitempty"`
+}
+
+// ToContent converts a DrainDelegate to Content
+func (d *DrainDelegate) ToContent() Content {
+	var metadata *ContentsMetadata
+	if d.Metadata != nil {
+		metadata = &ContentsMetadata{
+			BalanceUpdates:               d.Metadata.BalanceUpdates,
+			AllocatedDestinationContract: d.Metadata.AllocatedDestinationContract,
+		}
+	}
+
+	return Content{
+		Kind:         d.Kind,
+		ConsensusKey: d.ConsensusKey,
+		Delegate:     d.Delegate,
+		Destination:  d.Destination,
+		Metadata:     metadata,
+	}
+}
+
+// ToDrainDelegate converts Content to DrainDelegate.
+func (c *Content) ToDrainDelegate() DrainDelegate {
+	var metadata *DrainDelegateMetadata
+	if c.Metadata != nil {
+		metadata = &DrainDelegateMetadata{
+			BalanceUpdates:               c.Metadata.BalanceUpdates,
+			AllocatedDestinationContract: c.Metadata.AllocatedDestinationContract,
+		}
+	}
+
+	return DrainDelegate{
+		Kind:         c.Kind,
+		ConsensusKey: c.ConsensusKey,
+		Delegate:     c.Delegate,
+		Destination:  c.Destination,
+		Metadata:     metadata,
+	}
+}
+
+/*
+TransferTicket represents a transfer_ticket in the $operation.alpha.operation_contents_and_result in the tezos block schema
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type TransferTicket struct {
+	Kind           Kind                      `json:"kind"`
+	Source         string                    `json:"source" validate:"required"`
+	Fee            string                    `json:"fee" validate:"required"`
+	Counter        string                    `json:"counter" validate:"required"`
+	GasLimit       string                    `json:"gas_limit" validate:"required"`
+	StorageLimit   string                    `json:"storage_limit" validate:"required"`
+	TicketContents *json.RawMessage          `json:"ticket_contents" validate:"required"`
+	TicketTy       *json.RawMessage          `json:"ticket_ty" validate:"required"`
+	TicketTicketer string                    `json:"ticket_ticketer" validate:"required"`
+	TicketAmount   string                    `json:"ticket_amount" validate:"required"`
+	Destination    string                    `json:"destination" validate:"required"`
+	Entrypoint     string                    `json:"entrypoint" validate:"required"`
+	Metadata       *ManagerOperationMetadata `json:"metadata,omitempty"`
+}
+
+// ToContent converts a TransferTicket to Content
+func (t *TransferTicket) ToContent() Content {
+	return Content{
+		Kind:           t.Kind,
+		Source:         t.Source,
+		Fee:            t.Fee,
+		Counter:        t.Counter,
+		GasLimit:       t.GasLimit,
+		StorageLimit:   t.StorageLimit,
+		TicketContents: t.TicketContents,
+		TicketTy:       t.TicketTy,
+		TicketTicketer: t.TicketTicketer,
+		TicketAmount:   t.TicketAmount,
+		Destination:    t.Destination,
+		Entrypoint:     t.Entrypoint,
+		Metadata:       t.Metadata.toContentsMetadata(),
+	}
+}
+
+// ToTransferTicket converts Content to TransferTicket.
+func (c *Content) ToTransferTicket() TransferTicket {
+	return TransferTicket{
+		Kind:           c.Kind,
+		Source:         c.Source,
+		Fee:            c.Fee,
+		Counter:        c.Counter,
+		GasLimit:       c.GasLimit,
+		StorageLimit:   c.StorageLimit,
+		TicketContents: c.TicketContents,
+		TicketTy:       c.TicketTy,
+		TicketTicketer: c.TicketTicketer,
+		TicketAmount:   c.TicketAmount,
+		Destination:    c.Destination,
+		Entrypoint:     c.Entrypoint,
+		Metadata:       c.toManagerOperationMetadata(),
+	}
+}
+
+/*
+SmartRollupOriginate represents a smart_rollup_originate in the $operation.alpha.operation_contents_and_result in the tezos block schema
+
+Note:
+	OriginationProof is only part of the operation in Mumbai and Whitelist from Oxford onwards.
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type SmartRollupOriginate struct {
+	Kind             Kind                      `json:"kind"`
+	Source           string                    `json:"source" validate:"required"`
+	Fee              string                    `json:"fee" validate:"required"`
+	Counter          string                    `json:"counter" validate:"required"`
+	GasLimit         string                    `json:"gas_limit" validate:"required"`
+	StorageLimit     string                    `json:"storage_limit" validate:"required"`
+	PvmKind          string                    `json:"pvm_kind" validate:"required"`
+	Kernel           string                    `json:"kernel"`
+	OriginationProof string                    `json:"origination_proof,omitempty"`
+	ParametersTy     *json.RawMessage          `json:"parameters_ty" validate:"required"`
+	Whitelist        []string                  `json:"whitelist,omitempty"`
+	Metadata         *ManagerOperationMetadata `json:"metadata,omitempty"`
+}
+
+// ToContent converts a SmartRollupOriginate to Content
+func (s *SmartRollupOriginate) ToContent() Content {
+	return Content{
+		Kind:             s.Kind,
+		Source:           s.Source,
+		Fee:              s.Fee,
+		Counter:          s.Counter,
+		GasLimit:         s.GasLimit,
+		StorageLimit:     s.StorageLimit,
+		PvmKind:          s.PvmKind,
+		Kernel:           s.Kernel,
+		OriginationProof: s.OriginationProof,
+		ParametersTy:     s.ParametersTy,
+		Whitelist:        s.Whitelist,
+		Metadata:         s.Metadata.toContentsMetadata(),
+	}
+}
+
+// ToSmartRollupOriginate converts Content to SmartRollupOriginate.
+func (c *Content) ToSmartRollupOriginate() SmartRollupOriginate {
+	return SmartRollupOriginate{
+		Kind:             c.Kind,
+		Source:           c.Source,
+		Fee:              c.Fee,
+		Counter:          c.Counter,
+		GasLimit:         c.GasLimit,
+		StorageLimit:     c.StorageLimit,
+		PvmKind:          c.PvmKind,
+		Kernel:           c.Kernel,
+		OriginationProof: c.OriginationProof,
+		ParametersTy:     c.ParametersTy,
+		Whitelist:        c.Whitelist,
+		Metadata:         c.toManagerOperationMetadata(),
+	}
+}
+
+/*
+SmartRollupAddMessages represents a smart_rollup_add_messages in the $operation.alpha.operation_contents_and_result in the tezos block schema
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type SmartRollupAddMessages struct {
+	Kind         Kind                      `json:"kind"`
+	Source       string                    `json:"source" validate:"required"`
+	Fee          string                    `json:"fee" validate:"required"`
+	Counter      string                    `json:"counter" validate:"required"`
+	GasLimit     string                    `json:"gas_limit" validate:"required"`
+	StorageLimit string                    `json:"storage_limit" validate:"required"`
+	Message      []string                  `json:"message" validate:"required"`
+	Metadata     *ManagerOperationMetadata `json:"metadata,omitempty"`
+}
+
+// ToContent converts a SmartRollupAddMessages to Content
+func (s *SmartRollupAddMessages) ToContent() Content {
+	return Content{
+		Kind:         s.Kind,
+		Source:       s.Source,
+		Fee:          s.Fee,
+		Counter:      s.Counter,
+		GasLimit:     s.GasLimit,
+		StorageLimit: s.StorageLimit,
+		Message:      s.Message,
+		Metadata:     s.Metadata.toContentsMetadata(),
+	}
+}
+
+// ToSmartRollupAddMessages converts Content to SmartRollupAddMessages.
+func (c *Content) ToSmartRollupAddMessages() SmartRollupAddMessages {
+	return SmartRollupAddMessages{
+		Kind:         c.Kind,
+		Source:       c.Source,
+		Fee:          c.Fee,
+		Counter:      c.Counter,
+		GasLimit:     c.GasLimit,
+		StorageLimit: c.StorageLimit,
+		Message:      c.Message,
+		Metadata:     c.toManagerOperationMetadata(),
+	}
+}
+
+/*
+SmartRollupCement represents a smart_rollup_cement in the $operation.alpha.operation_contents_and_result in the tezos block schema
+
+Note:
+	Commitment is only part of the operation in Mumbai and Nairobi.
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type SmartRollupCement struct {
+	Kind         Kind                      `json:"kind"`
+	Source       string                    `json:"source" validate:"required"`
+	Fee          string                    `json:"fee" validate:"required"`
+	Counter      string                    `json:"counter" validate:"required"`
+	GasLimit     string                    `json:"gas_limit" validate:"required"`
+	StorageLimit string                    `json:"storage_limit" validate:"required"`
+	Rollup       string                    `json:"rollup" validate:"required"`
+	Commitment   string                    `json:"commitment,omitempty"`
+	Metadata     *ManagerOperationMetadata `json:"metadata,omitempty"`
+}
+
+// ToContent converts a SmartRollupCement to Content
+func (s *SmartRollupCement) ToContent() Content {
+	var commitment *SmartRollupCommitment
+	if s.Commitment != "" {
+		commitment = &SmartRollupCommitment{Hash: s.Commitment}
+	}
+
+	return Content{
+		Kind:         s.Kind,
+		Source:       s.Source,
+		Fee:          s.Fee,
+		Counter:      s.Counter,
+		GasLimit:     s.GasLimit,
+		StorageLimit: s.StorageLimit,
+		Rollup:       s.Rollup,
+		Commitment:   commitment,
+		Metadata:     s.Metadata.toContentsMetadata(),
+	}
+}
+
+// ToSmartRollupCement converts Content to SmartRollupCement.
+func (c *Content) ToSmartRollupCement() SmartRollupCement {
+	var commitment string
+	if c.Commitment != nil {
+		commitment = c.Commitment.Hash
+	}
+
+	return SmartRollupCement{
+		Kind:         c.Kind,
+		Source:       c.Source,
+		Fee:          c.Fee,
+		Counter:      c.Counter,
+		GasLimit:     c.GasLimit,
+		StorageLimit: c.StorageLimit,
+		Rollup:       c.Rollup,
+		Commitment:   commitment,
+		Metadata:     c.toManagerOperationMetadata(),
+	}
+}
+
+/*
+SmartRollupPublish represents a smart_rollup_publish in the $operation.alpha.operation_contents_and_result in the tezos block schema
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type SmartRollupPublish struct {
+	Kind         Kind                      `json:"kind"`
+	Source       string                    `json:"source" validate:"required"`
+	Fee          string                    `json:"fee" validate:"required"`
+	Counter      string                    `json:"counter" validate:"required"`
+	GasLimit     string                    `json:"gas_limit" validate:"required"`
+	StorageLimit string                    `json:"storage_limit" validate:"required"`
+	Rollup       string                    `json:"rollup" validate:"required"`
+	Commitment   *SmartRollupCommitment    `json:"commitment" validate:"required"`
+	Metadata     *ManagerOperationMetadata `json:"metadata,omitempty"`
+}
+
+// ToContent converts a SmartRollupPublish to Content
+func (s *SmartRollupPublish) ToContent() Content {
+	return Content{
+		Kind:         s.Kind,
+		Source:       s.Source,
+		Fee:          s.Fee,
+		Counter:      s.Counter,
+		GasLimit:     s.GasLimit,
+		StorageLimit: s.StorageLimit,
+		Rollup:       s.Rollup,
+		Commitment:   s.Commitment,
+		Metadata:     s.Metadata.toContentsMetadata(),
+	}
+}
+
+// ToSmartRollupPublish converts Content to SmartRollupPublish.
+func (c *Content) ToSmartRollupPublish() SmartRollupPublish {
+	return SmartRollupPublish{
+		Kind:         c.Kind,
+		Source:       c.Source,
+		Fee:          c.Fee,
+		Counter:      c.Counter,
+		GasLimit:     c.GasLimit,
+		StorageLimit: c.StorageLimit,
+		Rollup:       c.Rollup,
+		Commitment:   c.Commitment,
+		Metadata:     c.toManagerOperationMetadata(),
+	}
+}
+
+/*
+SmartRollupCommitment represents $smart_rollup_commitment in the tezos block schema, the commitment published by
+smart_rollup_publish.
+
+Note:
+	smart_rollup_cement only refers to the hash of its commitment, in which case Hash is set and the commitment is
+	marshaled as a string.
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type SmartRollupCommitment struct {
+	Hash            string `json:"-"`
+	CompressedState string `json:"compressed_state"`
+	InboxLevel      int    `json:"inbox_level"`
+	Predecessor     string `json:"predecessor"`
+	NumberOfTicks   string `json:"number_of_ticks"`
+}
+
+type smartRollupCommitment SmartRollupCommitment
+
+// MarshalJSON satisfies json.Marshaler
+func (s *SmartRollupCommitment) MarshalJSON() ([]byte, error) {
+	if s.Hash != "" {
+		return json.Marshal(s.Hash)
+	}
+
+	return json.Marshal((*smartRollupCommitment)(s))
+}
+
+// UnmarshalJSON satisfies json.Unmarshaler
+func (s *SmartRollupCommitment) UnmarshalJSON(v []byte) error {
+	if len(v) > 0 && v[0] == '"' {
+		*s = SmartRollupCommitment{}
+		return json.Unmarshal(v, &s.Hash)
+	}
+
+	return json.Unmarshal(v, (*smartRollupCommitment)(s))
+}
+
+/*
+SmartRollupExecuteOutboxMessage represents a smart_rollup_execute_outbox_message in the $operation.alpha.operation_contents_and_result in the tezos block schema
+
+RPC:
+	/chains/<chain_id>/blocks/<block_id> (<dyn>)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type SmartRollupExecuteOutboxMessage struct {
+	Kind               Kind                      `json:"kind"`
+	Source             string                    `json:"source" validate:"required"`
+	Fee                string                    `json:"fee" validate:"required"`
+	Counter            string                    `json:"counter" validate:"required"`
+	GasLimit           string                    `json:"gas_limit" validate:"required"`
+	StorageLimit       string                    `json:"storage_limit" validate:"required"`
+	Rollup             string                    `json:"rollup" validate:"required"`
+	CementedCommitment string                    `json:"cemented_commitment" validate:"required"`
+	OutputProof        string                    `json:"output_proof" validate:"required"`
+	Metadata           *ManagerOperationMetadata `json:"metadata,omitempty"`
+}
+
+// ToContent converts a SmartRollupExecuteOutboxMessage to Content
+func (s *SmartRollupExecuteOutboxMessage) ToContent() Content {
+	return Content{
+		Kind:               s.Kind,
+		Source:             s.Source,
+		Fee:                s.Fee,
+		Counter:            s.Counter,
+		GasLimit:           s.GasLimit,
+		StorageLimit:       s.StorageLimit,
+		Rollup:             s.Rollup,
+		CementedCommitment: s.CementedCommitment,
+		OutputProof:        s.OutputProof,
+		Metadata:           s.Metadata.toContentsMetadata(),
+	}
+}
+
+// ToSmartRollupExecuteOutboxMessage converts Content to SmartRollupExecuteOutboxMessage.
+func (c *Content) ToSmartRollupExecuteOutboxMessage() SmartRollupExecuteOutboxMessage {
+	return SmartRollupExecuteOutboxMessage{
+		Kind:               c.Kind,
+		Source:             c.Source,
+		Fee:                c.Fee,
+		Counter:            c.Counter,
+		GasLimit:           c.GasLimit,
+		StorageLimit:       c.StorageLimit,
+		Rollup:             c.Rollup,
+		CementedCommitment: c.CementedCommitment,
+		OutputProof:        c.OutputProof,
+		Metadata:           c.toManagerOperationMetadata(),
+	}
+}
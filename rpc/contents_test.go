@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SmartRollupCommitment(t *testing.T) {
+	type want struct {
+		wantErr     bool
+		containsErr string
+		content     Content
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want
+	}{
+		{
+			"is successful with cement commitment hash",
+			`{"kind":"smart_rollup_cement","rollup":"sr1Ghq66tYK9y3r8CC1Tf8i8m5nxh8nTvZEf","commitment":"src12UJzB8mg7yU6nWPzicH7ofJbFjyJEbHvwtZdfRXi8DQHNp1LY8"}`,
+			want{
+				false,
+				"",
+				Content{
+					Kind:       SMARTROLLUPCEMENT,
+					Rollup:     "sr1Ghq66tYK9y3r8CC1Tf8i8m5nxh8nTvZEf",
+					Commitment: &SmartRollupCommitment{Hash: "src12UJzB8mg7yU6nWPzicH7ofJbFjyJEbHvwtZdfRXi8DQHNp1LY8"},
+				},
+			},
+		},
+		{
+			"is successful with published commitment",
+			`{"kind":"smart_rollup_publish","rollup":"sr1Ghq66tYK9y3r8CC1Tf8i8m5nxh8nTvZEf","commitment":{"compressed_state":"srs11y1ZCJfeWnHzoX3rAjcTXiphwg8NvqQhvishP3PU68jgSREuk6","inbox_level":300,"predecessor":"src12UJzB8mg7yU6nWPzicH7ofJbFjyJEbHvwtZdfRXi8DQHNp1LY8","number_of_ticks":"880000000000"}}`,
+			want{
+				false,
+				"",
+				Content{
+					Kind:   SMARTROLLUPPUBLISH,
+					Rollup: "sr1Ghq66tYK9y3r8CC1Tf8i8m5nxh8nTvZEf",
+					Commitment: &SmartRollupCommitment{
+						CompressedState: "srs11y1ZCJfeWnHzoX3rAjcTXiphwg8NvqQhvishP3PU68jgSREuk6",
+						InboxLevel:      300,
+						Predecessor:     "src12UJzB8mg7yU6nWPzicH7ofJbFjyJEbHvwtZdfRXi8DQHNp1LY8",
+						NumberOfTicks:   "880000000000",
+					},
+				},
+			},
+		},
+		{
+			"handles invalid commitment",
+			`{"kind":"smart_rollup_publish","commitment":1}`,
+			want{
+				true,
+				"cannot unmarshal number",
+				Content{},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var content Content
+			err := json.Unmarshal([]byte(tt.input), &content)
+			checkErr(t, tt.wantErr, tt.containsErr, err)
+			if tt.wantErr {
+				return
+			}
+
+			assert.Equal(t, tt.want.content, content)
+
+			var fields map[string]json.RawMessage
+			checkErr(t, false, "", json.Unmarshal([]byte(tt.input), &fields))
+
+			v, err := json.Marshal(content.Commitment)
+			checkErr(t, false, "", err)
+			assert.JSONEq(t, string(fields["commitment"]), string(v))
+		})
+	}
+}
+
+func Test_SmartRollupCement_ToContent(t *testing.T) {
+	cement := SmartRollupCement{
+		Kind:       SMARTROLLUPCEMENT,
+		Source:     "tz1NXjqkurAmpKJEF76T58oyNsy3hWK7mk8e",
+		Rollup:     "sr1Ghq66tYK9y3r8CC1Tf8i8m5nxh8nTvZEf",
+		Commitment: "src12UJzB8mg7yU6nWPzicH7ofJbFjyJEbHvwtZdfRXi8DQHNp1LY8",
+	}
+
+	content := cement.ToContent()
+	assert.Equal(t, cement.Commitment, content.Commitment.Hash)
+	assert.Equal(t, cement, content.ToSmartRollupCement())
+
+	cement.Commitment = ""
+	content = cement.ToContent()
+	assert.Nil(t, content.Commitment)
+	assert.Equal(t, cement, content.ToSmartRollupCement())
+}
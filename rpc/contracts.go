@@ -1,12 +1,13 @@
 package rpc
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
-	"strings"
 
 	validator "github.com/go-playground/validator/v10"
-	"github.com/goat-systems/go-tezos/v3/internal/crypto"
+	"github.com/goat-systems/go-tezos/v3/address"
+	"github.com/goat-systems/go-tezos/v3/b58"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/blake2b"
 )
@@ -68,13 +69,18 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-storage
 */
 func (c *Client) ContractStorage(input ContractStorageInput) ([]byte, error) {
+	return c.ContractStorageContext(context.Background(), input)
+}
+
+// ContractStorageContext is like ContractStorage but uses ctx for its requests to the node.
+func (c *Client) ContractStorageContext(ctx context.Context, input ContractStorageInput) ([]byte, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
 	}
 
 	query := fmt.Sprintf("/chains/%s/blocks/%s/context/contracts/%s/storage", c.chain, input.Blockhash, input.Contract)
-	resp, err := c.get(query)
+	resp, err := c.get(ctx, query)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "could not get storage '%s'")
 	}
@@ -92,18 +98,23 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-block-id-context-big-maps-big-map-id-script-expr
 */
 func (c *Client) BigMap(input BigMapInput) ([]byte, error) {
+	return c.BigMapContext(context.Background(), input)
+}
+
+// BigMapContext is like BigMap but uses ctx for its requests to the node.
+func (c *Client) BigMapContext(ctx context.Context, input BigMapInput) ([]byte, error) {
 	err := input.validate()
 	if err != nil {
 		return []byte{}, errors.Wrapf(err, "could not get big map '%d' at cycle '%d'", input.BigMapID, input.Cycle)
 	}
 
-	input.Blockhash, err = c.extractBlockHash(input.Cycle, input.Blockhash)
+	input.Blockhash, err = c.extractBlockHash(ctx, input.Cycle, input.Blockhash)
 	if err != nil {
 		return []byte{}, errors.Wrapf(err, "could not get big map '%d' at cycle '%d'", input.BigMapID, input.Cycle)
 	}
 
 	query := fmt.Sprintf("/chains/%s/blocks/%s/context/big_maps/%d/%s", c.chain, input.Blockhash, input.BigMapID, input.ScriptExpression)
-	resp, err := c.get(query)
+	resp, err := c.get(ctx, query)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "could not get storage '%s'")
 	}
@@ -121,54 +132,39 @@ func ForgeScriptExpressionForAddress(input string) (ScriptExpression, error) {
 		return "", errors.Wrap(err, "failed to forge script expression for address")
 	}
 
-	prefix := []byte{13, 44, 64, 27}
-
-	a := []byte{}
-	for i := 0; i < len(input); i += 2 {
-		elem, err := hex.DecodeString(input[i:(i + 2)])
-		if err != nil {
-			return "", errors.Wrap(err, "failed to forge script expression for address")
-		}
-		a = append(a, elem...)
-	}
-
-	hash, err := blake2b.New(32, []byte{})
+	v, err := hex.DecodeString(input)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to forge script expression for address")
 	}
 
-	_, err = hash.Write(a)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to forge script expression for address")
-	}
+	return ForgeScriptExpression(v)
+}
 
-	blakeHash := hash.Sum([]byte{})
-	n := []byte{}
-	n = append(n, prefix...)
-	n = append(n, blakeHash...)
+/*
+ForgeScriptExpression hashes packed data (e.g. the result of forge.Pack) to the script expression used to look up
+a big map value.
 
-	return ScriptExpression(crypto.Encode(n)), nil
-}
+Parameters:
 
-func pack(input string) (string, error) {
-	var prefix []byte
-	var tzPrefix string
-	if strings.HasPrefix(input, "tz1") {
-		prefix = []byte{6, 161, 159}
-		tzPrefix = "00"
-	} else if strings.HasPrefix(input, "tz2") {
-		prefix = []byte{6, 161, 161}
-		tzPrefix = "01"
-	} else if strings.HasPrefix(input, "tz3") {
-		prefix = []byte{6, 161, 164}
-		tzPrefix = "02"
+	packed:
+		The packed data, starting with 0x05.
+*/
+func ForgeScriptExpression(packed []byte) (ScriptExpression, error) {
+	if len(packed) == 0 || packed[0] != 0x05 {
+		return "", errors.New("failed to forge script expression: data is not packed")
 	}
 
-	dInput, err := crypto.Decode(input)
+	hash := blake2b.Sum256(packed)
+	return ScriptExpression(b58.MustEncode(b58.ScriptExpr, hash[:])), nil
+}
+
+func pack(input string) (string, error) {
+	addr, err := address.Parse(input)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to pack script expression")
 	}
-	bytes := fmt.Sprintf("00%s%s", tzPrefix, hex.EncodeToString(dInput[len(prefix):]))
+
+	bytes := hex.EncodeToString(addr.ContractBytes())
 	bytesHalfLen := len(bytes) / 2
 
 	out := "050a"
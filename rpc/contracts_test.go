@@ -102,7 +102,93 @@ func Test_ContractStorage(t *testing.T) {
 }
 
 func Test_ForgeScriptExpressionForAddress(t *testing.T) {
-	val, err := ForgeScriptExpressionForAddress(`tz1S82rGFZK8cVbNDpP1Hf9VhTUa4W8oc2WV`)
-	checkErr(t, false, "", err)
-	assert.Equal(t, ScriptExpression("expru1LH1CafV3yYgs9BkbrMWWfAE9ye3RdWwyndr9MKYN8w5VQ7Rt"), val)
+	type want struct {
+		err         bool
+		errContains string
+		val         ScriptExpression
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want  want
+	}{
+		{
+			"handles tz1 address",
+			"tz1S82rGFZK8cVbNDpP1Hf9VhTUa4W8oc2WV",
+			want{
+				false,
+				"",
+				ScriptExpression("expru1LH1CafV3yYgs9BkbrMWWfAE9ye3RdWwyndr9MKYN8w5VQ7Rt"),
+			},
+		},
+		{
+			"handles KT1 address",
+			"KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9",
+			want{
+				false,
+				"",
+				ScriptExpression("expruuuG5UL8yh4Pth2rpi6FoJLYGkdB5vWga7KwfeMfymReg4WZPV"),
+			},
+		},
+		{
+			"handles invalid checksum",
+			"tz1S82rGFZK8cVbNDpP1Hf9VhTUa4W8oc2WW",
+			want{
+				true,
+				"failed to forge script expression for address",
+				"",
+			},
+		},
+		{
+			"handles unknown prefix",
+			"abc",
+			want{
+				true,
+				"unknown prefix",
+				"",
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			val, err := ForgeScriptExpressionForAddress(tt.input)
+			checkErr(t, tt.want.err, tt.want.errContains, err)
+			assert.Equal(t, tt.want.val, val)
+		})
+	}
+}
+
+func Test_ForgeScriptExpression(t *testing.T) {
+	type want struct {
+		err         bool
+		errContains string
+		val         ScriptExpression
+	}
+
+	cases := []struct {
+		name  string
+		input []byte
+		want  want
+	}{
+		{
+			"handles packed string",
+			[]byte{0x05, 0x01, 0x00, 0x00, 0x00, 0x03, 'f', 'o', 'o'},
+			want{false, "", ScriptExpression("expruTFUPVsqkuD5iwLMJuzoyGSFABnxLo7CZrgnS1czt1WbTwpVrJ")},
+		},
+		{
+			"handles unpacked data",
+			[]byte{0x01, 0x00, 0x00, 0x00, 0x00},
+			want{true, "data is not packed", ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			val, err := ForgeScriptExpression(tt.input)
+			checkErr(t, tt.want.err, tt.want.errContains, err)
+			assert.Equal(t, tt.want.val, val)
+		})
+	}
 }
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -319,18 +320,23 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-block-id-context-delegates-pkh-delegated-contracts
 */
 func (c *Client) DelegatedContracts(input DelegatedContractsInput) ([]string, error) {
+	return c.DelegatedContractsContext(context.Background(), input)
+}
+
+// DelegatedContractsContext is like DelegatedContracts but uses ctx for its requests to the node.
+func (c *Client) DelegatedContractsContext(ctx context.Context, input DelegatedContractsInput) ([]string, error) {
 	err := input.validate()
 	if err != nil {
 		return []string{}, errors.Wrapf(err, "could not get delegations for delegate '%s'", input.Delegate)
 	}
 
-	input.Blockhash, err = c.extractBlockHash(input.Cycle, input.Blockhash)
+	input.Blockhash, err = c.extractBlockHash(ctx, input.Cycle, input.Blockhash)
 	if err != nil {
 		return []string{}, errors.Wrapf(err, "could not get delegations for delegate '%s'", input.Delegate)
 	}
 
 	var resp []byte
-	resp, err = c.get(fmt.Sprintf("/chains/%s/blocks/%s/context/delegates/%s/delegated_contracts", c.chain, input.Blockhash, input.Delegate))
+	resp, err = c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/context/delegates/%s/delegated_contracts", c.chain, input.Blockhash, input.Delegate))
 	if err != nil {
 		return []string{}, errors.Wrapf(err, "could not get delegations for delegate '%s'", input.Delegate)
 	}
@@ -353,18 +359,23 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-block-id-context-delegates-pkh-frozen-balance
 */
 func (c *Client) FrozenBalance(input FrozenBalanceInput) (FrozenBalance, error) {
+	return c.FrozenBalanceContext(context.Background(), input)
+}
+
+// FrozenBalanceContext is like FrozenBalance but uses ctx for its requests to the node.
+func (c *Client) FrozenBalanceContext(ctx context.Context, input FrozenBalanceInput) (FrozenBalance, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return FrozenBalance{}, errors.Wrap(err, "invalid input")
 	}
 
 	level := (input.Cycle+1)*(c.networkConstants.BlocksPerCycle) + 1
-	head, err := c.Block(level)
+	head, err := c.BlockContext(ctx, level)
 	if err != nil {
 		return FrozenBalance{}, errors.Wrapf(err, "failed to get frozen balance at cycle '%d' for delegate '%s'", input.Cycle, input.Delegate)
 	}
 
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/%s/context/raw/json/contracts/index/%s/frozen_balance/%d", c.chain, head.Hash, input.Delegate, input.Cycle))
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/context/raw/json/contracts/index/%s/frozen_balance/%d", c.chain, head.Hash, input.Delegate, input.Cycle))
 	if err != nil {
 		return FrozenBalance{}, errors.Wrapf(err, "failed to get frozen balance for delegate '%s'", input.Delegate)
 	}
@@ -388,17 +399,22 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-block-id-context-delegates-pkh
 */
 func (c *Client) Delegate(input DelegateInput) (Delegate, error) {
+	return c.DelegateContext(context.Background(), input)
+}
+
+// DelegateContext is like Delegate but uses ctx for its requests to the node.
+func (c *Client) DelegateContext(ctx context.Context, input DelegateInput) (Delegate, error) {
 	err := input.validate()
 	if err != nil {
 		return Delegate{}, errors.Wrapf(err, "could not get delegate '%s'", input.Delegate)
 	}
 
-	input.Blockhash, err = c.extractBlockHash(input.Cycle, input.Blockhash)
+	input.Blockhash, err = c.extractBlockHash(ctx, input.Cycle, input.Blockhash)
 	if err != nil {
 		return Delegate{}, errors.Wrapf(err, "could not get delegate '%s'", input.Delegate)
 	}
 
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/%s/context/delegates/%s", c.chain, input.Blockhash, input.Delegate))
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/context/delegates/%s", c.chain, input.Blockhash, input.Delegate))
 	if err != nil {
 		return Delegate{}, errors.Wrapf(err, "could not get delegate '%s'", input.Delegate)
 	}
@@ -429,18 +445,23 @@ Parameters:
 		Modifies the StakingBalance RPC query by passing optional parameters. Delegate and (Cycle or Blockhash) is required.
 */
 func (c *Client) StakingBalance(input StakingBalanceInput) (int, error) {
+	return c.StakingBalanceContext(context.Background(), input)
+}
+
+// StakingBalanceContext is like StakingBalance but uses ctx for its requests to the node.
+func (c *Client) StakingBalanceContext(ctx context.Context, input StakingBalanceInput) (int, error) {
 	err := input.validate()
 	if err != nil {
 		return 0, errors.Wrapf(err, "could not get staking balance for '%s'", input.Delegate)
 	}
 
-	input.Blockhash, err = c.extractBlockHash(input.Cycle, input.Blockhash)
+	input.Blockhash, err = c.extractBlockHash(ctx, input.Cycle, input.Blockhash)
 	if err != nil {
 		return 0, errors.Wrapf(err, "could not get staking balance for '%s'", input.Delegate)
 	}
 
 	var resp []byte
-	resp, err = c.get(fmt.Sprintf("/chains/%s/blocks/%s/context/delegates/%s/staking_balance", c.chain, input.Blockhash, input.Delegate))
+	resp, err = c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/context/delegates/%s/staking_balance", c.chain, input.Blockhash, input.Delegate))
 	if err != nil {
 		return 0, errors.Wrapf(err, "could not get staking balance for '%s'", input.Delegate)
 	}
@@ -473,12 +494,17 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-block-id-helpers-baking-rights
 */
 func (c *Client) BakingRights(input BakingRightsInput) (*BakingRights, error) {
+	return c.BakingRightsContext(context.Background(), input)
+}
+
+// BakingRightsContext is like BakingRights but uses ctx for its requests to the node.
+func (c *Client) BakingRightsContext(ctx context.Context, input BakingRightsInput) (*BakingRights, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return &BakingRights{}, errors.Wrap(err, "invalid input")
 	}
 
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/%s/helpers/baking_rights", c.chain, input.BlockHash), input.contructRPCOptions()...)
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/helpers/baking_rights", c.chain, input.BlockHash), input.contructRPCOptions()...)
 	if err != nil {
 		return &BakingRights{}, errors.Wrapf(err, "could not get baking rights")
 	}
@@ -543,12 +569,17 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-block-id-helpers-endorsing-rights
 */
 func (c *Client) EndorsingRights(input EndorsingRightsInput) (*EndorsingRights, error) {
+	return c.EndorsingRightsContext(context.Background(), input)
+}
+
+// EndorsingRightsContext is like EndorsingRights but uses ctx for its requests to the node.
+func (c *Client) EndorsingRightsContext(ctx context.Context, input EndorsingRightsInput) (*EndorsingRights, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return &EndorsingRights{}, errors.Wrap(err, "invalid input")
 	}
 
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/%s/helpers/endorsing_rights", c.chain, input.BlockHash), input.contructRPCOptions()...)
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/helpers/endorsing_rights", c.chain, input.BlockHash), input.contructRPCOptions()...)
 	if err != nil {
 		return &EndorsingRights{}, errors.Wrap(err, "could not get endorsing rights")
 	}
@@ -598,17 +629,22 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-block-id-context-delegates
 */
 func (c *Client) Delegates(input DelegatesInput) ([]string, error) {
+	return c.DelegatesContext(context.Background(), input)
+}
+
+// DelegatesContext is like Delegates but uses ctx for its requests to the node.
+func (c *Client) DelegatesContext(ctx context.Context, input DelegatesInput) ([]string, error) {
 	err := input.validate()
 	if err != nil {
 		return []string{}, errors.Wrap(err, "could not get delegates")
 	}
 
-	input.Blockhash, err = c.extractBlockHash(input.Cycle, input.Blockhash)
+	input.Blockhash, err = c.extractBlockHash(ctx, input.Cycle, input.Blockhash)
 	if err != nil {
 		return []string{}, errors.Wrap(err, "could not get delegates")
 	}
 
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/%s/context/delegates", c.chain, input.Blockhash), input.contructRPCOptions()...)
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/context/delegates", c.chain, input.Blockhash), input.contructRPCOptions()...)
 	if err != nil {
 		return []string{}, errors.Wrap(err, "could not get delegates")
 	}
@@ -651,9 +687,9 @@ func (d *DelegatesInput) contructRPCOptions() []rpcOptions {
 	return opts
 }
 
-func (c *Client) extractBlockHash(cycle int, blockhash string) (string, error) {
+func (c *Client) extractBlockHash(ctx context.Context, cycle int, blockhash string) (string, error) {
 	if cycle != 0 {
-		snapshot, err := c.Cycle(cycle)
+		snapshot, err := c.CycleContext(ctx, cycle)
 		if err != nil {
 			return "", errors.Wrapf(err, "failed to get cycle: %d", cycle)
 		}
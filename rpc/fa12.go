@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"regexp"
 	"strconv"
@@ -242,17 +243,22 @@ that calls an intermediary contract which calls the FA1.2 contract and parses th
 See: https://gitlab.com/camlcase-dev/dexter-integration/-/blob/master/call_fa1.2_view_entrypoints.md
 */
 func (c *Client) GetFA12Balance(input GetFA12BalanceInput) (string, error) {
+	return c.GetFA12BalanceContext(context.Background(), input)
+}
+
+// GetFA12BalanceContext is like GetFA12Balance but uses ctx for its requests to the node.
+func (c *Client) GetFA12BalanceContext(ctx context.Context, input GetFA12BalanceInput) (string, error) {
 	err := input.validate()
 	if err != nil {
 		return "0", errors.Wrapf(err, "could not get fa1.2 balance for '%s' in contract '%s'", input.OwnerAddress, input.FA12Contract)
 	}
 
-	input.Blockhash, err = c.extractBlockHash(input.Cycle, input.Blockhash)
+	input.Blockhash, err = c.extractBlockHash(ctx, input.Cycle, input.Blockhash)
 	if err != nil {
 		return "0", errors.Wrapf(err, "could not get fa1.2 balance for '%s' in contract '%s'", input.OwnerAddress, input.FA12Contract)
 	}
 
-	counter, err := c.Counter(CounterInput{
+	counter, err := c.CounterContext(ctx, CounterInput{
 		input.Blockhash,
 		input.Source,
 	})
@@ -286,7 +292,7 @@ func (c *Client) GetFA12Balance(input GetFA12BalanceInput) (string, error) {
 		},
 	}
 
-	operation, err := c.RunOperation(RunOperationInput{
+	operation, err := c.RunOperationContext(ctx, RunOperationInput{
 		Blockhash: input.Blockhash,
 		Operation: RunOperation{
 			Operation: Operations{
@@ -320,17 +326,22 @@ See: https://gitlab.com/camlcase-dev/dexter-integration/-/blob/master/call_fa1.2
 
 */
 func (c *Client) GetFA12Supply(input GetFA12SupplyInput) (string, error) {
+	return c.GetFA12SupplyContext(context.Background(), input)
+}
+
+// GetFA12SupplyContext is like GetFA12Supply but uses ctx for its requests to the node.
+func (c *Client) GetFA12SupplyContext(ctx context.Context, input GetFA12SupplyInput) (string, error) {
 	err := input.validate()
 	if err != nil {
 		return "0", errors.Wrapf(err, "could not get fa1.2 supply for contract '%s'", input.FA12Contract)
 	}
 
-	input.Blockhash, err = c.extractBlockHash(input.Cycle, input.Blockhash)
+	input.Blockhash, err = c.extractBlockHash(ctx, input.Cycle, input.Blockhash)
 	if err != nil {
 		return "0", errors.Wrapf(err, "could not get fa1.2 supply for contract '%s'", input.FA12Contract)
 	}
 
-	counter, err := c.Counter(CounterInput{
+	counter, err := c.CounterContext(ctx, CounterInput{
 		input.Blockhash,
 		input.Source,
 	})
@@ -364,7 +375,7 @@ func (c *Client) GetFA12Supply(input GetFA12SupplyInput) (string, error) {
 		},
 	}
 
-	operation, err := c.RunOperation(RunOperationInput{
+	operation, err := c.RunOperationContext(ctx, RunOperationInput{
 		Blockhash: input.Blockhash,
 		Operation: RunOperation{
 			Operation: Operations{
@@ -391,17 +402,22 @@ that calls an intermediary contract which calls the FA1.2 contract and parses th
 See: https://gitlab.com/camlcase-dev/dexter-integration/-/blob/master/call_fa1.2_view_entrypoints.md
 */
 func (c *Client) GetFA12Allowance(input GetFA12AllowanceInput) (string, error) {
+	return c.GetFA12AllowanceContext(context.Background(), input)
+}
+
+// GetFA12AllowanceContext is like GetFA12Allowance but uses ctx for its requests to the node.
+func (c *Client) GetFA12AllowanceContext(ctx context.Context, input GetFA12AllowanceInput) (string, error) {
 	err := input.validate()
 	if err != nil {
 		return "0", errors.Wrapf(err, "could not get fa1.2 balance for '%s' in contract '%s'", input.OwnerAddress, input.FA12Contract)
 	}
 
-	input.Blockhash, err = c.extractBlockHash(input.Cycle, input.Blockhash)
+	input.Blockhash, err = c.extractBlockHash(ctx, input.Cycle, input.Blockhash)
 	if err != nil {
 		return "0", errors.Wrapf(err, "could not get fa1.2 balance for '%s' in contract '%s'", input.OwnerAddress, input.FA12Contract)
 	}
 
-	counter, err := c.Counter(CounterInput{
+	counter, err := c.CounterContext(ctx, CounterInput{
 		input.Blockhash,
 		input.Source,
 	})
@@ -435,7 +451,7 @@ func (c *Client) GetFA12Allowance(input GetFA12AllowanceInput) (string, error) {
 		},
 	}
 
-	operation, err := c.RunOperation(RunOperationInput{
+	operation, err := c.RunOperationContext(ctx, RunOperationInput{
 		Blockhash: input.Blockhash,
 		Operation: RunOperation{
 			Operation: Operations{
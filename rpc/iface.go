@@ -1,48 +1,111 @@
 package rpc
 
+import "context"
+
 // IFace is an interface mocking a GoTezos object.
 type IFace interface {
 	ActiveChains() (ActiveChains, error)
+	ActiveChainsContext(ctx context.Context) (ActiveChains, error)
 	BakingRights(input BakingRightsInput) (*BakingRights, error)
+	BakingRightsContext(ctx context.Context, input BakingRightsInput) (*BakingRights, error)
 	Balance(input BalanceInput) (string, error)
+	BalanceContext(ctx context.Context, input BalanceInput) (string, error)
 	BallotList(blockhash string) (BallotList, error)
+	BallotListContext(ctx context.Context, blockhash string) (BallotList, error)
 	Ballots(blockhash string) (Ballots, error)
+	BallotsContext(ctx context.Context, blockhash string) (Ballots, error)
+	BanOperation(operationHash string) error
+	BanOperationContext(ctx context.Context, operationHash string) error
 	BigMap(input BigMapInput) ([]byte, error)
+	BigMapContext(ctx context.Context, input BigMapInput) ([]byte, error)
 	Block(id interface{}) (*Block, error)
+	BlockContext(ctx context.Context, id interface{}) (*Block, error)
 	Blocks(input BlocksInput) ([][]string, error)
+	BlocksContext(ctx context.Context, input BlocksInput) ([][]string, error)
 	Bootstrap() (Bootstrap, error)
+	BootstrapContext(ctx context.Context) (Bootstrap, error)
 	ChainID() (string, error)
+	ChainIDContext(ctx context.Context) (string, error)
 	Checkpoint() (Checkpoint, error)
+	CheckpointContext(ctx context.Context) (Checkpoint, error)
 	Commit() (string, error)
+	CommitContext(ctx context.Context) (string, error)
 	Connections() (Connections, error)
+	ConnectionsContext(ctx context.Context) (Connections, error)
 	Constants(blockhash string) (Constants, error)
+	ConstantsContext(ctx context.Context, blockhash string) (Constants, error)
 	ContractStorage(input ContractStorageInput) ([]byte, error)
+	ContractStorageContext(ctx context.Context, input ContractStorageInput) ([]byte, error)
 	Counter(input CounterInput) (int, error)
+	CounterContext(ctx context.Context, input CounterInput) (int, error)
 	CurrentPeriodKind(blockhash string) (string, error)
+	CurrentPeriodKindContext(ctx context.Context, blockhash string) (string, error)
 	CurrentProposal(blockhash string) (string, error)
+	CurrentProposalContext(ctx context.Context, blockhash string) (string, error)
 	CurrentQuorum(blockhash string) (int, error)
+	CurrentQuorumContext(ctx context.Context, blockhash string) (int, error)
 	Cycle(cycle int) (Cycle, error)
+	CycleContext(ctx context.Context, cycle int) (Cycle, error)
 	Delegate(input DelegateInput) (Delegate, error)
+	DelegateContext(ctx context.Context, input DelegateInput) (Delegate, error)
 	Delegates(input DelegatesInput) ([]string, error)
+	DelegatesContext(ctx context.Context, input DelegatesInput) ([]string, error)
 	DelegatedContracts(input DelegatedContractsInput) ([]string, error)
+	DelegatedContractsContext(ctx context.Context, input DelegatedContractsInput) ([]string, error)
 	DeleteInvalidBlock(blockHash string) error
+	DeleteInvalidBlockContext(ctx context.Context, blockHash string) error
 	EndorsingRights(input EndorsingRightsInput) (*EndorsingRights, error)
+	EndorsingRightsContext(ctx context.Context, input EndorsingRightsInput) (*EndorsingRights, error)
 	ForgeOperation(input ForgeOperationInput) (string, error)
+	ForgeOperationContext(ctx context.Context, input ForgeOperationInput) (string, error)
 	GetFA12Allowance(input GetFA12AllowanceInput) (string, error)
+	GetFA12AllowanceContext(ctx context.Context, input GetFA12AllowanceInput) (string, error)
 	FrozenBalance(input FrozenBalanceInput) (FrozenBalance, error)
+	FrozenBalanceContext(ctx context.Context, input FrozenBalanceInput) (FrozenBalance, error)
 	GetFA12Balance(input GetFA12BalanceInput) (string, error)
+	GetFA12BalanceContext(ctx context.Context, input GetFA12BalanceInput) (string, error)
 	GetFA12Supply(input GetFA12SupplyInput) (string, error)
+	GetFA12SupplyContext(ctx context.Context, input GetFA12SupplyInput) (string, error)
 	Head() (*Block, error)
+	HeadContext(ctx context.Context) (*Block, error)
 	InjectionBlock(input InjectionBlockInput) ([]byte, error)
+	InjectionBlockContext(ctx context.Context, input InjectionBlockInput) ([]byte, error)
 	InjectionOperation(input InjectionOperationInput) (string, error)
+	InjectionOperationContext(ctx context.Context, input InjectionOperationInput) (string, error)
 	InvalidBlock(blockHash string) (InvalidBlock, error)
+	InvalidBlockContext(ctx context.Context, blockHash string) (InvalidBlock, error)
 	InvalidBlocks() ([]InvalidBlock, error)
+	InvalidBlocksContext(ctx context.Context) ([]InvalidBlock, error)
+	IsBootstrapped() (Bootstrapped, error)
+	IsBootstrappedContext(ctx context.Context) (Bootstrapped, error)
+	MempoolFilter() (MempoolFilter, error)
+	MempoolFilterContext(ctx context.Context) (MempoolFilter, error)
 	OperationHashes(blockhash string) ([][]string, error)
+	OperationHashesContext(ctx context.Context, blockhash string) ([][]string, error)
+	PendingOperations(input PendingOperationsInput) (PendingOperations, error)
+	PendingOperationsContext(ctx context.Context, input PendingOperationsInput) (PendingOperations, error)
 	PreapplyOperations(input PreapplyOperationsInput) ([]Operations, error)
+	PreapplyOperationsContext(ctx context.Context, input PreapplyOperationsInput) ([]Operations, error)
 	Proposals(blockhash string) (Proposals, error)
+	ProposalsContext(ctx context.Context, blockhash string) (Proposals, error)
+	RequestOperations(input RequestOperationsInput) error
+	RequestOperationsContext(ctx context.Context, input RequestOperationsInput) error
 	RunOperation(input RunOperationInput) (Operations, error)
+	RunOperationContext(ctx context.Context, input RunOperationInput) (Operations, error)
+	SetMempoolFilter(filter MempoolFilter) error
+	SetMempoolFilterContext(ctx context.Context, filter MempoolFilter) error
 	StakingBalance(input StakingBalanceInput) (int, error)
+	StakingBalanceContext(ctx context.Context, input StakingBalanceInput) (int, error)
+	UnbanAllOperations() error
+	UnbanAllOperationsContext(ctx context.Context) error
+	UnbanOperation(operationHash string) error
+	UnbanOperationContext(ctx context.Context, operationHash string) error
 	UnforgeOperation(input UnforgeOperationInput) ([]Operations, error)
+	UnforgeOperationContext(ctx context.Context, input UnforgeOperationInput) ([]Operations, error)
 	UserActivatedProtocolOverrides() (UserActivatedProtocolOverrides, error)
+	UserActivatedProtocolOverridesContext(ctx context.Context) (UserActivatedProtocolOverrides, error)
 	Version() (Version, error)
+	VersionContext(ctx context.Context) (Version, error)
+	VoteListings(blockhash string) (Listings, error)
+	VoteListingsContext(ctx context.Context, blockhash string) (Listings, error)
 }
@@ -0,0 +1,523 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+/*
+PendingOperations represents the operations in the mempool of a node by classification.
+
+Note:
+	Applied operations are called validated from Lima, they are both decoded into Applied.
+
+RPC:
+	/chains/<chain_id>/mempool/pending_operations (GET)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-chains-chain-id-mempool-pending-operations
+*/
+type PendingOperations struct {
+	Applied       []MempoolOperation `json:"applied"`
+	Refused       []MempoolOperation `json:"refused"`
+	Outdated      []MempoolOperation `json:"outdated"`
+	BranchRefused []MempoolOperation `json:"branch_refused"`
+	BranchDelayed []MempoolOperation `json:"branch_delayed"`
+	Unprocessed   []MempoolOperation `json:"unprocessed"`
+}
+
+/*
+MempoolFilter represents the configuration of the mempool filter of a node.
+
+RPC:
+	/chains/<chain_id>/mempool/filter (GET/POST)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-chains-chain-id-mempool-filter
+*/
+type MempoolFilter struct {
+	MinimalFees                    string   `json:"minimal_fees,omitempty"`
+	MinimalNanotezPerGasUnit       []string `json:"minimal_nanotez_per_gas_unit,omitempty"`
+	MinimalNanotezPerByte          []string `json:"minimal_nanotez_per_byte,omitempty"`
+	AllowScriptFailure             *bool    `json:"allow_script_failure,omitempty"`
+	ClockDrift                     string   `json:"clock_drift,omitempty"`
+	ReplaceByFeeFactor             []string `json:"replace_by_fee_factor,omitempty"`
+	MaxPrecheckedManagerOperations int      `json:"max_prechecked_manager_operations,omitempty"`
+}
+
+/*
+MempoolOperation represents an operation in the mempool of a node.
+
+Note:
+	Error is set for the operations that the node didn't apply, e.g. refused or outdated operations.
+
+RPC:
+	/chains/<chain_id>/mempool/monitor_operations (GET)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-chains-chain-id-mempool-monitor-operations
+*/
+type MempoolOperation struct {
+	Protocol  string        `json:"protocol,omitempty"`
+	Hash      string        `json:"hash,omitempty"`
+	Branch    string        `json:"branch"`
+	Contents  Contents      `json:"contents"`
+	Signature string        `json:"signature,omitempty"`
+	Error     []ResultError `json:"error,omitempty"`
+}
+
+/*
+MonitorMempoolOperationsInput is the input for the MonitorMempoolOperations function.
+
+Note:
+	The classifications left nil use the defaults of the node. Applied was renamed Validated in Lima, set the one the
+	node supports.
+
+Function:
+	func (c *Client) MonitorMempoolOperations(ctx context.Context, input MonitorMempoolOperationsInput) (<-chan []MempoolOperation, <-chan error) {}
+*/
+type MonitorMempoolOperationsInput struct {
+	Applied       *bool
+	Validated     *bool
+	Refused       *bool
+	Outdated      *bool
+	BranchRefused *bool
+	BranchDelayed *bool
+}
+
+func (m *MonitorMempoolOperationsInput) contructRPCOptions() []rpcOptions {
+	return classificationOptions(m.Applied, m.Validated, m.Refused, m.Outdated, m.BranchRefused, m.BranchDelayed)
+}
+
+/*
+PendingOperationsInput is the input for the PendingOperations function.
+
+Note:
+	The classifications left nil use the defaults of the node. Applied was renamed Validated in Lima, set the one the
+	node supports.
+
+Function:
+	func (c *Client) PendingOperations(input PendingOperationsInput) (PendingOperations, error) {}
+*/
+type PendingOperationsInput struct {
+	// Version is the version of the response format, the default of the node if 0.
+	Version       int
+	Applied       *bool
+	Validated     *bool
+	Refused       *bool
+	Outdated      *bool
+	BranchRefused *bool
+	BranchDelayed *bool
+}
+
+func (p *PendingOperationsInput) contructRPCOptions() []rpcOptions {
+	var opts []rpcOptions
+	if p.Version != 0 {
+		opts = append(opts, rpcOptions{
+			"version",
+			strconv.Itoa(p.Version),
+		})
+	}
+
+	return append(opts, classificationOptions(p.Applied, p.Validated, p.Refused, p.Outdated, p.BranchRefused, p.BranchDelayed)...)
+}
+
+/*
+RequestOperationsInput is the input for the RequestOperations function.
+
+Function:
+	func (c *Client) RequestOperations(input RequestOperationsInput) error {}
+*/
+type RequestOperationsInput struct {
+	// PeerID is the peer to request the operations from, all the peers if empty.
+	PeerID string
+}
+
+func (r *RequestOperationsInput) contructRPCOptions() []rpcOptions {
+	var opts []rpcOptions
+	if r.PeerID != "" {
+		opts = append(opts, rpcOptions{
+			"peer_id",
+			r.PeerID,
+		})
+	}
+
+	return opts
+}
+
+func classificationOptions(applied, validated, refused, outdated, branchRefused, branchDelayed *bool) []rpcOptions {
+	var opts []rpcOptions
+	for _, classification := range []struct {
+		key   string
+		value *bool
+	}{
+		{"applied", applied},
+		{"validated", validated},
+		{"refused", refused},
+		{"outdated", outdated},
+		{"branch_refused", branchRefused},
+		{"branch_delayed", branchDelayed},
+	} {
+		if classification.value != nil {
+			opts = append(opts, rpcOptions{
+				classification.key,
+				strconv.FormatBool(*classification.value),
+			})
+		}
+	}
+
+	return opts
+}
+
+/*
+MonitorMempoolOperations streams the operations of the mempool of the node as they are classified, starting with the
+operations already in the mempool. The node closes the stream when the head changes, after which it is reconnected.
+
+Note:
+	The stream reconnects with a backoff when it fails, sending the error on the error channel, which buffers the last
+	errors and drops older ones, so it does not need to be received from. Both channels are closed when ctx is done.
+
+Path:
+	/chains/<chain_id>/mempool/monitor_operations (GET)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-chains-chain-id-mempool-monitor-operations
+
+Parameters:
+
+	ctx:
+		The context of the stream, which is stopped when it is done.
+
+	input:
+		Modifies the MonitorMempoolOperations function.
+*/
+func (c *Client) MonitorMempoolOperations(ctx context.Context, input MonitorMempoolOperationsInput) (<-chan []MempoolOperation, <-chan error) {
+	operations := make(chan []MempoolOperation)
+	errs := make(chan error, monitorErrors)
+
+	go func() {
+		defer close(operations)
+		c.monitor(ctx, fmt.Sprintf("/chains/%s/mempool/monitor_operations", c.chain), endRestarts, errs, func(dec *json.Decoder) error {
+			var ops []MempoolOperation
+			if err := dec.Decode(&ops); err != nil {
+				return err
+			}
+
+			select {
+			case operations <- ops:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}, input.contructRPCOptions()...)
+	}()
+
+	return operations, errs
+}
+
+/*
+UnmarshalJSON satisfies the json.Unmarshaler interface for MempoolOperation, which is either an operation with its
+hash or a [hash, operation] pair in the responses of older nodes.
+*/
+func (m *MempoolOperation) UnmarshalJSON(v []byte) error {
+	type mempoolOperation MempoolOperation
+	var op mempoolOperation
+
+	if v = bytes.TrimSpace(v); len(v) == 0 || v[0] != '[' {
+		if err := json.Unmarshal(v, &op); err != nil {
+			return err
+		}
+		*m = MempoolOperation(op)
+		return nil
+	}
+
+	var pair []json.RawMessage
+	if err := json.Unmarshal(v, &pair); err != nil {
+		return err
+	}
+
+	if len(pair) != 2 {
+		return errors.Errorf("invalid mempool operation: expected [hash, operation] got %d elements", len(pair))
+	}
+
+	if err := json.Unmarshal(pair[1], &op); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(pair[0], &op.Hash); err != nil {
+		return err
+	}
+
+	*m = MempoolOperation(op)
+	return nil
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface for PendingOperations.
+func (p *PendingOperations) UnmarshalJSON(v []byte) error {
+	type pendingOperations PendingOperations
+	var ops struct {
+		pendingOperations
+		Validated []MempoolOperation `json:"validated"`
+	}
+
+	if err := json.Unmarshal(v, &ops); err != nil {
+		return err
+	}
+
+	*p = PendingOperations(ops.pendingOperations)
+	p.Applied = append(p.Applied, ops.Validated...)
+	return nil
+}
+
+/*
+Operation finds an operation in the mempool and returns it with its classification, i.e. "applied", "refused",
+"outdated", "branch_refused", "branch_delayed" or "unprocessed".
+
+Parameters:
+
+	operationHash:
+		The hash of the operation, e.g. the result of InjectionOperation.
+*/
+func (p *PendingOperations) Operation(operationHash string) (MempoolOperation, string, bool) {
+	for _, classification := range []struct {
+		name       string
+		operations []MempoolOperation
+	}{
+		{"applied", p.Applied},
+		{"refused", p.Refused},
+		{"outdated", p.Outdated},
+		{"branch_refused", p.BranchRefused},
+		{"branch_delayed", p.BranchDelayed},
+		{"unprocessed", p.Unprocessed},
+	} {
+		for _, op := range classification.operations {
+			if op.Hash == operationHash {
+				return op, classification.name, true
+			}
+		}
+	}
+
+	return MempoolOperation{}, "", false
+}
+
+/*
+PendingOperations gets the operations in the mempool of the node by classification, with the errors of the operations
+the node didn't apply.
+
+Path:
+	/chains/<chain_id>/mempool/pending_operations (GET)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-chains-chain-id-mempool-pending-operations
+
+Parameters:
+
+	input:
+		Modifies the PendingOperations function.
+*/
+func (c *Client) PendingOperations(input PendingOperationsInput) (PendingOperations, error) {
+	return c.PendingOperationsContext(context.Background(), input)
+}
+
+// PendingOperationsContext is like PendingOperations but uses ctx for its requests to the node.
+func (c *Client) PendingOperationsContext(ctx context.Context, input PendingOperationsInput) (PendingOperations, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/mempool/pending_operations", c.chain), input.contructRPCOptions()...)
+	if err != nil {
+		return PendingOperations{}, errors.Wrap(err, "failed to get pending operations")
+	}
+
+	var pendingOperations PendingOperations
+	err = json.Unmarshal(resp, &pendingOperations)
+	if err != nil {
+		return PendingOperations{}, errors.Wrap(err, "failed to unmarshal pending operations")
+	}
+
+	return pendingOperations, nil
+}
+
+/*
+MempoolFilter gets the configuration of the mempool filter of the node.
+
+Path:
+	/chains/<chain_id>/mempool/filter (GET)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-chains-chain-id-mempool-filter
+*/
+func (c *Client) MempoolFilter() (MempoolFilter, error) {
+	return c.MempoolFilterContext(context.Background())
+}
+
+// MempoolFilterContext is like MempoolFilter but uses ctx for its requests to the node.
+func (c *Client) MempoolFilterContext(ctx context.Context) (MempoolFilter, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/mempool/filter", c.chain))
+	if err != nil {
+		return MempoolFilter{}, errors.Wrap(err, "failed to get mempool filter")
+	}
+
+	var filter MempoolFilter
+	err = json.Unmarshal(resp, &filter)
+	if err != nil {
+		return MempoolFilter{}, errors.Wrap(err, "failed to unmarshal mempool filter")
+	}
+
+	return filter, nil
+}
+
+/*
+SetMempoolFilter sets the configuration of the mempool filter of the node.
+
+Note:
+	The fields left empty are set to their default value.
+
+Path:
+	/chains/<chain_id>/mempool/filter (POST)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#post-chains-chain-id-mempool-filter
+
+Parameters:
+
+	filter:
+		The configuration of the mempool filter.
+*/
+func (c *Client) SetMempoolFilter(filter MempoolFilter) error {
+	return c.SetMempoolFilterContext(context.Background(), filter)
+}
+
+// SetMempoolFilterContext is like SetMempoolFilter but uses ctx for its requests to the node.
+func (c *Client) SetMempoolFilterContext(ctx context.Context, filter MempoolFilter) error {
+	v, err := json.Marshal(filter)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal mempool filter")
+	}
+
+	_, err = c.post(ctx, fmt.Sprintf("/chains/%s/mempool/filter", c.chain), v)
+	if err != nil {
+		return errors.Wrap(err, "failed to set mempool filter")
+	}
+
+	return nil
+}
+
+/*
+RequestOperations requests the operations in the mempools of the peers of the node.
+
+Path:
+	/chains/<chain_id>/mempool/request_operations (POST)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#post-chains-chain-id-mempool-request-operations
+
+Parameters:
+
+	input:
+		Modifies the RequestOperations function.
+*/
+func (c *Client) RequestOperations(input RequestOperationsInput) error {
+	return c.RequestOperationsContext(context.Background(), input)
+}
+
+// RequestOperationsContext is like RequestOperations but uses ctx for its requests to the node.
+func (c *Client) RequestOperationsContext(ctx context.Context, input RequestOperationsInput) error {
+	_, err := c.post(ctx, fmt.Sprintf("/chains/%s/mempool/request_operations", c.chain), []byte("{}"), input.contructRPCOptions()...)
+	if err != nil {
+		return errors.Wrap(err, "failed to request operations")
+	}
+
+	return nil
+}
+
+/*
+BanOperation removes an operation from the mempool of the node and bans it, it is then ignored if received again.
+
+Path:
+	/chains/<chain_id>/mempool/ban_operation (POST)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#post-chains-chain-id-mempool-ban-operation
+
+Parameters:
+
+	operationHash:
+		The hash of the operation to ban.
+*/
+func (c *Client) BanOperation(operationHash string) error {
+	return c.BanOperationContext(context.Background(), operationHash)
+}
+
+// BanOperationContext is like BanOperation but uses ctx for its requests to the node.
+func (c *Client) BanOperationContext(ctx context.Context, operationHash string) error {
+	v, err := json.Marshal(operationHash)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ban operation '%s'", operationHash)
+	}
+
+	_, err = c.post(ctx, fmt.Sprintf("/chains/%s/mempool/ban_operation", c.chain), v)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ban operation '%s'", operationHash)
+	}
+
+	return nil
+}
+
+/*
+UnbanOperation removes an operation from the operations banned by the node.
+
+Path:
+	/chains/<chain_id>/mempool/unban_operation (POST)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#post-chains-chain-id-mempool-unban-operation
+
+Parameters:
+
+	operationHash:
+		The hash of the operation to unban.
+*/
+func (c *Client) UnbanOperation(operationHash string) error {
+	return c.UnbanOperationContext(context.Background(), operationHash)
+}
+
+// UnbanOperationContext is like UnbanOperation but uses ctx for its requests to the node.
+func (c *Client) UnbanOperationContext(ctx context.Context, operationHash string) error {
+	v, err := json.Marshal(operationHash)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unban operation '%s'", operationHash)
+	}
+
+	_, err = c.post(ctx, fmt.Sprintf("/chains/%s/mempool/unban_operation", c.chain), v)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unban operation '%s'", operationHash)
+	}
+
+	return nil
+}
+
+/*
+UnbanAllOperations removes all the operations from the operations banned by the node.
+
+Path:
+	/chains/<chain_id>/mempool/unban_all_operations (POST)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#post-chains-chain-id-mempool-unban-all-operations
+*/
+func (c *Client) UnbanAllOperations() error {
+	return c.UnbanAllOperationsContext(context.Background())
+}
+
+// UnbanAllOperationsContext is like UnbanAllOperations but uses ctx for its requests to the node.
+func (c *Client) UnbanAllOperationsContext(ctx context.Context) error {
+	_, err := c.post(ctx, fmt.Sprintf("/chains/%s/mempool/unban_all_operations", c.chain), []byte("{}"))
+	if err != nil {
+		return errors.Wrap(err, "failed to unban all operations")
+	}
+
+	return nil
+}
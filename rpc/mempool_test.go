@@ -0,0 +1,297 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	mockMempoolApplied = `[{"hash":"ooy6DvCGUNa35KRe1rFd6qRDgAbcDGkxYaC2V8LWQpN6g4AYVon","protocol":"PsCARTHAGazKbHtnKfLzQg3kms52kSRpgnDY982a9oYsSXRLQEb","branch":"BMcVzs8SCpDVUn8rMAn6WBBNSPHyRp7nWuD5EnNPYHDHodzVNzc","contents":[{"kind":"endorsement","level":1150847}],"signature":"sigsvbKbNDsSaXUn33KRXDx4FT8GjsSbbtGdEi1JMWD3UMeZqWzu7WYw1eGNW8Y3RxuYwh25b7LyEoSuBKeTUePGQEYyMb86"}]`
+	mockMempoolRefused = `[{"hash":"onvwARdqAn9QPwv9NkDCzx8kUtZXn9iRvfqhBrqYunLMPbRWUUY","protocol":"PsCARTHAGazKbHtnKfLzQg3kms52kSRpgnDY982a9oYsSXRLQEb","branch":"BMcVzs8SCpDVUn8rMAn6WBBNSPHyRp7nWuD5EnNPYHDHodzVNzc","contents":[{"kind":"transaction","source":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","fee":"1283","counter":"100","gas_limit":"10307","storage_limit":"0","amount":"1000","destination":"tz1S82rGFZK8cVbNDpP1Hf9VhTUa4W8oc2WV"}],"signature":"sigsvbKbNDsSaXUn33KRXDx4FT8GjsSbbtGdEi1JMWD3UMeZqWzu7WYw1eGNW8Y3RxuYwh25b7LyEoSuBKeTUePGQEYyMb86","error":[{"kind":"temporary","id":"proto.006-PsCARTHA.contract.counter_in_the_past"}]}]`
+)
+
+func Test_MonitorMempoolOperations(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chains/main/mempool/monitor_operations", r.URL.Path)
+		assert.Equal(t, "applied=true&branch_delayed=false&refused=true", r.URL.RawQuery)
+
+		// the node ends the stream on a new head
+		switch atomic.AddInt32(&requests, 1) {
+		case 1:
+			w.Write([]byte(mockMempoolApplied))
+		case 2:
+			w.Write([]byte(mockMempoolRefused))
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	rpc := &Client{client: http.DefaultClient, host: server.URL, chain: "main"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	yes, no := true, false
+	operations, errs := rpc.MonitorMempoolOperations(ctx, MonitorMempoolOperationsInput{
+		Applied:       &yes,
+		Refused:       &yes,
+		BranchDelayed: &no,
+	})
+
+	var received [][]MempoolOperation
+	for len(received) < 2 {
+		select {
+		case ops := <-operations:
+			received = append(received, ops)
+		case err := <-errs:
+			t.Fatalf("unexpected error: %s", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out receiving from monitor")
+		}
+	}
+
+	assert.Equal(t, "ooy6DvCGUNa35KRe1rFd6qRDgAbcDGkxYaC2V8LWQpN6g4AYVon", received[0][0].Hash)
+	assert.Equal(t, ENDORSEMENT, received[0][0].Contents[0].Kind)
+	assert.Nil(t, received[0][0].Error)
+
+	assert.Equal(t, "onvwARdqAn9QPwv9NkDCzx8kUtZXn9iRvfqhBrqYunLMPbRWUUY", received[1][0].Hash)
+	assert.Equal(t, TRANSACTION, received[1][0].Contents[0].Kind)
+	assert.Equal(t, "proto.006-PsCARTHA.contract.counter_in_the_past", received[1][0].Error[0].ID)
+
+	cancel()
+	_, ok := <-operations
+	assert.False(t, ok)
+}
+
+func Test_PendingOperations(t *testing.T) {
+	goldenPendingOperations := getResponse(pendingOperations).(PendingOperations)
+
+	type input struct {
+		handler http.Handler
+		input   PendingOperationsInput
+	}
+
+	type want struct {
+		err               bool
+		errContains       string
+		pendingOperations PendingOperations
+	}
+
+	yes := true
+	cases := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			"returns rpc error",
+			input{
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Write(readResponse(rpcerrors))
+				}),
+				PendingOperationsInput{},
+			},
+			want{
+				true,
+				"failed to get pending operations",
+				PendingOperations{},
+			},
+		},
+		{
+			"fails to unmarshal",
+			input{
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Write([]byte(`junk`))
+				}),
+				PendingOperationsInput{},
+			},
+			want{
+				true,
+				"failed to unmarshal pending operations",
+				PendingOperations{},
+			},
+		},
+		{
+			"is successful",
+			input{
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "/chains/main/mempool/pending_operations", r.URL.Path)
+					assert.Equal(t, "branch_refused=true&version=1", r.URL.RawQuery)
+					w.Write(readResponse(pendingOperations))
+				}),
+				PendingOperationsInput{Version: 1, BranchRefused: &yes},
+			},
+			want{
+				false,
+				"",
+				goldenPendingOperations,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.input.handler)
+			defer server.Close()
+
+			rpc := &Client{client: http.DefaultClient, host: server.URL, chain: "main"}
+
+			pendingOperations, err := rpc.PendingOperations(tt.input.input)
+			checkErr(t, tt.want.err, tt.want.errContains, err)
+			assert.Equal(t, tt.want.pendingOperations, pendingOperations)
+		})
+	}
+}
+
+func Test_PendingOperations_UnmarshalJSON(t *testing.T) {
+	pendingOperations := getResponse(pendingOperations).(PendingOperations)
+
+	assert.Len(t, pendingOperations.Applied, 1)
+	assert.Len(t, pendingOperations.Refused, 1)
+	assert.Len(t, pendingOperations.BranchRefused, 1)
+	assert.Empty(t, pendingOperations.Outdated)
+
+	op, classification, ok := pendingOperations.Operation("onvwARdqAn9QPwv9NkDCzx8kUtZXn9iRvfqhBrqYunLMPbRWUUY")
+	assert.True(t, ok)
+	assert.Equal(t, "refused", classification)
+	assert.Equal(t, "PsCARTHAGazKbHtnKfLzQg3kms52kSRpgnDY982a9oYsSXRLQEb", op.Protocol)
+	assert.Equal(t, "100", op.Contents[0].Counter)
+	assert.Equal(t, "proto.006-PsCARTHA.contract.counter_in_the_past", op.Error[0].ID)
+
+	_, classification, ok = pendingOperations.Operation("opCvA7VGxwwknp7GiMdmqb3tqkL2FeNmKRCJFTbDFJ4yjK4uRBe")
+	assert.True(t, ok)
+	assert.Equal(t, "branch_refused", classification)
+
+	_, _, ok = pendingOperations.Operation("oo5Hj2mSMtUsGq6Db7zmWUfgu2Mu1Ldd5WLmDGeqUprnPGS4Mgk")
+	assert.False(t, ok)
+
+	// nodes from Lima call applied operations validated and no longer use [hash, operation] pairs
+	var validated PendingOperations
+	err := json.Unmarshal([]byte(`{"validated":`+mockMempoolApplied+`,"refused":`+mockMempoolRefused+`}`), &validated)
+	assert.Nil(t, err)
+	assert.Equal(t, "ooy6DvCGUNa35KRe1rFd6qRDgAbcDGkxYaC2V8LWQpN6g4AYVon", validated.Applied[0].Hash)
+	assert.Equal(t, "onvwARdqAn9QPwv9NkDCzx8kUtZXn9iRvfqhBrqYunLMPbRWUUY", validated.Refused[0].Hash)
+
+	err = json.Unmarshal([]byte(`{"refused":[["onvwARdqAn9QPwv9NkDCzx8kUtZXn9iRvfqhBrqYunLMPbRWUUY"]]}`), &validated)
+	checkErr(t, true, "expected [hash, operation]", err)
+}
+
+func Test_MempoolFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chains/main/mempool/filter", r.URL.Path)
+
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"minimal_fees":"100","minimal_nanotez_per_gas_unit":["100","1"],"minimal_nanotez_per_byte":["1000","1"],"allow_script_failure":true,"clock_drift":"1","replace_by_fee_factor":["21","20"],"max_prechecked_manager_operations":5000}`))
+		case http.MethodPost:
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, `{"minimal_fees":"0","allow_script_failure":false}`, string(body))
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	rpc := &Client{client: http.DefaultClient, host: server.URL, chain: "main"}
+
+	filter, err := rpc.MempoolFilter()
+	assert.Nil(t, err)
+
+	allow := true
+	assert.Equal(t, MempoolFilter{
+		MinimalFees:                    "100",
+		MinimalNanotezPerGasUnit:       []string{"100", "1"},
+		MinimalNanotezPerByte:          []string{"1000", "1"},
+		AllowScriptFailure:             &allow,
+		ClockDrift:                     "1",
+		ReplaceByFeeFactor:             []string{"21", "20"},
+		MaxPrecheckedManagerOperations: 5000,
+	}, filter)
+
+	allow = false
+	err = rpc.SetMempoolFilter(MempoolFilter{MinimalFees: "0", AllowScriptFailure: &allow})
+	assert.Nil(t, err)
+}
+
+func Test_MempoolPOST(t *testing.T) {
+	const operationHash = "onvwARdqAn9QPwv9NkDCzx8kUtZXn9iRvfqhBrqYunLMPbRWUUY"
+
+	cases := []struct {
+		name    string
+		path    string
+		query   string
+		body    string
+		request func(c *Client) error
+	}{
+		{
+			"requests operations",
+			"/chains/main/mempool/request_operations",
+			"peer_id=idrpUzAiPoFwhTbQZHsGHsq3ci1YK7",
+			"{}",
+			func(c *Client) error {
+				return c.RequestOperations(RequestOperationsInput{PeerID: "idrpUzAiPoFwhTbQZHsGHsq3ci1YK7"})
+			},
+		},
+		{
+			"bans operation",
+			"/chains/main/mempool/ban_operation",
+			"",
+			`"` + operationHash + `"`,
+			func(c *Client) error {
+				return c.BanOperation(operationHash)
+			},
+		},
+		{
+			"unbans operation",
+			"/chains/main/mempool/unban_operation",
+			"",
+			`"` + operationHash + `"`,
+			func(c *Client) error {
+				return c.UnbanOperation(operationHash)
+			},
+		},
+		{
+			"unbans all operations",
+			"/chains/main/mempool/unban_all_operations",
+			"",
+			"{}",
+			func(c *Client) error {
+				return c.UnbanAllOperations()
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, tt.path, r.URL.Path)
+				assert.Equal(t, tt.query, r.URL.RawQuery)
+
+				body, _ := ioutil.ReadAll(r.Body)
+				assert.Equal(t, tt.body, string(body))
+			}))
+			defer server.Close()
+
+			rpc := &Client{client: http.DefaultClient, host: server.URL, chain: "main"}
+			assert.Nil(t, tt.request(rpc))
+		})
+	}
+
+	t.Run("returns rpc error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write(readResponse(rpcerrors))
+		}))
+		defer server.Close()
+
+		rpc := &Client{client: http.DefaultClient, host: server.URL, chain: "main"}
+		checkErr(t, true, "failed to ban operation '"+operationHash+"'", rpc.BanOperation(operationHash))
+	})
+}
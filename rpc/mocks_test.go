@@ -40,6 +40,7 @@ const (
 	invalidblocks      responseKey = ".test-fixtures/invalid_blocks.json"
 	operationhashes    responseKey = ".test-fixtures/operation_hashes.json"
 	parseOperations    responseKey = ".test-fixtures/parse_operations.json"
+	pendingOperations  responseKey = ".test-fixtures/pending_operations.json"
 	preapplyOperations responseKey = ".test-fixtures/preapply_operations.json"
 	proposals          responseKey = ".test-fixtures/proposals.json"
 	rpcerrors          responseKey = ".test-fixtures/rpc_errors.json"
@@ -169,6 +170,11 @@ func getResponse(key responseKey) interface{} {
 		var out []Operations
 		json.Unmarshal(f, &out)
 		return out
+	case pendingOperations:
+		f := readResponse(key)
+		var out PendingOperations
+		json.Unmarshal(f, &out)
+		return out
 	case preapplyOperations:
 		f := readResponse(key)
 		var out []Operations
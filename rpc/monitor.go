@@ -0,0 +1,399 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	monitorMinBackoff = time.Second
+	monitorMaxBackoff = 30 * time.Second
+	// monitorErrors is the number of errors a monitor buffers before dropping the oldest ones.
+	monitorErrors = 8
+)
+
+// streamEnd is what a monitor does when the node ends a stream.
+type streamEnd int
+
+const (
+	// endStops stops the monitor, the stream has a natural end, e.g. once the node is bootstrapped.
+	endStops streamEnd = iota
+	// endFails reconnects with a backoff and reports the error, the node only ends the stream on failure.
+	endFails
+	// endRestarts reconnects immediately, the node ends the stream to restart it, e.g. on a new head.
+	endRestarts
+)
+
+/*
+ShellHeader represents the shell header of a block and its hash streamed by the monitor RPCs.
+
+RPC:
+	/monitor/heads/<chain_id> (GET)
+	/monitor/valid_blocks (GET)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-monitor-heads-chain-id
+	https://tezos.gitlab.io/api/rpc.html#get-monitor-valid-blocks
+*/
+type ShellHeader struct {
+	ChainID        string    `json:"chain_id,omitempty"`
+	Hash           string    `json:"hash"`
+	Level          int       `json:"level"`
+	Proto          int       `json:"proto"`
+	Predecessor    string    `json:"predecessor"`
+	Timestamp      time.Time `json:"timestamp"`
+	ValidationPass int       `json:"validation_pass"`
+	OperationsHash string    `json:"operations_hash"`
+	Fitness        []string  `json:"fitness"`
+	Context        string    `json:"context"`
+	ProtocolData   string    `json:"protocol_data"`
+}
+
+/*
+MonitorHeadsInput is the input for the MonitorHeads function.
+
+Function:
+	func (c *Client) MonitorHeads(ctx context.Context, input MonitorHeadsInput) (<-chan ShellHeader, <-chan error) {}
+*/
+type MonitorHeadsInput struct {
+	// NextProtocols filters the heads to those of blocks whose next protocol is one of the protocols.
+	NextProtocols []string
+}
+
+func (m *MonitorHeadsInput) contructRPCOptions() []rpcOptions {
+	var opts []rpcOptions
+	for _, protocol := range m.NextProtocols {
+		opts = append(opts, rpcOptions{
+			"next_protocol",
+			protocol,
+		})
+	}
+
+	return opts
+}
+
+/*
+MonitorValidBlocksInput is the input for the MonitorValidBlocks function.
+
+Function:
+	func (c *Client) MonitorValidBlocks(ctx context.Context, input MonitorValidBlocksInput) (<-chan ShellHeader, <-chan error) {}
+*/
+type MonitorValidBlocksInput struct {
+	// Protocols filters the blocks to those of one of the protocols.
+	Protocols []string
+	// NextProtocols filters the blocks to those whose next protocol is one of the protocols.
+	NextProtocols []string
+	// Chains filters the blocks to those of one of the chains.
+	Chains []string
+}
+
+func (m *MonitorValidBlocksInput) contructRPCOptions() []rpcOptions {
+	var opts []rpcOptions
+	for _, protocol := range m.Protocols {
+		opts = append(opts, rpcOptions{
+			"protocol",
+			protocol,
+		})
+	}
+
+	for _, protocol := range m.NextProtocols {
+		opts = append(opts, rpcOptions{
+			"next_protocol",
+			protocol,
+		})
+	}
+
+	for _, chain := range m.Chains {
+		opts = append(opts, rpcOptions{
+			"chain",
+			chain,
+		})
+	}
+
+	return opts
+}
+
+/*
+MonitorHeads streams the new heads of the chain of the client, starting with the current head.
+
+Note:
+	The stream reconnects with a backoff when it fails or is closed by the node, sending the error on the error channel,
+	which buffers the last errors and drops older ones, so it does not need to be received from. Both channels are
+	closed when ctx is done.
+
+Path:
+	/monitor/heads/<chain_id> (GET)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-monitor-heads-chain-id
+
+Parameters:
+
+	ctx:
+		The context of the stream, which is stopped when it is done.
+
+	input:
+		Modifies the MonitorHeads function.
+*/
+func (c *Client) MonitorHeads(ctx context.Context, input MonitorHeadsInput) (<-chan ShellHeader, <-chan error) {
+	heads := make(chan ShellHeader)
+	errs := make(chan error, monitorErrors)
+
+	go func() {
+		defer close(heads)
+		c.monitor(ctx, fmt.Sprintf("/monitor/heads/%s", c.chain), endFails, errs, func(dec *json.Decoder) error {
+			var head ShellHeader
+			if err := dec.Decode(&head); err != nil {
+				return err
+			}
+
+			select {
+			case heads <- head:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}, input.contructRPCOptions()...)
+	}()
+
+	return heads, errs
+}
+
+/*
+MonitorBootstrapped streams the heads of the node while it is bootstrapping, and closes the channels once it is
+bootstrapped. If the node is already bootstrapped, the current head is sent before closing them.
+
+Note:
+	The stream reconnects with a backoff when it fails, sending the error on the error channel, which buffers the last
+	errors and drops older ones, so it does not need to be received from. Both channels are closed when ctx is done.
+
+Path:
+	/monitor/bootstrapped (GET)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-monitor-bootstrapped
+
+Parameters:
+
+	ctx:
+		The context of the stream, which is stopped when it is done.
+*/
+func (c *Client) MonitorBootstrapped(ctx context.Context) (<-chan Bootstrap, <-chan error) {
+	bootstraps := make(chan Bootstrap)
+	errs := make(chan error, monitorErrors)
+
+	go func() {
+		defer close(bootstraps)
+		c.monitor(ctx, "/monitor/bootstrapped", endStops, errs, func(dec *json.Decoder) error {
+			var bootstrap Bootstrap
+			if err := dec.Decode(&bootstrap); err != nil {
+				return err
+			}
+
+			select {
+			case bootstraps <- bootstrap:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	return bootstraps, errs
+}
+
+/*
+MonitorValidBlocks streams the blocks validated by the node.
+
+Note:
+	The stream reconnects with a backoff when it fails or is closed by the node, sending the error on the error channel,
+	which buffers the last errors and drops older ones, so it does not need to be received from. Both channels are
+	closed when ctx is done.
+
+Path:
+	/monitor/valid_blocks (GET)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-monitor-valid-blocks
+
+Parameters:
+
+	ctx:
+		The context of the stream, which is stopped when it is done.
+
+	input:
+		Modifies the MonitorValidBlocks function.
+*/
+func (c *Client) MonitorValidBlocks(ctx context.Context, input MonitorValidBlocksInput) (<-chan ShellHeader, <-chan error) {
+	blocks := make(chan ShellHeader)
+	errs := make(chan error, monitorErrors)
+
+	go func() {
+		defer close(blocks)
+		c.monitor(ctx, "/monitor/valid_blocks", endFails, errs, func(dec *json.Decoder) error {
+			var block ShellHeader
+			if err := dec.Decode(&block); err != nil {
+				return err
+			}
+
+			select {
+			case blocks <- block:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}, input.contructRPCOptions()...)
+	}()
+
+	return blocks, errs
+}
+
+/*
+MonitorProtocols streams the hashes of the protocols the node fetches or compiles.
+
+Note:
+	The stream reconnects with a backoff when it fails or is closed by the node, sending the error on the error channel,
+	which buffers the last errors and drops older ones, so it does not need to be received from. Both channels are
+	closed when ctx is done.
+
+Path:
+	/monitor/protocols (GET)
+
+Link:
+	https://tezos.gitlab.io/api/rpc.html#get-monitor-protocols
+
+Parameters:
+
+	ctx:
+		The context of the stream, which is stopped when it is done.
+*/
+func (c *Client) MonitorProtocols(ctx context.Context) (<-chan string, <-chan error) {
+	protocols := make(chan string)
+	errs := make(chan error, monitorErrors)
+
+	go func() {
+		defer close(protocols)
+		c.monitor(ctx, "/monitor/protocols", endFails, errs, func(dec *json.Decoder) error {
+			var protocol string
+			if err := dec.Decode(&protocol); err != nil {
+				return err
+			}
+
+			select {
+			case protocols <- protocol:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	return protocols, errs
+}
+
+// monitor streams the JSON values of a streaming RPC to decode until ctx is done and closes errs when it returns.
+func (c *Client) monitor(ctx context.Context, path string, end streamEnd, errs chan error, decode func(dec *json.Decoder) error, opts ...rpcOptions) {
+	defer close(errs)
+
+	backoff := RetryPolicy{MinBackoff: monitorMinBackoff, MaxBackoff: monitorMaxBackoff}
+	client := c.streamClient()
+
+	var attempt int
+	for {
+		received, err := c.stream(ctx, client, path, decode, opts...)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if received {
+			attempt = 0
+		}
+		attempt++
+
+		if err == io.EOF {
+			switch end {
+			case endStops:
+				return
+			case endRestarts:
+				// don't reconnect in a loop to a node ending streams right away
+				if !received && sleep(ctx, backoff.backoff(attempt)) != nil {
+					return
+				}
+				continue
+			}
+			err = errors.New("stream closed by the node")
+		}
+
+		sendError(errs, errors.Wrapf(err, "failed to monitor '%s'", path))
+
+		if sleep(ctx, backoff.backoff(attempt)) != nil {
+			return
+		}
+	}
+}
+
+// sendError sends err on the buffered errs without blocking, dropping the oldest error if the buffer is full.
+func sendError(errs chan error, err error) {
+	for {
+		select {
+		case errs <- err:
+			return
+		default:
+		}
+
+		select {
+		case <-errs:
+		default:
+		}
+	}
+}
+
+// stream makes a request to a streaming RPC and decodes its JSON values until it fails, and returns if any were.
+func (c *Client) stream(ctx context.Context, client client, path string, decode func(dec *json.Decoder) error, opts ...rpcOptions) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.host, path), nil)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to construct request")
+	}
+
+	constructQueryParams(req, opts...)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to complete request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		byts, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+		return false, fmt.Errorf("response returned code %d with body %s", resp.StatusCode, string(byts))
+	}
+
+	dec := json.NewDecoder(resp.Body)
+
+	var received bool
+	for {
+		if err := decode(dec); err != nil {
+			return received, err
+		}
+		received = true
+	}
+}
+
+// streamClient returns the client without its timeout, which would otherwise close streams.
+func (c *Client) streamClient() client {
+	if hc, ok := c.client.(*http.Client); ok && hc.Timeout != 0 {
+		stream := *hc
+		stream.Timeout = 0
+		return &stream
+	}
+
+	return c.client
+}
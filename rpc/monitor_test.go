@@ -0,0 +1,234 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	mockHead1 = `{"hash":"BLDvjpDFaNUHqkYUtDMiwxPFyhnJwEJ3P5RKjLZQstXRwL4JZf1","level":1000,"proto":7,"predecessor":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","timestamp":"2020-10-12T18:03:18Z","validation_pass":4,"operations_hash":"LLoaG8QsdaKuJuVUB2VdB7JAF6Jz2LyuWaM7NZMi7JHHUvuvfFCqr","fitness":["01","0000000000000a0e"],"context":"CoV4hGAhCj8pDEAUH1YGsaQKhx4TTDaMEkMDUV7xgKHFD5hcDuMJ","protocol_data":"00"}`
+	mockHead2 = `{"hash":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","level":1001}`
+	mockHead3 = `{"hash":"BLBL72xDPC4j4ghkaXhA3o1ncSoaSKCAYCFhv9w1a6PQoQpdT5V","level":1002}`
+)
+
+// streamHandler streams the chunks of the nth stream to the nth request, and holds the last stream open.
+func streamHandler(requests *int32, streams ...[]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(requests, 1)) - 1
+		if n >= len(streams) {
+			<-r.Context().Done()
+			return
+		}
+
+		for _, chunk := range streams[n] {
+			w.Write([]byte(chunk))
+			w.(http.Flusher).Flush()
+		}
+
+		if n == len(streams)-1 {
+			<-r.Context().Done()
+		}
+	})
+}
+
+func receiveHead(t *testing.T, heads <-chan ShellHeader, errs <-chan error) (ShellHeader, error) {
+	select {
+	case head := <-heads:
+		return head, nil
+	case err := <-errs:
+		return ShellHeader{}, err
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out receiving from monitor")
+		return ShellHeader{}, nil
+	}
+}
+
+func Test_MonitorHeads(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(streamHandler(&requests,
+		// the first head is split across chunks and the second shares a chunk with it
+		[]string{mockHead1[:20], mockHead1[20:100], mockHead1[100:] + "\n" + mockHead2},
+		[]string{mockHead3},
+	))
+	defer server.Close()
+
+	rpc := &Client{client: http.DefaultClient, host: server.URL, chain: "main"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	heads, errs := rpc.MonitorHeads(ctx, MonitorHeadsInput{})
+
+	head, err := receiveHead(t, heads, errs)
+	assert.Nil(t, err)
+	assert.Equal(t, "BLDvjpDFaNUHqkYUtDMiwxPFyhnJwEJ3P5RKjLZQstXRwL4JZf1", head.Hash)
+	assert.Equal(t, 1000, head.Level)
+	assert.Equal(t, []string{"01", "0000000000000a0e"}, head.Fitness)
+
+	head, err = receiveHead(t, heads, errs)
+	assert.Nil(t, err)
+	assert.Equal(t, 1001, head.Level)
+
+	_, err = receiveHead(t, heads, errs)
+	checkErr(t, true, "failed to monitor '/monitor/heads/main': stream closed by the node", err)
+
+	head, err = receiveHead(t, heads, errs)
+	assert.Nil(t, err)
+	assert.Equal(t, 1002, head.Level)
+
+	cancel()
+	_, ok := <-heads
+	assert.False(t, ok)
+	_, ok = <-errs
+	assert.False(t, ok)
+}
+
+func Test_MonitorHeads_Error(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(mockHead2))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	rpc := &Client{client: http.DefaultClient, host: server.URL, chain: "main"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	heads, errs := rpc.MonitorHeads(ctx, MonitorHeadsInput{NextProtocols: []string{"PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA"}})
+
+	_, err := receiveHead(t, heads, errs)
+	checkErr(t, true, "response returned code 503", err)
+
+	head, err := receiveHead(t, heads, errs)
+	assert.Nil(t, err)
+	assert.Equal(t, 1001, head.Level)
+}
+
+func Test_MonitorHeads_IgnoredErrors(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(streamHandler(&requests,
+		[]string{mockHead1},
+		[]string{mockHead2},
+		[]string{mockHead3},
+	))
+	defer server.Close()
+
+	rpc := &Client{client: http.DefaultClient, host: server.URL, chain: "main"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	heads, _ := rpc.MonitorHeads(ctx, MonitorHeadsInput{})
+
+	var levels []int
+	for head := range heads {
+		levels = append(levels, head.Level)
+		if len(levels) == 3 {
+			cancel()
+		}
+	}
+
+	assert.Equal(t, []int{1000, 1001, 1002}, levels)
+}
+
+func Test_sendError(t *testing.T) {
+	errs := make(chan error, 2)
+	for _, err := range []string{"first", "second", "third"} {
+		sendError(errs, errors.New(err))
+	}
+
+	assert.EqualError(t, <-errs, "second")
+	assert.EqualError(t, <-errs, "third")
+	assert.Len(t, errs, 0)
+}
+
+func Test_MonitorBootstrapped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/monitor/bootstrapped", r.URL.Path)
+		w.Write([]byte(`{"block":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","timestamp":"2020-10-12T18:03:18Z"}`))
+	}))
+	defer server.Close()
+
+	rpc := &Client{client: http.DefaultClient, host: server.URL, chain: "main"}
+
+	bootstraps, errs := rpc.MonitorBootstrapped(context.Background())
+
+	var received []Bootstrap
+	for bootstrap := range bootstraps {
+		received = append(received, bootstrap)
+	}
+
+	assert.Len(t, received, 1)
+	assert.Equal(t, mockBlockHash, received[0].Block)
+
+	_, ok := <-errs
+	assert.False(t, ok)
+}
+
+func Test_MonitorValidBlocks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/monitor/valid_blocks", r.URL.Path)
+		assert.Equal(t, "chain=main&next_protocol=PtEdo2Zk&protocol=PsDELPH1", r.URL.RawQuery)
+		w.Write([]byte(`{"chain_id":"NetXdQprcVkpaWU",` + mockHead2[1:]))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	rpc := &Client{client: http.DefaultClient, host: server.URL, chain: "main"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blocks, errs := rpc.MonitorValidBlocks(ctx, MonitorValidBlocksInput{
+		Protocols:     []string{"PsDELPH1"},
+		NextProtocols: []string{"PtEdo2Zk"},
+		Chains:        []string{"main"},
+	})
+
+	block, err := receiveHead(t, blocks, errs)
+	assert.Nil(t, err)
+	assert.Equal(t, "NetXdQprcVkpaWU", block.ChainID)
+	assert.Equal(t, 1001, block.Level)
+}
+
+func Test_MonitorProtocols(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/monitor/protocols", r.URL.Path)
+		w.Write([]byte(`"PsDELPH1Kxsxt8f9eWbxQeRxkjfbxoqM52jvs5Y5fBxWWh4ifpo""PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA"`))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	rpc := &Client{client: http.DefaultClient, host: server.URL, chain: "main"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	protocols, _ := rpc.MonitorProtocols(ctx)
+	assert.Equal(t, "PsDELPH1Kxsxt8f9eWbxQeRxkjfbxoqM52jvs5Y5fBxWWh4ifpo", <-protocols)
+	assert.Equal(t, "PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA", <-protocols)
+}
+
+func Test_streamClient(t *testing.T) {
+	timeout := &http.Client{Timeout: time.Second}
+	rpc := &Client{client: timeout}
+
+	stream, ok := rpc.streamClient().(*http.Client)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), stream.Timeout)
+	assert.Equal(t, time.Second, timeout.Timeout)
+}
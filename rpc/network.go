@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -177,7 +178,12 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-network-version
 */
 func (c *Client) Version() (Version, error) {
-	resp, err := c.get("/network/version")
+	return c.VersionContext(context.Background())
+}
+
+// VersionContext is like Version but uses ctx for its requests to the node.
+func (c *Client) VersionContext(ctx context.Context) (Version, error) {
+	resp, err := c.get(ctx, "/network/version")
 	if err != nil {
 		return Version{}, errors.Wrap(err, "could not get network version")
 	}
@@ -201,7 +207,12 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-block-id-context-constants
 */
 func (c *Client) Constants(blockhash string) (Constants, error) {
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/%s/context/constants", c.chain, blockhash))
+	return c.ConstantsContext(context.Background(), blockhash)
+}
+
+// ConstantsContext is like Constants but uses ctx for its requests to the node.
+func (c *Client) ConstantsContext(ctx context.Context, blockhash string) (Constants, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/context/constants", c.chain, blockhash))
 	if err != nil {
 		return Constants{}, errors.Wrapf(err, "could not get network constants")
 	}
@@ -225,7 +236,12 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-network-connections
 */
 func (c *Client) Connections() (Connections, error) {
-	resp, err := c.get("/network/connections")
+	return c.ConnectionsContext(context.Background())
+}
+
+// ConnectionsContext is like Connections but uses ctx for its requests to the node.
+func (c *Client) ConnectionsContext(ctx context.Context) (Connections, error) {
+	resp, err := c.get(ctx, "/network/connections")
 	if err != nil {
 		return Connections{}, errors.Wrapf(err, "could not get network connections")
 	}
@@ -251,7 +267,12 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-monitor-bootstrapped
 */
 func (c *Client) Bootstrap() (Bootstrap, error) {
-	resp, err := c.get("/monitor/bootstrapped")
+	return c.BootstrapContext(context.Background())
+}
+
+// BootstrapContext is like Bootstrap but uses ctx for its requests to the node.
+func (c *Client) BootstrapContext(ctx context.Context) (Bootstrap, error) {
+	resp, err := c.get(ctx, "/monitor/bootstrapped")
 	if err != nil {
 		return Bootstrap{}, errors.Wrap(err, "could not get bootstrap")
 	}
@@ -275,7 +296,12 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-monitor-commit-hash
 */
 func (c *Client) Commit() (string, error) {
-	resp, err := c.get("/monitor/commit_hash")
+	return c.CommitContext(context.Background())
+}
+
+// CommitContext is like Commit but uses ctx for its requests to the node.
+func (c *Client) CommitContext(ctx context.Context) (string, error) {
+	resp, err := c.get(ctx, "/monitor/commit_hash")
 	if err != nil {
 		return "", errors.Wrap(err, "could not get commit hash")
 	}
@@ -299,7 +325,12 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-block-id-context-raw-bytes
 */
 func (c *Client) Cycle(cycle int) (Cycle, error) {
-	head, err := c.Head()
+	return c.CycleContext(context.Background(), cycle)
+}
+
+// CycleContext is like Cycle but uses ctx for its requests to the node.
+func (c *Client) CycleContext(ctx context.Context, cycle int) (Cycle, error) {
+	head, err := c.HeadContext(ctx)
 	if err != nil {
 		return Cycle{}, errors.Wrapf(err, "could not get cycle '%d'", cycle)
 	}
@@ -310,19 +341,19 @@ func (c *Client) Cycle(cycle int) (Cycle, error) {
 
 	var cyc Cycle
 	if cycle < head.Metadata.Level.Cycle {
-		block, err := c.Block(cycle*c.networkConstants.BlocksPerCycle + 1)
+		block, err := c.BlockContext(ctx, cycle*c.networkConstants.BlocksPerCycle+1)
 		if err != nil {
 			return Cycle{}, errors.Wrapf(err, "could not get cycle '%d'", cycle)
 		}
 
-		cyc, err = c.getCycleAtHash(block.Hash, cycle)
+		cyc, err = c.getCycleAtHash(ctx, block.Hash, cycle)
 		if err != nil {
 			return Cycle{}, errors.Wrapf(err, "could not get cycle '%d'", cycle)
 		}
 
 	} else {
 		var err error
-		cyc, err = c.getCycleAtHash(head.Hash, cycle)
+		cyc, err = c.getCycleAtHash(ctx, head.Hash, cycle)
 		if err != nil {
 			return Cycle{}, errors.Wrapf(err, "could not get cycle '%d'", cycle)
 		}
@@ -333,7 +364,7 @@ func (c *Client) Cycle(cycle int) (Cycle, error) {
 		level = 1
 	}
 
-	block, err := c.Block(level)
+	block, err := c.BlockContext(ctx, level)
 	if err != nil {
 		return cyc, errors.Wrapf(err, "could not get cycle '%d'", cycle)
 	}
@@ -342,8 +373,8 @@ func (c *Client) Cycle(cycle int) (Cycle, error) {
 	return cyc, nil
 }
 
-func (c *Client) getCycleAtHash(blockhash string, cycle int) (Cycle, error) {
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/%s/context/raw/json/cycle/%d", c.chain, blockhash, cycle))
+func (c *Client) getCycleAtHash(ctx context.Context, blockhash string, cycle int) (Cycle, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/context/raw/json/cycle/%d", c.chain, blockhash, cycle))
 	if err != nil {
 		return Cycle{}, errors.Wrapf(err, "could not get cycle at hash '%s'", blockhash)
 	}
@@ -367,7 +398,12 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-monitor-active-chains
 */
 func (c *Client) ActiveChains() (ActiveChains, error) {
-	resp, err := c.get("/monitor/active_chains")
+	return c.ActiveChainsContext(context.Background())
+}
+
+// ActiveChainsContext is like ActiveChains but uses ctx for its requests to the node.
+func (c *Client) ActiveChainsContext(ctx context.Context) (ActiveChains, error) {
+	resp, err := c.get(ctx, "/monitor/active_chains")
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get active chains")
 	}
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -8,7 +9,7 @@ import (
 	"strconv"
 
 	validator "github.com/go-playground/validator/v10"
-	"github.com/goat-systems/go-tezos/v3/internal/crypto"
+	"github.com/goat-systems/go-tezos/v3/b58"
 	"github.com/pkg/errors"
 )
 
@@ -129,6 +130,11 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-preapply-operations
 */
 func (c *Client) PreapplyOperations(input PreapplyOperationsInput) ([]Operations, error) {
+	return c.PreapplyOperationsContext(context.Background(), input)
+}
+
+// PreapplyOperationsContext is like PreapplyOperations but uses ctx for its requests to the node.
+func (c *Client) PreapplyOperationsContext(ctx context.Context, input PreapplyOperationsInput) ([]Operations, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return nil, errors.Wrap(err, "invalid input")
@@ -139,7 +145,7 @@ func (c *Client) PreapplyOperations(input PreapplyOperationsInput) ([]Operations
 		return nil, errors.Wrap(err, "failed to preapply operation")
 	}
 
-	resp, err := c.post(fmt.Sprintf("/chains/%s/blocks/%s/helpers/preapply/operations", c.chain, input.Blockhash), op)
+	resp, err := c.post(ctx, fmt.Sprintf("/chains/%s/blocks/%s/helpers/preapply/operations", c.chain, input.Blockhash), op)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to preapply operation")
 	}
@@ -169,6 +175,11 @@ Link:
 	https/tezos.gitlab.io/api/rpc.html#post-injection-operation
 */
 func (c *Client) InjectionOperation(input InjectionOperationInput) (string, error) {
+	return c.InjectionOperationContext(context.Background(), input)
+}
+
+// InjectionOperationContext is like InjectionOperation but uses ctx for its requests to the node.
+func (c *Client) InjectionOperationContext(ctx context.Context, input InjectionOperationInput) (string, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return "", errors.Wrap(err, "invalid input")
@@ -178,7 +189,7 @@ func (c *Client) InjectionOperation(input InjectionOperationInput) (string, erro
 	if err != nil {
 		return "", errors.Wrap(err, "failed to inject operation")
 	}
-	resp, err := c.post("/injection/operation", v, input.contructRPCOptions()...)
+	resp, err := c.post(ctx, "/injection/operation", v, input.contructRPCOptions()...)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to inject operation")
 	}
@@ -226,6 +237,11 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-forge-operations
 */
 func (c *Client) ForgeOperation(input ForgeOperationInput) (string, error) {
+	return c.ForgeOperationContext(context.Background(), input)
+}
+
+// ForgeOperationContext is like ForgeOperation but uses ctx for its requests to the node.
+func (c *Client) ForgeOperationContext(ctx context.Context, input ForgeOperationInput) (string, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return "", errors.Wrap(err, "invalid input")
@@ -241,7 +257,7 @@ func (c *Client) ForgeOperation(input ForgeOperationInput) (string, error) {
 		return "", errors.Wrap(err, "failed to forge operation")
 	}
 
-	resp, err := c.post(fmt.Sprintf("/chains/%s/blocks/%s/helpers/forge/operations", c.chain, input.Blockhash), v)
+	resp, err := c.post(ctx, fmt.Sprintf("/chains/%s/blocks/%s/helpers/forge/operations", c.chain, input.Blockhash), v)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to forge operation")
 	}
@@ -259,7 +275,7 @@ func (c *Client) ForgeOperation(input ForgeOperationInput) (string, error) {
 
 	var rpc *Client
 	if input.CheckRPCAddr != "" {
-		rpc, err = New(input.CheckRPCAddr)
+		rpc, err = NewContext(ctx, input.CheckRPCAddr)
 		if err != nil {
 			return operation, errors.Wrap(err, "failed to forge operation: unable to verify rpc returned a valid contents with alternative node")
 		}
@@ -267,7 +283,7 @@ func (c *Client) ForgeOperation(input ForgeOperationInput) (string, error) {
 		rpc = c
 	}
 
-	operations, err := rpc.UnforgeOperation(UnforgeOperationInput{
+	operations, err := rpc.UnforgeOperationContext(ctx, UnforgeOperationInput{
 		Blockhash: input.Blockhash,
 		Operations: []UnforgeOperation{
 			{
@@ -304,6 +320,11 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-parse-operations
 */
 func (c *Client) UnforgeOperation(input UnforgeOperationInput) ([]Operations, error) {
+	return c.UnforgeOperationContext(context.Background(), input)
+}
+
+// UnforgeOperationContext is like UnforgeOperation but uses ctx for its requests to the node.
+func (c *Client) UnforgeOperationContext(ctx context.Context, input UnforgeOperationInput) ([]Operations, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return []Operations{}, errors.Wrap(err, "invalid input")
@@ -314,7 +335,7 @@ func (c *Client) UnforgeOperation(input UnforgeOperationInput) ([]Operations, er
 		return []Operations{}, errors.Wrap(err, "failed to unforge forge operations with RPC")
 	}
 
-	resp, err := c.post(fmt.Sprintf("/chains/%s/blocks/%s/helpers/parse/operations", c.chain, input.Blockhash), v)
+	resp, err := c.post(ctx, fmt.Sprintf("/chains/%s/blocks/%s/helpers/parse/operations", c.chain, input.Blockhash), v)
 	if err != nil {
 		return []Operations{}, errors.Wrap(err, "failed to unforge forge operations with RPC")
 	}
@@ -345,6 +366,11 @@ Link:
 	https/tezos.gitlab.io/api/rpc.html#post-injection-operation
 */
 func (c *Client) InjectionBlock(input InjectionBlockInput) ([]byte, error) {
+	return c.InjectionBlockContext(context.Background(), input)
+}
+
+// InjectionBlockContext is like InjectionBlock but uses ctx for its requests to the node.
+func (c *Client) InjectionBlockContext(ctx context.Context, input InjectionBlockInput) ([]byte, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
@@ -354,7 +380,7 @@ func (c *Client) InjectionBlock(input InjectionBlockInput) ([]byte, error) {
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "failed to inject block")
 	}
-	resp, err := c.post("/injection/block", v, input.contructRPCOptions()...)
+	resp, err := c.post(ctx, "/injection/block", v, input.contructRPCOptions()...)
 	if err != nil {
 		return resp, errors.Wrap(err, "failed to inject block")
 	}
@@ -396,7 +422,12 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-counter
 */
 func (c *Client) Counter(input CounterInput) (int, error) {
-	resp, err := c.get(fmt.Sprintf("/chains/%s/blocks/%s/context/contracts/%s/counter", c.chain, input.Blockhash, input.Address))
+	return c.CounterContext(context.Background(), input)
+}
+
+// CounterContext is like Counter but uses ctx for its requests to the node.
+func (c *Client) CounterContext(ctx context.Context, input CounterInput) (int, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/%s/context/contracts/%s/counter", c.chain, input.Blockhash, input.Address))
 	if err != nil {
 		return 0, errors.Wrapf(err, "failed to get counter")
 	}
@@ -423,6 +454,11 @@ Link:
 	https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-scripts-run-operation
 */
 func (c *Client) RunOperation(input RunOperationInput) (Operations, error) {
+	return c.RunOperationContext(context.Background(), input)
+}
+
+// RunOperationContext is like RunOperation but uses ctx for its requests to the node.
+func (c *Client) RunOperationContext(ctx context.Context, input RunOperationInput) (Operations, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return Operations{}, errors.Wrap(err, "invalid input")
@@ -433,7 +469,7 @@ func (c *Client) RunOperation(input RunOperationInput) (Operations, error) {
 		return input.Operation.Operation, errors.Wrap(err, "failed to marshal operation")
 	}
 
-	resp, err := c.post(fmt.Sprintf("/chains/%s/blocks/%s/helpers/scripts/run_operation", c.chain, input.Blockhash), v)
+	resp, err := c.post(ctx, fmt.Sprintf("/chains/%s/blocks/%s/helpers/scripts/run_operation", c.chain, input.Blockhash), v)
 	if err != nil {
 		return input.Operation.Operation, errors.Wrapf(err, "failed to run_operation")
 	}
@@ -469,7 +505,7 @@ func stripBranchFromForgedOperation(operation string, signed bool) (string, stri
 		return "", operation, errors.New("failed to unforge branch from operation")
 	}
 
-	branch := crypto.B58cencode(resultByts, []byte{1, 52})
+	branch, err := b58.Encode(b58.BlockHash, resultByts)
 	if err != nil {
 		return branch, rest, errors.Wrap(err, "failed to unforge branch from operation")
 	}
@@ -0,0 +1,1220 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Selection is the strategy a Pool uses to select the node of a request.
+type Selection int
+
+const (
+	// RoundRobin rotates requests over the healthy nodes.
+	RoundRobin Selection = iota
+	// LeastLatency sends requests to the healthy node with the lowest latency on its last health check.
+	LeastLatency
+)
+
+/*
+PoolOptions are the options of a Pool.
+
+Note:
+	A node is healthy if its last health check succeeded, it is bootstrapped and its head is at most MaxLag levels
+	behind the highest head of the pool.
+*/
+type PoolOptions struct {
+	// Selection is the strategy to select the node of a request, RoundRobin by default.
+	Selection Selection
+	// MaxLag is the number of levels a node can be behind the highest head of the pool and be healthy, 2 if 0.
+	MaxLag int
+	// HealthCheckInterval is the interval between background health checks, which are disabled if 0.
+	HealthCheckInterval time.Duration
+}
+
+/*
+NodeStatus is the status of a node of a Pool at its last health check.
+*/
+type NodeStatus struct {
+	Host         string
+	Healthy      bool
+	Bootstrapped bool
+	Level        int
+	Latency      time.Duration
+	Err          error
+	CheckedAt    time.Time
+}
+
+/*
+Pool is a client over multiple Tezos nodes which implements IFace. Requests are sent to a healthy node chosen with the
+Selection of the pool and fail over to the next healthy nodes, and then the unhealthy ones, on a transient error.
+
+Note:
+	A transient error is an error the RetryPolicy of the pool retries on, a connection error or a response with one
+	of its RetryableStatusCodes. Injections only fail over if the policy has RetryInjection set.
+
+	Nodes can be at different heads, a block hash from a node could be unknown to another one. Use Pin to send a
+	sequence of requests to a single node.
+	Each node has its own mempool, the mempool RPCs changing it are sent to all the nodes and the ones reading it to
+	the node returned by Pin.
+*/
+type Pool struct {
+	nodes     []*poolNode
+	selection Selection
+	maxLag    int
+	next      uint32
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+type poolNode struct {
+	client *Client
+	mu     sync.RWMutex
+	status NodeStatus
+}
+
+/*
+NewPool returns a pointer to a Pool over the nodes, initializes their clients with the network constants and checks
+their health.
+
+Parameters:
+
+	hosts:
+		The Tezos nodes.
+
+	opts:
+		The options of the pool.
+*/
+func NewPool(hosts []string, opts PoolOptions) (*Pool, error) {
+	return NewPoolContext(context.Background(), hosts, opts)
+}
+
+// NewPoolContext is like NewPool but uses ctx for the requests initializing the pool.
+func NewPoolContext(ctx context.Context, hosts []string, opts PoolOptions) (*Pool, error) {
+	if len(hosts) == 0 {
+		return nil, errors.New("failed to create pool: no hosts")
+	}
+
+	if opts.MaxLag == 0 {
+		opts.MaxLag = 2
+	}
+
+	p := &Pool{
+		nodes:     make([]*poolNode, len(hosts)),
+		selection: opts.Selection,
+		maxLag:    opts.MaxLag,
+		done:      make(chan struct{}),
+	}
+
+	errs := make([]error, len(hosts))
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		wg.Add(1)
+		go func(i int, host string) {
+			defer wg.Done()
+			client, err := NewContext(ctx, host)
+			p.nodes[i] = &poolNode{client: client, status: NodeStatus{Host: client.host}}
+			errs[i] = err
+		}(i, host)
+	}
+	wg.Wait()
+
+	var constants *Constants
+	for i, n := range p.nodes {
+		if errs[i] == nil {
+			constants = n.client.networkConstants
+			break
+		}
+	}
+
+	if constants == nil {
+		return nil, errors.Wrap(errs[0], "failed to create pool: could not initialize any node")
+	}
+
+	for _, n := range p.nodes {
+		if n.client.networkConstants == nil {
+			n.client.SetConstants(*constants)
+		}
+	}
+
+	p.CheckHealthContext(ctx)
+
+	if opts.HealthCheckInterval > 0 {
+		go p.checkHealthEvery(opts.HealthCheckInterval)
+	}
+
+	return p, nil
+}
+
+// Close stops the background health checks of the pool.
+func (p *Pool) Close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+}
+
+// SetChain sets the chain for the nodes of the pool.
+func (p *Pool) SetChain(chain string) {
+	for _, n := range p.nodes {
+		n.client.SetChain(chain)
+	}
+}
+
+/*
+SetRetryPolicy sets the RetryPolicy of the clients of the nodes of the pool.
+
+Note:
+	Retries are made on the same node before failing over, set a policy with less attempts for faster failovers.
+
+Parameters:
+
+	policy:
+		The policy for retrying requests failing with a transient error.
+*/
+func (p *Pool) SetRetryPolicy(policy RetryPolicy) {
+	for _, n := range p.nodes {
+		n.client.SetRetryPolicy(policy)
+	}
+}
+
+/*
+Pin returns the client of the node the next request would be sent to, so that a sequence of requests can be sent to a
+single node, e.g. to query a block hash on the node it was fetched from.
+*/
+func (p *Pool) Pin() *Client {
+	return p.candidates()[0].client
+}
+
+// Status returns the status of the nodes of the pool at their last health check.
+func (p *Pool) Status() []NodeStatus {
+	statuses := make([]NodeStatus, len(p.nodes))
+	for i, n := range p.nodes {
+		statuses[i] = n.getStatus()
+	}
+
+	return statuses
+}
+
+/*
+CheckHealth checks the bootstrap status and head level of the nodes of the pool, and updates which are healthy.
+*/
+func (p *Pool) CheckHealth() {
+	p.CheckHealthContext(context.Background())
+}
+
+// CheckHealthContext is like CheckHealth but uses ctx for its requests to the nodes.
+func (p *Pool) CheckHealthContext(ctx context.Context) {
+	statuses := make([]NodeStatus, len(p.nodes))
+	var wg sync.WaitGroup
+	for i, n := range p.nodes {
+		wg.Add(1)
+		go func(i int, n *poolNode) {
+			defer wg.Done()
+			statuses[i] = n.check(ctx)
+		}(i, n)
+	}
+	wg.Wait()
+
+	var highest int
+	for _, status := range statuses {
+		if status.Err == nil && status.Level > highest {
+			highest = status.Level
+		}
+	}
+
+	for i, n := range p.nodes {
+		status := statuses[i]
+		status.Healthy = status.Err == nil && status.Bootstrapped && highest-status.Level <= p.maxLag
+		n.setStatus(status)
+	}
+}
+
+func (p *Pool) checkHealthEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.CheckHealth()
+		}
+	}
+}
+
+// candidates returns the nodes in the order a request tries them, the selected healthy nodes first.
+func (p *Pool) candidates() []*poolNode {
+	var healthy, unhealthy []*poolNode
+	for _, n := range p.nodes {
+		if n.getStatus().Healthy {
+			healthy = append(healthy, n)
+		} else {
+			unhealthy = append(unhealthy, n)
+		}
+	}
+
+	switch p.selection {
+	case LeastLatency:
+		sort.SliceStable(healthy, func(i, j int) bool {
+			return healthy[i].getStatus().Latency < healthy[j].getStatus().Latency
+		})
+	default:
+		if len(healthy) > 0 {
+			start := int(atomic.AddUint32(&p.next, 1)-1) % len(healthy)
+			rotated := make([]*poolNode, 0, len(healthy))
+			healthy = append(append(rotated, healthy[start:]...), healthy[:start]...)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// do calls f with the client of each candidate node until it succeeds or fails with an error which is not transient.
+func (p *Pool) do(ctx context.Context, f func(c *Client) error) error {
+	return p.try(ctx, false, f)
+}
+
+// inject is like do for an injection, which only fails over if the retry policy of the clients allows retrying injections.
+func (p *Pool) inject(ctx context.Context, f func(c *Client) error) error {
+	return p.try(ctx, true, f)
+}
+
+func (p *Pool) try(ctx context.Context, injection bool, f func(c *Client) error) error {
+	var err error
+	for _, n := range p.candidates() {
+		err = f(n.client)
+		if err == nil {
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return err
+		}
+
+		policy := n.client.retryPolicy
+		if !policy.transient(err) {
+			return err
+		}
+
+		if policy.retryableError(err) {
+			n.markUnhealthy(err)
+		}
+
+		if injection && !policy.RetryInjection {
+			return err
+		}
+	}
+
+	return errors.Wrapf(err, "failed on all %d nodes", len(p.nodes))
+}
+
+// broadcast calls f with the clients of all the nodes concurrently and fails if it fails on any of them.
+func (p *Pool) broadcast(f func(c *Client) error) error {
+	errs := make([]error, len(p.nodes))
+	var wg sync.WaitGroup
+	for i, n := range p.nodes {
+		wg.Add(1)
+		go func(i int, n *poolNode) {
+			defer wg.Done()
+			errs[i] = f(n.client)
+		}(i, n)
+	}
+	wg.Wait()
+
+	var (
+		failed []string
+		err    error
+	)
+	for i, e := range errs {
+		if e != nil {
+			failed = append(failed, p.nodes[i].client.host)
+			if err == nil {
+				err = e
+			}
+		}
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "failed on %d of %d nodes (%s)", len(failed), len(p.nodes), strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+func (n *poolNode) getStatus() NodeStatus {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	return n.status
+}
+
+func (n *poolNode) setStatus(status NodeStatus) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.status = status
+}
+
+func (n *poolNode) markUnhealthy(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.status.Healthy = false
+	n.status.Err = err
+}
+
+func (n *poolNode) check(ctx context.Context) NodeStatus {
+	status := NodeStatus{
+		Host:      n.client.host,
+		CheckedAt: time.Now(),
+	}
+
+	start := time.Now()
+	status.Level, status.Err = n.client.headLevel(ctx)
+	status.Latency = time.Since(start)
+	if status.Err != nil {
+		return status
+	}
+
+	bootstrapped, err := n.client.IsBootstrappedContext(ctx)
+	if err != nil {
+		status.Err = err
+		return status
+	}
+	status.Bootstrapped = bootstrapped.Bootstrapped
+
+	return status
+}
+
+func (c *Client) headLevel(ctx context.Context) (int, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/chains/%s/blocks/head/header", c.chain))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get head header")
+	}
+
+	var header struct {
+		Level int `json:"level"`
+	}
+	err = json.Unmarshal(resp, &header)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to unmarshal head header")
+	}
+
+	return header.Level, nil
+}
+
+// ActiveChains calls ActiveChains on the nodes of the pool until it succeeds.
+func (p *Pool) ActiveChains() (ActiveChains, error) {
+	return p.ActiveChainsContext(context.Background())
+}
+
+// ActiveChainsContext is like ActiveChains but uses ctx for its requests to the nodes.
+func (p *Pool) ActiveChainsContext(ctx context.Context) (ActiveChains, error) {
+	var v ActiveChains
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.ActiveChainsContext(ctx)
+		return err
+	})
+
+	return v, err
+}
+
+// BakingRights calls BakingRights on the nodes of the pool until it succeeds.
+func (p *Pool) BakingRights(input BakingRightsInput) (*BakingRights, error) {
+	return p.BakingRightsContext(context.Background(), input)
+}
+
+// BakingRightsContext is like BakingRights but uses ctx for its requests to the nodes.
+func (p *Pool) BakingRightsContext(ctx context.Context, input BakingRightsInput) (*BakingRights, error) {
+	var v *BakingRights
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.BakingRightsContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// Balance calls Balance on the nodes of the pool until it succeeds.
+func (p *Pool) Balance(input BalanceInput) (string, error) {
+	return p.BalanceContext(context.Background(), input)
+}
+
+// BalanceContext is like Balance but uses ctx for its requests to the nodes.
+func (p *Pool) BalanceContext(ctx context.Context, input BalanceInput) (string, error) {
+	var v string
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.BalanceContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// BallotList calls BallotList on the nodes of the pool until it succeeds.
+func (p *Pool) BallotList(blockhash string) (BallotList, error) {
+	return p.BallotListContext(context.Background(), blockhash)
+}
+
+// BallotListContext is like BallotList but uses ctx for its requests to the nodes.
+func (p *Pool) BallotListContext(ctx context.Context, blockhash string) (BallotList, error) {
+	var v BallotList
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.BallotListContext(ctx, blockhash)
+		return err
+	})
+
+	return v, err
+}
+
+// Ballots calls Ballots on the nodes of the pool until it succeeds.
+func (p *Pool) Ballots(blockhash string) (Ballots, error) {
+	return p.BallotsContext(context.Background(), blockhash)
+}
+
+// BallotsContext is like Ballots but uses ctx for its requests to the nodes.
+func (p *Pool) BallotsContext(ctx context.Context, blockhash string) (Ballots, error) {
+	var v Ballots
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.BallotsContext(ctx, blockhash)
+		return err
+	})
+
+	return v, err
+}
+
+// BanOperation calls BanOperation on all the nodes of the pool, as each node has its own mempool.
+func (p *Pool) BanOperation(operationHash string) error {
+	return p.BanOperationContext(context.Background(), operationHash)
+}
+
+// BanOperationContext is like BanOperation but uses ctx for its requests to the nodes.
+func (p *Pool) BanOperationContext(ctx context.Context, operationHash string) error {
+	return p.broadcast(func(c *Client) error {
+		return c.BanOperationContext(ctx, operationHash)
+	})
+}
+
+// BigMap calls BigMap on the nodes of the pool until it succeeds.
+func (p *Pool) BigMap(input BigMapInput) ([]byte, error) {
+	return p.BigMapContext(context.Background(), input)
+}
+
+// BigMapContext is like BigMap but uses ctx for its requests to the nodes.
+func (p *Pool) BigMapContext(ctx context.Context, input BigMapInput) ([]byte, error) {
+	var v []byte
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.BigMapContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// Block calls Block on the nodes of the pool until it succeeds.
+func (p *Pool) Block(id interface{}) (*Block, error) {
+	return p.BlockContext(context.Background(), id)
+}
+
+// BlockContext is like Block but uses ctx for its requests to the nodes.
+func (p *Pool) BlockContext(ctx context.Context, id interface{}) (*Block, error) {
+	var v *Block
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.BlockContext(ctx, id)
+		return err
+	})
+
+	return v, err
+}
+
+// Blocks calls Blocks on the nodes of the pool until it succeeds.
+func (p *Pool) Blocks(input BlocksInput) ([][]string, error) {
+	return p.BlocksContext(context.Background(), input)
+}
+
+// BlocksContext is like Blocks but uses ctx for its requests to the nodes.
+func (p *Pool) BlocksContext(ctx context.Context, input BlocksInput) ([][]string, error) {
+	var v [][]string
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.BlocksContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// Bootstrap calls Bootstrap on the nodes of the pool until it succeeds.
+func (p *Pool) Bootstrap() (Bootstrap, error) {
+	return p.BootstrapContext(context.Background())
+}
+
+// BootstrapContext is like Bootstrap but uses ctx for its requests to the nodes.
+func (p *Pool) BootstrapContext(ctx context.Context) (Bootstrap, error) {
+	var v Bootstrap
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.BootstrapContext(ctx)
+		return err
+	})
+
+	return v, err
+}
+
+// ChainID calls ChainID on the nodes of the pool until it succeeds.
+func (p *Pool) ChainID() (string, error) {
+	return p.ChainIDContext(context.Background())
+}
+
+// ChainIDContext is like ChainID but uses ctx for its requests to the nodes.
+func (p *Pool) ChainIDContext(ctx context.Context) (string, error) {
+	var v string
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.ChainIDContext(ctx)
+		return err
+	})
+
+	return v, err
+}
+
+// Checkpoint calls Checkpoint on the nodes of the pool until it succeeds.
+func (p *Pool) Checkpoint() (Checkpoint, error) {
+	return p.CheckpointContext(context.Background())
+}
+
+// CheckpointContext is like Checkpoint but uses ctx for its requests to the nodes.
+func (p *Pool) CheckpointContext(ctx context.Context) (Checkpoint, error) {
+	var v Checkpoint
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.CheckpointContext(ctx)
+		return err
+	})
+
+	return v, err
+}
+
+// Commit calls Commit on the nodes of the pool until it succeeds.
+func (p *Pool) Commit() (string, error) {
+	return p.CommitContext(context.Background())
+}
+
+// CommitContext is like Commit but uses ctx for its requests to the nodes.
+func (p *Pool) CommitContext(ctx context.Context) (string, error) {
+	var v string
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.CommitContext(ctx)
+		return err
+	})
+
+	return v, err
+}
+
+// Connections calls Connections on the nodes of the pool until it succeeds.
+func (p *Pool) Connections() (Connections, error) {
+	return p.ConnectionsContext(context.Background())
+}
+
+// ConnectionsContext is like Connections but uses ctx for its requests to the nodes.
+func (p *Pool) ConnectionsContext(ctx context.Context) (Connections, error) {
+	var v Connections
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.ConnectionsContext(ctx)
+		return err
+	})
+
+	return v, err
+}
+
+// Constants calls Constants on the nodes of the pool until it succeeds.
+func (p *Pool) Constants(blockhash string) (Constants, error) {
+	return p.ConstantsContext(context.Background(), blockhash)
+}
+
+// ConstantsContext is like Constants but uses ctx for its requests to the nodes.
+func (p *Pool) ConstantsContext(ctx context.Context, blockhash string) (Constants, error) {
+	var v Constants
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.ConstantsContext(ctx, blockhash)
+		return err
+	})
+
+	return v, err
+}
+
+// ContractStorage calls ContractStorage on the nodes of the pool until it succeeds.
+func (p *Pool) ContractStorage(input ContractStorageInput) ([]byte, error) {
+	return p.ContractStorageContext(context.Background(), input)
+}
+
+// ContractStorageContext is like ContractStorage but uses ctx for its requests to the nodes.
+func (p *Pool) ContractStorageContext(ctx context.Context, input ContractStorageInput) ([]byte, error) {
+	var v []byte
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.ContractStorageContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// Counter calls Counter on the nodes of the pool until it succeeds.
+func (p *Pool) Counter(input CounterInput) (int, error) {
+	return p.CounterContext(context.Background(), input)
+}
+
+// CounterContext is like Counter but uses ctx for its requests to the nodes.
+func (p *Pool) CounterContext(ctx context.Context, input CounterInput) (int, error) {
+	var v int
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.CounterContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// CurrentPeriodKind calls CurrentPeriodKind on the nodes of the pool until it succeeds.
+func (p *Pool) CurrentPeriodKind(blockhash string) (string, error) {
+	return p.CurrentPeriodKindContext(context.Background(), blockhash)
+}
+
+// CurrentPeriodKindContext is like CurrentPeriodKind but uses ctx for its requests to the nodes.
+func (p *Pool) CurrentPeriodKindContext(ctx context.Context, blockhash string) (string, error) {
+	var v string
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.CurrentPeriodKindContext(ctx, blockhash)
+		return err
+	})
+
+	return v, err
+}
+
+// CurrentProposal calls CurrentProposal on the nodes of the pool until it succeeds.
+func (p *Pool) CurrentProposal(blockhash string) (string, error) {
+	return p.CurrentProposalContext(context.Background(), blockhash)
+}
+
+// CurrentProposalContext is like CurrentProposal but uses ctx for its requests to the nodes.
+func (p *Pool) CurrentProposalContext(ctx context.Context, blockhash string) (string, error) {
+	var v string
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.CurrentProposalContext(ctx, blockhash)
+		return err
+	})
+
+	return v, err
+}
+
+// CurrentQuorum calls CurrentQuorum on the nodes of the pool until it succeeds.
+func (p *Pool) CurrentQuorum(blockhash string) (int, error) {
+	return p.CurrentQuorumContext(context.Background(), blockhash)
+}
+
+// CurrentQuorumContext is like CurrentQuorum but uses ctx for its requests to the nodes.
+func (p *Pool) CurrentQuorumContext(ctx context.Context, blockhash string) (int, error) {
+	var v int
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.CurrentQuorumContext(ctx, blockhash)
+		return err
+	})
+
+	return v, err
+}
+
+// Cycle calls Cycle on the nodes of the pool until it succeeds.
+func (p *Pool) Cycle(cycle int) (Cycle, error) {
+	return p.CycleContext(context.Background(), cycle)
+}
+
+// CycleContext is like Cycle but uses ctx for its requests to the nodes.
+func (p *Pool) CycleContext(ctx context.Context, cycle int) (Cycle, error) {
+	var v Cycle
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.CycleContext(ctx, cycle)
+		return err
+	})
+
+	return v, err
+}
+
+// Delegate calls Delegate on the nodes of the pool until it succeeds.
+func (p *Pool) Delegate(input DelegateInput) (Delegate, error) {
+	return p.DelegateContext(context.Background(), input)
+}
+
+// DelegateContext is like Delegate but uses ctx for its requests to the nodes.
+func (p *Pool) DelegateContext(ctx context.Context, input DelegateInput) (Delegate, error) {
+	var v Delegate
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.DelegateContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// Delegates calls Delegates on the nodes of the pool until it succeeds.
+func (p *Pool) Delegates(input DelegatesInput) ([]string, error) {
+	return p.DelegatesContext(context.Background(), input)
+}
+
+// DelegatesContext is like Delegates but uses ctx for its requests to the nodes.
+func (p *Pool) DelegatesContext(ctx context.Context, input DelegatesInput) ([]string, error) {
+	var v []string
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.DelegatesContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// DelegatedContracts calls DelegatedContracts on the nodes of the pool until it succeeds.
+func (p *Pool) DelegatedContracts(input DelegatedContractsInput) ([]string, error) {
+	return p.DelegatedContractsContext(context.Background(), input)
+}
+
+// DelegatedContractsContext is like DelegatedContracts but uses ctx for its requests to the nodes.
+func (p *Pool) DelegatedContractsContext(ctx context.Context, input DelegatedContractsInput) ([]string, error) {
+	var v []string
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.DelegatedContractsContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// DeleteInvalidBlock calls DeleteInvalidBlock on the nodes of the pool until it succeeds.
+func (p *Pool) DeleteInvalidBlock(blockHash string) error {
+	return p.DeleteInvalidBlockContext(context.Background(), blockHash)
+}
+
+// DeleteInvalidBlockContext is like DeleteInvalidBlock but uses ctx for its requests to the nodes.
+func (p *Pool) DeleteInvalidBlockContext(ctx context.Context, blockHash string) error {
+	return p.do(ctx, func(c *Client) error {
+		return c.DeleteInvalidBlockContext(ctx, blockHash)
+	})
+}
+
+// EndorsingRights calls EndorsingRights on the nodes of the pool until it succeeds.
+func (p *Pool) EndorsingRights(input EndorsingRightsInput) (*EndorsingRights, error) {
+	return p.EndorsingRightsContext(context.Background(), input)
+}
+
+// EndorsingRightsContext is like EndorsingRights but uses ctx for its requests to the nodes.
+func (p *Pool) EndorsingRightsContext(ctx context.Context, input EndorsingRightsInput) (*EndorsingRights, error) {
+	var v *EndorsingRights
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.EndorsingRightsContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// ForgeOperation calls ForgeOperation on the nodes of the pool until it succeeds.
+func (p *Pool) ForgeOperation(input ForgeOperationInput) (string, error) {
+	return p.ForgeOperationContext(context.Background(), input)
+}
+
+// ForgeOperationContext is like ForgeOperation but uses ctx for its requests to the nodes.
+func (p *Pool) ForgeOperationContext(ctx context.Context, input ForgeOperationInput) (string, error) {
+	var v string
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.ForgeOperationContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// GetFA12Allowance calls GetFA12Allowance on the nodes of the pool until it succeeds.
+func (p *Pool) GetFA12Allowance(input GetFA12AllowanceInput) (string, error) {
+	return p.GetFA12AllowanceContext(context.Background(), input)
+}
+
+// GetFA12AllowanceContext is like GetFA12Allowance but uses ctx for its requests to the nodes.
+func (p *Pool) GetFA12AllowanceContext(ctx context.Context, input GetFA12AllowanceInput) (string, error) {
+	var v string
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.GetFA12AllowanceContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// FrozenBalance calls FrozenBalance on the nodes of the pool until it succeeds.
+func (p *Pool) FrozenBalance(input FrozenBalanceInput) (FrozenBalance, error) {
+	return p.FrozenBalanceContext(context.Background(), input)
+}
+
+// FrozenBalanceContext is like FrozenBalance but uses ctx for its requests to the nodes.
+func (p *Pool) FrozenBalanceContext(ctx context.Context, input FrozenBalanceInput) (FrozenBalance, error) {
+	var v FrozenBalance
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.FrozenBalanceContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// GetFA12Balance calls GetFA12Balance on the nodes of the pool until it succeeds.
+func (p *Pool) GetFA12Balance(input GetFA12BalanceInput) (string, error) {
+	return p.GetFA12BalanceContext(context.Background(), input)
+}
+
+// GetFA12BalanceContext is like GetFA12Balance but uses ctx for its requests to the nodes.
+func (p *Pool) GetFA12BalanceContext(ctx context.Context, input GetFA12BalanceInput) (string, error) {
+	var v string
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.GetFA12BalanceContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// GetFA12Supply calls GetFA12Supply on the nodes of the pool until it succeeds.
+func (p *Pool) GetFA12Supply(input GetFA12SupplyInput) (string, error) {
+	return p.GetFA12SupplyContext(context.Background(), input)
+}
+
+// GetFA12SupplyContext is like GetFA12Supply but uses ctx for its requests to the nodes.
+func (p *Pool) GetFA12SupplyContext(ctx context.Context, input GetFA12SupplyInput) (string, error) {
+	var v string
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.GetFA12SupplyContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// Head calls Head on the nodes of the pool until it succeeds.
+func (p *Pool) Head() (*Block, error) {
+	return p.HeadContext(context.Background())
+}
+
+// HeadContext is like Head but uses ctx for its requests to the nodes.
+func (p *Pool) HeadContext(ctx context.Context) (*Block, error) {
+	var v *Block
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.HeadContext(ctx)
+		return err
+	})
+
+	return v, err
+}
+
+/*
+InjectionBlock calls InjectionBlock on the nodes of the pool until it succeeds.
+
+Note:
+	The injection only fails over to the next node if the RetryPolicy of the pool has RetryInjection set, as a node
+	which failed to respond could have injected it.
+*/
+func (p *Pool) InjectionBlock(input InjectionBlockInput) ([]byte, error) {
+	return p.InjectionBlockContext(context.Background(), input)
+}
+
+// InjectionBlockContext is like InjectionBlock but uses ctx for its requests to the nodes.
+func (p *Pool) InjectionBlockContext(ctx context.Context, input InjectionBlockInput) ([]byte, error) {
+	var v []byte
+	err := p.inject(ctx, func(c *Client) (err error) {
+		v, err = c.InjectionBlockContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+/*
+InjectionOperation calls InjectionOperation on the nodes of the pool until it succeeds.
+
+Note:
+	The injection only fails over to the next node if the RetryPolicy of the pool has RetryInjection set, as a node
+	which failed to respond could have injected it.
+*/
+func (p *Pool) InjectionOperation(input InjectionOperationInput) (string, error) {
+	return p.InjectionOperationContext(context.Background(), input)
+}
+
+// InjectionOperationContext is like InjectionOperation but uses ctx for its requests to the nodes.
+func (p *Pool) InjectionOperationContext(ctx context.Context, input InjectionOperationInput) (string, error) {
+	var v string
+	err := p.inject(ctx, func(c *Client) (err error) {
+		v, err = c.InjectionOperationContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// InvalidBlock calls InvalidBlock on the nodes of the pool until it succeeds.
+func (p *Pool) InvalidBlock(blockHash string) (InvalidBlock, error) {
+	return p.InvalidBlockContext(context.Background(), blockHash)
+}
+
+// InvalidBlockContext is like InvalidBlock but uses ctx for its requests to the nodes.
+func (p *Pool) InvalidBlockContext(ctx context.Context, blockHash string) (InvalidBlock, error) {
+	var v InvalidBlock
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.InvalidBlockContext(ctx, blockHash)
+		return err
+	})
+
+	return v, err
+}
+
+// InvalidBlocks calls InvalidBlocks on the nodes of the pool until it succeeds.
+func (p *Pool) InvalidBlocks() ([]InvalidBlock, error) {
+	return p.InvalidBlocksContext(context.Background())
+}
+
+// InvalidBlocksContext is like InvalidBlocks but uses ctx for its requests to the nodes.
+func (p *Pool) InvalidBlocksContext(ctx context.Context) ([]InvalidBlock, error) {
+	var v []InvalidBlock
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.InvalidBlocksContext(ctx)
+		return err
+	})
+
+	return v, err
+}
+
+// IsBootstrapped calls IsBootstrapped on the nodes of the pool until it succeeds.
+func (p *Pool) IsBootstrapped() (Bootstrapped, error) {
+	return p.IsBootstrappedContext(context.Background())
+}
+
+// IsBootstrappedContext is like IsBootstrapped but uses ctx for its requests to the nodes.
+func (p *Pool) IsBootstrappedContext(ctx context.Context) (Bootstrapped, error) {
+	var v Bootstrapped
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.IsBootstrappedContext(ctx)
+		return err
+	})
+
+	return v, err
+}
+
+// MempoolFilter calls MempoolFilter on the node returned by Pin, as each node has its own mempool.
+func (p *Pool) MempoolFilter() (MempoolFilter, error) {
+	return p.MempoolFilterContext(context.Background())
+}
+
+// MempoolFilterContext is like MempoolFilter but uses ctx for its request to the node.
+func (p *Pool) MempoolFilterContext(ctx context.Context) (MempoolFilter, error) {
+	return p.Pin().MempoolFilterContext(ctx)
+}
+
+// OperationHashes calls OperationHashes on the nodes of the pool until it succeeds.
+func (p *Pool) OperationHashes(blockhash string) ([][]string, error) {
+	return p.OperationHashesContext(context.Background(), blockhash)
+}
+
+// OperationHashesContext is like OperationHashes but uses ctx for its requests to the nodes.
+func (p *Pool) OperationHashesContext(ctx context.Context, blockhash string) ([][]string, error) {
+	var v [][]string
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.OperationHashesContext(ctx, blockhash)
+		return err
+	})
+
+	return v, err
+}
+
+// PendingOperations calls PendingOperations on the node returned by Pin, as each node has its own mempool.
+func (p *Pool) PendingOperations(input PendingOperationsInput) (PendingOperations, error) {
+	return p.PendingOperationsContext(context.Background(), input)
+}
+
+// PendingOperationsContext is like PendingOperations but uses ctx for its request to the node.
+func (p *Pool) PendingOperationsContext(ctx context.Context, input PendingOperationsInput) (PendingOperations, error) {
+	return p.Pin().PendingOperationsContext(ctx, input)
+}
+
+// PreapplyOperations calls PreapplyOperations on the nodes of the pool until it succeeds.
+func (p *Pool) PreapplyOperations(input PreapplyOperationsInput) ([]Operations, error) {
+	return p.PreapplyOperationsContext(context.Background(), input)
+}
+
+// PreapplyOperationsContext is like PreapplyOperations but uses ctx for its requests to the nodes.
+func (p *Pool) PreapplyOperationsContext(ctx context.Context, input PreapplyOperationsInput) ([]Operations, error) {
+	var v []Operations
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.PreapplyOperationsContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// Proposals calls Proposals on the nodes of the pool until it succeeds.
+func (p *Pool) Proposals(blockhash string) (Proposals, error) {
+	return p.ProposalsContext(context.Background(), blockhash)
+}
+
+// ProposalsContext is like Proposals but uses ctx for its requests to the nodes.
+func (p *Pool) ProposalsContext(ctx context.Context, blockhash string) (Proposals, error) {
+	var v Proposals
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.ProposalsContext(ctx, blockhash)
+		return err
+	})
+
+	return v, err
+}
+
+// RequestOperations calls RequestOperations on all the nodes of the pool, as each node has its own mempool.
+func (p *Pool) RequestOperations(input RequestOperationsInput) error {
+	return p.RequestOperationsContext(context.Background(), input)
+}
+
+// RequestOperationsContext is like RequestOperations but uses ctx for its requests to the nodes.
+func (p *Pool) RequestOperationsContext(ctx context.Context, input RequestOperationsInput) error {
+	return p.broadcast(func(c *Client) error {
+		return c.RequestOperationsContext(ctx, input)
+	})
+}
+
+// RunOperation calls RunOperation on the nodes of the pool until it succeeds.
+func (p *Pool) RunOperation(input RunOperationInput) (Operations, error) {
+	return p.RunOperationContext(context.Background(), input)
+}
+
+// RunOperationContext is like RunOperation but uses ctx for its requests to the nodes.
+func (p *Pool) RunOperationContext(ctx context.Context, input RunOperationInput) (Operations, error) {
+	var v Operations
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.RunOperationContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// SetMempoolFilter calls SetMempoolFilter on all the nodes of the pool, as each node has its own mempool.
+func (p *Pool) SetMempoolFilter(filter MempoolFilter) error {
+	return p.SetMempoolFilterContext(context.Background(), filter)
+}
+
+// SetMempoolFilterContext is like SetMempoolFilter but uses ctx for its requests to the nodes.
+func (p *Pool) SetMempoolFilterContext(ctx context.Context, filter MempoolFilter) error {
+	return p.broadcast(func(c *Client) error {
+		return c.SetMempoolFilterContext(ctx, filter)
+	})
+}
+
+// StakingBalance calls StakingBalance on the nodes of the pool until it succeeds.
+func (p *Pool) StakingBalance(input StakingBalanceInput) (int, error) {
+	return p.StakingBalanceContext(context.Background(), input)
+}
+
+// StakingBalanceContext is like StakingBalance but uses ctx for its requests to the nodes.
+func (p *Pool) StakingBalanceContext(ctx context.Context, input StakingBalanceInput) (int, error) {
+	var v int
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.StakingBalanceContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// UnbanAllOperations calls UnbanAllOperations on all the nodes of the pool, as each node has its own mempool.
+func (p *Pool) UnbanAllOperations() error {
+	return p.UnbanAllOperationsContext(context.Background())
+}
+
+// UnbanAllOperationsContext is like UnbanAllOperations but uses ctx for its requests to the nodes.
+func (p *Pool) UnbanAllOperationsContext(ctx context.Context) error {
+	return p.broadcast(func(c *Client) error {
+		return c.UnbanAllOperationsContext(ctx)
+	})
+}
+
+// UnbanOperation calls UnbanOperation on all the nodes of the pool, as each node has its own mempool.
+func (p *Pool) UnbanOperation(operationHash string) error {
+	return p.UnbanOperationContext(context.Background(), operationHash)
+}
+
+// UnbanOperationContext is like UnbanOperation but uses ctx for its requests to the nodes.
+func (p *Pool) UnbanOperationContext(ctx context.Context, operationHash string) error {
+	return p.broadcast(func(c *Client) error {
+		return c.UnbanOperationContext(ctx, operationHash)
+	})
+}
+
+// UnforgeOperation calls UnforgeOperation on the nodes of the pool until it succeeds.
+func (p *Pool) UnforgeOperation(input UnforgeOperationInput) ([]Operations, error) {
+	return p.UnforgeOperationContext(context.Background(), input)
+}
+
+// UnforgeOperationContext is like UnforgeOperation but uses ctx for its requests to the nodes.
+func (p *Pool) UnforgeOperationContext(ctx context.Context, input UnforgeOperationInput) ([]Operations, error) {
+	var v []Operations
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.UnforgeOperationContext(ctx, input)
+		return err
+	})
+
+	return v, err
+}
+
+// UserActivatedProtocolOverrides calls UserActivatedProtocolOverrides on the nodes of the pool until it succeeds.
+func (p *Pool) UserActivatedProtocolOverrides() (UserActivatedProtocolOverrides, error) {
+	return p.UserActivatedProtocolOverridesContext(context.Background())
+}
+
+// UserActivatedProtocolOverridesContext is like UserActivatedProtocolOverrides but uses ctx for its requests to the nodes.
+func (p *Pool) UserActivatedProtocolOverridesContext(ctx context.Context) (UserActivatedProtocolOverrides, error) {
+	var v UserActivatedProtocolOverrides
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.UserActivatedProtocolOverridesContext(ctx)
+		return err
+	})
+
+	return v, err
+}
+
+// Version calls Version on the nodes of the pool until it succeeds.
+func (p *Pool) Version() (Version, error) {
+	return p.VersionContext(context.Background())
+}
+
+// VersionContext is like Version but uses ctx for its requests to the nodes.
+func (p *Pool) VersionContext(ctx context.Context) (Version, error) {
+	var v Version
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.VersionContext(ctx)
+		return err
+	})
+
+	return v, err
+}
+
+// VoteListings calls VoteListings on the nodes of the pool until it succeeds.
+func (p *Pool) VoteListings(blockhash string) (Listings, error) {
+	return p.VoteListingsContext(context.Background(), blockhash)
+}
+
+// VoteListingsContext is like VoteListings but uses ctx for its requests to the nodes.
+func (p *Pool) VoteListingsContext(ctx context.Context, blockhash string) (Listings, error) {
+	var v Listings
+	err := p.do(ctx, func(c *Client) (err error) {
+		v, err = c.VoteListingsContext(ctx, blockhash)
+		return err
+	})
+
+	return v, err
+}
@@ -0,0 +1,299 @@
+package rpc
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type nodeMock struct {
+	level        int
+	bootstrapped bool
+	delay        time.Duration
+	status       int
+	requests     int32
+	injections   int32
+	mempool      int32
+}
+
+func (n *nodeMock) handler() http.Handler {
+	return gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/chains/main/blocks/head/header":
+			time.Sleep(n.delay)
+			fmt.Fprintf(w, `{"level":%d}`, n.level)
+		case "/chains/main/is_bootstrapped":
+			fmt.Fprintf(w, `{"bootstrapped":%t,"sync_state":"synced"}`, n.bootstrapped)
+		case "/chains/main/chain_id":
+			atomic.AddInt32(&n.requests, 1)
+			if n.status != 0 {
+				w.WriteHeader(n.status)
+				return
+			}
+			w.Write(readResponse(chainid))
+		case "/injection/operation":
+			atomic.AddInt32(&n.injections, 1)
+			if n.status != 0 {
+				w.WriteHeader(n.status)
+				return
+			}
+			w.Write([]byte(`"ooYYiTNU4ttrDHjQq1Ydx6fvSZBDHBgSDpbXgvBDWSMHKBvzFNh"`))
+		case "/chains/main/mempool/ban_operation", "/chains/main/mempool/filter":
+			atomic.AddInt32(&n.mempool, 1)
+			if n.status != 0 {
+				w.WriteHeader(n.status)
+				return
+			}
+			w.Write([]byte(`{"minimal_fees":"100"}`))
+		case fmt.Sprintf("/chains/main/blocks/%s/votes/listings", mockBlockHash):
+			w.Write(readResponse(voteListings))
+		}
+	}))
+}
+
+func newPoolMock(t *testing.T, opts PoolOptions, nodes ...*nodeMock) (*Pool, []string) {
+	hosts := make([]string, len(nodes))
+	for i, n := range nodes {
+		server := httptest.NewServer(n.handler())
+		t.Cleanup(server.Close)
+		hosts[i] = server.URL
+	}
+
+	pool, err := NewPool(hosts, opts)
+	assert.Nil(t, err)
+
+	return pool, hosts
+}
+
+func Test_NewPool(t *testing.T) {
+	t.Run("fails without hosts", func(t *testing.T) {
+		_, err := NewPool(nil, PoolOptions{})
+		checkErr(t, true, "no hosts", err)
+	})
+
+	t.Run("fails if no node can be initialized", func(t *testing.T) {
+		server := httptest.NewServer(blankHandler)
+		server.Close()
+
+		_, err := NewPool([]string{server.URL}, PoolOptions{})
+		checkErr(t, true, "could not initialize any node", err)
+	})
+
+	t.Run("implements IFace", func(t *testing.T) {
+		var rpc IFace
+		rpc, _ = newPoolMock(t, PoolOptions{}, &nodeMock{level: 100, bootstrapped: true})
+		assert.NotNil(t, rpc)
+	})
+}
+
+func Test_Pool_CheckHealth(t *testing.T) {
+	down := httptest.NewServer(blankHandler)
+	down.Close()
+
+	synced := &nodeMock{level: 100, bootstrapped: true}
+	lagging := &nodeMock{level: 97, bootstrapped: true}
+	behind := &nodeMock{level: 98, bootstrapped: true}
+	bootstrapping := &nodeMock{level: 100, bootstrapped: false}
+
+	hosts := []string{down.URL}
+	for _, n := range []*nodeMock{synced, lagging, behind, bootstrapping} {
+		server := httptest.NewServer(n.handler())
+		defer server.Close()
+		hosts = append(hosts, server.URL)
+	}
+
+	pool, err := NewPool(hosts, PoolOptions{})
+	assert.Nil(t, err)
+
+	var healthy []bool
+	for _, status := range pool.Status() {
+		healthy = append(healthy, status.Healthy)
+	}
+	assert.Equal(t, []bool{false, true, false, true, false}, healthy)
+	assert.NotNil(t, pool.Status()[0].Err)
+	assert.Equal(t, 97, pool.Status()[2].Level)
+
+	lagging.level = 100
+	pool.CheckHealth()
+	assert.True(t, pool.Status()[2].Healthy)
+}
+
+func Test_Pool_Selection(t *testing.T) {
+	t.Run("round robin", func(t *testing.T) {
+		first := &nodeMock{level: 100, bootstrapped: true}
+		second := &nodeMock{level: 100, bootstrapped: true}
+		unhealthy := &nodeMock{level: 100, bootstrapped: false}
+		pool, _ := newPoolMock(t, PoolOptions{}, first, second, unhealthy)
+
+		for i := 0; i < 4; i++ {
+			_, err := pool.ChainID()
+			assert.Nil(t, err)
+		}
+
+		assert.Equal(t, int32(2), first.requests)
+		assert.Equal(t, int32(2), second.requests)
+		assert.Equal(t, int32(0), unhealthy.requests)
+	})
+
+	t.Run("least latency", func(t *testing.T) {
+		slow := &nodeMock{level: 100, bootstrapped: true, delay: 50 * time.Millisecond}
+		fast := &nodeMock{level: 100, bootstrapped: true}
+		pool, _ := newPoolMock(t, PoolOptions{Selection: LeastLatency}, slow, fast)
+
+		for i := 0; i < 4; i++ {
+			_, err := pool.ChainID()
+			assert.Nil(t, err)
+		}
+
+		assert.Equal(t, int32(0), slow.requests)
+		assert.Equal(t, int32(4), fast.requests)
+	})
+}
+
+func Test_Pool_Failover(t *testing.T) {
+	noRetries := RetryPolicy{RetryableStatusCodes: []int{http.StatusServiceUnavailable}}
+	injection := InjectionOperationInput{Operation: "some_operation"}
+
+	t.Run("fails over to the next node", func(t *testing.T) {
+		failing := &nodeMock{level: 100, bootstrapped: true, status: http.StatusServiceUnavailable}
+		working := &nodeMock{level: 100, bootstrapped: true}
+		pool, _ := newPoolMock(t, PoolOptions{}, failing, working)
+		pool.SetRetryPolicy(noRetries)
+
+		for i := 0; i < 2; i++ {
+			chainID, err := pool.ChainID()
+			assert.Nil(t, err)
+			assert.Equal(t, getResponse(chainid), chainID)
+		}
+
+		assert.Equal(t, int32(1), failing.requests)
+		assert.Equal(t, int32(2), working.requests)
+	})
+
+	t.Run("fails if all nodes fail", func(t *testing.T) {
+		first := &nodeMock{level: 100, bootstrapped: true, status: http.StatusServiceUnavailable}
+		second := &nodeMock{level: 100, bootstrapped: true, status: http.StatusServiceUnavailable}
+		pool, _ := newPoolMock(t, PoolOptions{}, first, second)
+		pool.SetRetryPolicy(noRetries)
+
+		_, err := pool.ChainID()
+		checkErr(t, true, "failed on all 2 nodes", err)
+	})
+
+	t.Run("does not fail over on a deterministic error", func(t *testing.T) {
+		failing := &nodeMock{level: 100, bootstrapped: true, status: http.StatusInternalServerError}
+		working := &nodeMock{level: 100, bootstrapped: true}
+		pool, _ := newPoolMock(t, PoolOptions{}, failing, working)
+		pool.SetRetryPolicy(noRetries)
+
+		_, err := pool.ChainID()
+		checkErr(t, true, "response returned code 500", err)
+		assert.NotContains(t, err.Error(), "failed on all")
+		assert.Equal(t, int32(1), failing.requests)
+		assert.Equal(t, int32(0), working.requests)
+	})
+
+	t.Run("does not fail over injections", func(t *testing.T) {
+		failing := &nodeMock{level: 100, bootstrapped: true, status: http.StatusServiceUnavailable}
+		working := &nodeMock{level: 100, bootstrapped: true}
+		pool, _ := newPoolMock(t, PoolOptions{}, failing, working)
+		pool.SetRetryPolicy(noRetries)
+
+		_, err := pool.InjectionOperation(injection)
+		checkErr(t, true, "response returned code 503", err)
+		assert.Equal(t, int32(1), failing.injections)
+		assert.Equal(t, int32(0), working.injections)
+	})
+
+	t.Run("fails over injections if enabled", func(t *testing.T) {
+		failing := &nodeMock{level: 100, bootstrapped: true, status: http.StatusServiceUnavailable}
+		working := &nodeMock{level: 100, bootstrapped: true}
+		pool, _ := newPoolMock(t, PoolOptions{}, failing, working)
+		withInjection := noRetries
+		withInjection.RetryInjection = true
+		pool.SetRetryPolicy(withInjection)
+
+		hash, err := pool.InjectionOperation(injection)
+		assert.Nil(t, err)
+		assert.Equal(t, "ooYYiTNU4ttrDHjQq1Ydx6fvSZBDHBgSDpbXgvBDWSMHKBvzFNh", hash)
+		assert.Equal(t, int32(1), failing.injections)
+		assert.Equal(t, int32(1), working.injections)
+	})
+
+	t.Run("does not fail over on invalid input", func(t *testing.T) {
+		first := &nodeMock{level: 100, bootstrapped: true}
+		second := &nodeMock{level: 100, bootstrapped: true}
+		pool, _ := newPoolMock(t, PoolOptions{}, first, second)
+
+		_, err := pool.Balance(BalanceInput{Blockhash: mockBlockHash})
+		checkErr(t, true, "invalid input", err)
+		assert.NotContains(t, err.Error(), "failed on all")
+	})
+}
+
+func Test_Pool_Pin(t *testing.T) {
+	lagging := &nodeMock{level: 90, bootstrapped: true}
+	synced := &nodeMock{level: 100, bootstrapped: true}
+	pool, hosts := newPoolMock(t, PoolOptions{}, lagging, synced)
+
+	for i := 0; i < 3; i++ {
+		client := pool.Pin()
+		assert.Equal(t, hosts[1], client.host)
+
+		_, err := client.ChainID()
+		assert.Nil(t, err)
+	}
+
+	assert.Equal(t, int32(0), lagging.requests)
+	assert.Equal(t, int32(3), synced.requests)
+}
+
+func Test_Pool_VoteListings(t *testing.T) {
+	pool, _ := newPoolMock(t, PoolOptions{}, &nodeMock{level: 100, bootstrapped: true})
+
+	listings, err := pool.VoteListings(mockBlockHash)
+	assert.Nil(t, err)
+	assert.Equal(t, getResponse(voteListings).(Listings), listings)
+}
+
+func Test_Pool_Mempool(t *testing.T) {
+	operationHash := "ooYYiTNU4ttrDHjQq1Ydx6fvSZBDHBgSDpbXgvBDWSMHKBvzFNh"
+
+	t.Run("broadcasts changes to all nodes", func(t *testing.T) {
+		first := &nodeMock{level: 100, bootstrapped: true}
+		second := &nodeMock{level: 100, bootstrapped: true}
+		pool, _ := newPoolMock(t, PoolOptions{}, first, second)
+
+		assert.Nil(t, pool.BanOperation(operationHash))
+		assert.Equal(t, int32(1), first.mempool)
+		assert.Equal(t, int32(1), second.mempool)
+	})
+
+	t.Run("fails if a node fails", func(t *testing.T) {
+		working := &nodeMock{level: 100, bootstrapped: true}
+		failing := &nodeMock{level: 100, bootstrapped: true, status: http.StatusInternalServerError}
+		pool, hosts := newPoolMock(t, PoolOptions{}, working, failing)
+
+		err := pool.BanOperation(operationHash)
+		checkErr(t, true, "failed on 1 of 2 nodes ("+hosts[1]+")", err)
+		assert.Equal(t, int32(1), working.mempool)
+		assert.Equal(t, int32(1), failing.mempool)
+	})
+
+	t.Run("reads from the pinned node", func(t *testing.T) {
+		lagging := &nodeMock{level: 90, bootstrapped: true}
+		synced := &nodeMock{level: 100, bootstrapped: true}
+		pool, _ := newPoolMock(t, PoolOptions{}, lagging, synced)
+
+		filter, err := pool.MempoolFilter()
+		assert.Nil(t, err)
+		assert.Equal(t, "100", filter.MinimalFees)
+		assert.Equal(t, int32(0), lagging.mempool)
+		assert.Equal(t, int32(1), synced.mempool)
+	})
+}
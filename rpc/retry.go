@@ -0,0 +1,174 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*
+RetryPolicy configures how the Client retries requests that fail with a transient error.
+
+Note:
+	GET and DELETE requests and read-only POST requests (e.g. run_operation, preapply or forge) are retried. Injections
+	(/injection/... and /private/injection/...) are not idempotent, a retried injection could be applied twice, so
+	they are only retried if RetryInjection is set.
+
+	The backoff before attempt n+1 is a random duration between 0 and min(MaxBackoff, MinBackoff * 2^(n-1)).
+*/
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a request, including the first. Less than 2 disables retries.
+	MaxAttempts int
+	// MinBackoff is the upper bound of the backoff before the first retry.
+	MinBackoff time.Duration
+	// MaxBackoff caps the backoff between attempts.
+	MaxBackoff time.Duration
+	// RetryableStatusCodes are the HTTP status codes the request is retried on.
+	RetryableStatusCodes []int
+	// RetryableError decides if the request is retried on an error completing it, IsRetryableError if nil.
+	RetryableError func(err error) bool
+	// RetryInjection enables retrying injections.
+	RetryInjection bool
+}
+
+/*
+DefaultRetryPolicy returns the RetryPolicy used by a Client created with New, which makes up to 3 attempts of a request
+on connection errors and on 429, 502, 503 and 504 responses.
+*/
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  200 * time.Millisecond,
+		MaxBackoff:  5 * time.Second,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+/*
+IsRetryableError returns true if err is a transient error completing a request: a timeout, a refused, reset or
+aborted connection, or a connection closed before the response was read.
+
+Parameters:
+
+	err:
+		The error completing the request.
+*/
+func IsRetryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return false
+}
+
+// allows returns if a request with method to the RPC path, without the host, may be retried.
+func (r *RetryPolicy) allows(method, path string) bool {
+	if r.MaxAttempts < 2 {
+		return false
+	}
+
+	if method == http.MethodPost && isInjection(path) {
+		return r.RetryInjection
+	}
+
+	return true
+}
+
+// isInjection returns if the RPC path, without the host, injects an operation, block or protocol.
+func isInjection(path string) bool {
+	return strings.HasPrefix(path, "/injection/") || strings.HasPrefix(path, "/private/injection/")
+}
+
+// transient returns if err, returned by a request, is a retryable error or a response with a retryable status code.
+func (r *RetryPolicy) transient(err error) bool {
+	var serr *statusError
+	if errors.As(err, &serr) {
+		return r.retryableStatus(serr.code)
+	}
+
+	return r.retryableError(err)
+}
+
+func (r *RetryPolicy) retryableStatus(code int) bool {
+	for _, c := range r.RetryableStatusCodes {
+		if c == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *RetryPolicy) retryableError(err error) bool {
+	if r.RetryableError != nil {
+		return r.RetryableError(err)
+	}
+
+	return IsRetryableError(err)
+}
+
+// backoff returns the backoff before the retry following attempt (starting at 1).
+func (r *RetryPolicy) backoff(attempt int) time.Duration {
+	max := r.MinBackoff
+	for i := 1; i < attempt && max < r.MaxBackoff; i++ {
+		max *= 2
+	}
+
+	if r.MaxBackoff > 0 && max > r.MaxBackoff {
+		max = r.MaxBackoff
+	}
+
+	if max <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(max) + 1))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// statusError is the error of a request the node responded to with a status code other than 200.
+type statusError struct {
+	code int
+	body []byte
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("response returned code %d with body %s", e.code, string(e.body))
+}
@@ -0,0 +1,236 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RetryPolicy(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:          3,
+		MinBackoff:           time.Millisecond,
+		MaxBackoff:           5 * time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusBadGateway},
+	}
+
+	type input struct {
+		policy   RetryPolicy
+		failures int
+		status   int
+		hijack   bool
+		request  func(c *Client) ([]byte, error)
+	}
+
+	type want struct {
+		err         bool
+		containsErr string
+		attempts    int
+	}
+
+	get := func(c *Client) ([]byte, error) {
+		return c.get(context.Background(), "/some/endpoint")
+	}
+
+	inject := func(c *Client) ([]byte, error) {
+		return c.post(context.Background(), "/injection/operation", []byte(`"some_operation"`))
+	}
+
+	privateInject := func(c *Client) ([]byte, error) {
+		return c.post(context.Background(), "/private/injection/operation", []byte(`"some_operation"`))
+	}
+
+	runOperation := func(c *Client) ([]byte, error) {
+		return c.post(context.Background(), "/chains/main/blocks/head/helpers/scripts/run_operation", []byte(`"some_operation"`))
+	}
+
+	withInjection := policy
+	withInjection.RetryInjection = true
+
+	cases := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			"retries retryable status code",
+			input{policy, 2, http.StatusBadGateway, false, get},
+			want{false, "", 3},
+		},
+		{
+			"gives up after max attempts",
+			input{policy, 3, http.StatusBadGateway, false, get},
+			want{true, "response returned code 502", 3},
+		},
+		{
+			"does not retry other status codes",
+			input{policy, 1, http.StatusInternalServerError, false, get},
+			want{true, "response returned code 500", 1},
+		},
+		{
+			"retries dropped connections",
+			input{policy, 2, 0, true, get},
+			want{false, "", 3},
+		},
+		{
+			"retries read-only posts",
+			input{policy, 1, http.StatusBadGateway, false, runOperation},
+			want{false, "", 2},
+		},
+		{
+			"does not retry injection",
+			input{policy, 1, http.StatusBadGateway, false, inject},
+			want{true, "response returned code 502", 1},
+		},
+		{
+			"retries injection if enabled",
+			input{withInjection, 1, http.StatusBadGateway, false, inject},
+			want{false, "", 2},
+		},
+		{
+			"does not retry private injection",
+			input{policy, 1, http.StatusBadGateway, false, privateInject},
+			want{true, "response returned code 502", 1},
+		},
+		{
+			"does not retry with retries disabled",
+			input{RetryPolicy{}, 1, http.StatusBadGateway, false, get},
+			want{true, "response returned code 502", 1},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+
+				if r.Method == http.MethodPost {
+					body, _ := ioutil.ReadAll(r.Body)
+					assert.Equal(t, `"some_operation"`, string(body))
+				}
+
+				if attempts <= tt.input.failures {
+					if tt.input.hijack {
+						conn, _, _ := w.(http.Hijacker).Hijack()
+						conn.Close()
+						return
+					}
+					w.WriteHeader(tt.input.status)
+					return
+				}
+
+				w.Write([]byte("success"))
+			}))
+			defer server.Close()
+
+			c := &Client{client: &http.Client{}, host: server.URL, chain: "main"}
+			c.SetRetryPolicy(tt.input.policy)
+
+			resp, err := tt.input.request(c)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.attempts, attempts)
+			if !tt.want.err {
+				assert.Equal(t, []byte("success"), resp)
+			}
+		})
+	}
+}
+
+func Test_RetryPolicy_HostPath(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:          3,
+		MinBackoff:           time.Millisecond,
+		MaxBackoff:           5 * time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusBadGateway},
+	}
+
+	withInjection := policy
+	withInjection.RetryInjection = true
+
+	cases := []struct {
+		name     string
+		policy   RetryPolicy
+		path     string
+		attempts int
+	}{
+		{"does not retry injection", policy, "/injection/operation", 1},
+		{"does not retry private injection", policy, "/private/injection/operation", 1},
+		{"retries injection if enabled", withInjection, "/injection/operation", 3},
+		{"retries read-only posts", policy, "/chains/main/blocks/head/helpers/scripts/run_operation", 3},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int
+			mux := http.NewServeMux()
+			mux.HandleFunc("/mainnet"+tt.path, func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.WriteHeader(http.StatusBadGateway)
+			})
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			c := &Client{client: &http.Client{}, host: cleanseHost(server.URL + "/mainnet"), chain: "main"}
+			c.SetRetryPolicy(tt.policy)
+
+			_, err := c.post(context.Background(), tt.path, []byte(`"some_operation"`))
+			checkErr(t, true, "response returned code 502", err)
+			assert.Equal(t, tt.attempts, attempts)
+		})
+	}
+}
+
+func Test_RetryPolicy_Context(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := &Client{client: &http.Client{}, host: server.URL, chain: "main"}
+	c.SetRetryPolicy(RetryPolicy{
+		MaxAttempts:          10,
+		MinBackoff:           time.Hour,
+		MaxBackoff:           time.Hour,
+		RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := c.get(ctx, "/some/endpoint")
+	checkErr(t, true, "stopped retrying request", err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func Test_IsRetryableError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"retries connection refused", &url.Error{Op: "Get", Err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}}, true},
+		{"retries connection reset", &net.OpError{Op: "read", Err: syscall.ECONNRESET}, true},
+		{"retries unexpected EOF", errors.Wrap(io.ErrUnexpectedEOF, "could not read response body"), true},
+		{"retries timeouts", &net.DNSError{IsTimeout: true}, true},
+		{"does not retry canceled context", &url.Error{Op: "Get", Err: context.Canceled}, false},
+		{"does not retry other errors", errors.New("some error"), false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRetryableError(tt.err))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package signer
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/goat-systems/go-tezos/v3/keys"
+)
+
+// KeyStore provides the keys a Server signs with.
+type KeyStore interface {
+	// Get returns the signer of the public key hash passed, or an error if the key is unknown.
+	Get(pkh string) (keys.Signer, error)
+}
+
+var _ KeyStore = &MemoryKeyStore{}
+
+// MemoryKeyStore is a KeyStore holding signers in memory.
+type MemoryKeyStore struct {
+	mu      sync.RWMutex
+	signers map[string]keys.Signer
+}
+
+// NewMemoryKeyStore returns a MemoryKeyStore containing the signers passed.
+func NewMemoryKeyStore(signers ...keys.Signer) *MemoryKeyStore {
+	m := &MemoryKeyStore{
+		signers: map[string]keys.Signer{},
+	}
+
+	for _, signer := range signers {
+		m.Add(signer)
+	}
+
+	return m
+}
+
+// Add adds or replaces a signer in the MemoryKeyStore.
+func (m *MemoryKeyStore) Add(signer keys.Signer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.signers[signer.PublicKeyHash()] = signer
+}
+
+// Remove removes the signer of the public key hash passed from the MemoryKeyStore.
+func (m *MemoryKeyStore) Remove(pkh string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.signers, pkh)
+}
+
+// Get returns the signer of the public key hash passed.
+func (m *MemoryKeyStore) Get(pkh string) (keys.Signer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	signer, ok := m.signers[pkh]
+	if !ok {
+		return nil, fmt.Errorf("unknown key '%s'", pkh)
+	}
+
+	return signer, nil
+}
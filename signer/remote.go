@@ -0,0 +1,280 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/goat-systems/go-tezos/v3/address"
+	"github.com/goat-systems/go-tezos/v3/keys"
+	"github.com/pkg/errors"
+)
+
+var _ keys.Signer = &Remote{}
+
+/*
+NewRemoteInput is the input for the signer.NewRemote function.
+
+Note:
+	Authentication is only required if the remote signer was started with authorized keys
+	(octez-signer --require-authentication). Client defaults to an http.Client with a 10 second timeout.
+
+Function:
+	func NewRemote(input NewRemoteInput) (*Remote, error) {}
+*/
+type NewRemoteInput struct {
+	Host           string `validate:"required"`
+	PublicKeyHash  string `validate:"required"`
+	Authentication keys.Signer
+	Client         *http.Client
+}
+
+/*
+Remote is a keys.Signer backed by a remote signer speaking the octez signer HTTP protocol.
+
+Link:
+	https://tezos.gitlab.io/user/key-management.html#signer
+*/
+type Remote struct {
+	client         *http.Client
+	host           string
+	pkh            string
+	authentication keys.Signer
+
+	mu     sync.Mutex
+	pubKey *keys.PubKey
+}
+
+/*
+NewRemote returns a Remote signer for the public key hash passed.
+
+Parameters:
+
+	input:
+		Modifies the remote signer host, key and authentication.
+*/
+func NewRemote(input NewRemoteInput) (*Remote, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	client := input.Client
+	if client == nil {
+		client = &http.Client{
+			Timeout: time.Second * 10,
+			Transport: &http.Transport{
+				Dial: (&net.Dialer{
+					Timeout: 10 * time.Second,
+				}).Dial,
+				TLSHandshakeTimeout: 10 * time.Second,
+			},
+		}
+	}
+
+	return &Remote{
+		client:         client,
+		host:           cleanseHost(input.Host),
+		pkh:            input.PublicKeyHash,
+		authentication: input.Authentication,
+	}, nil
+}
+
+/*
+PublicKey returns the public key of the remote signer's key. The public key is cached after the first call.
+
+Path:
+	/keys/<pkh> (GET)
+*/
+func (r *Remote) PublicKey() (keys.PubKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pubKey != nil {
+		return *r.pubKey, nil
+	}
+
+	resp, err := r.do(http.MethodGet, fmt.Sprintf("/keys/%s", r.pkh), nil)
+	if err != nil {
+		return keys.PubKey{}, errors.Wrap(err, "failed to get public key")
+	}
+
+	var publicKey struct {
+		PublicKey string `json:"public_key"`
+	}
+	if err := json.Unmarshal(resp, &publicKey); err != nil {
+		return keys.PubKey{}, errors.Wrap(err, "failed to unmarshal public key")
+	}
+
+	kind, err := keys.KindFromPrefix(publicKey.PublicKey)
+	if err != nil {
+		return keys.PubKey{}, errors.Wrap(err, "failed to get public key")
+	}
+
+	pubKey, err := keys.NewPubKey(keys.NewPubKeyInput{
+		String: publicKey.PublicKey,
+		Kind:   kind,
+	})
+	if err != nil {
+		return keys.PubKey{}, errors.Wrap(err, "failed to get public key")
+	}
+
+	if pubKey.GetPublicKeyHash() != r.pkh {
+		return keys.PubKey{}, fmt.Errorf("failed to get public key: remote signer returned public key for '%s'", pubKey.GetPublicKeyHash())
+	}
+
+	r.pubKey = &pubKey
+	return pubKey, nil
+}
+
+// PublicKeyHash returns the public key hash (address) of the remote signer's key.
+func (r *Remote) PublicKeyHash() string {
+	return r.pkh
+}
+
+/*
+SignBytes asks the remote signer to sign already watermarked bytes and verifies the signature returned.
+
+Path:
+	/keys/<pkh> (POST)
+*/
+func (r *Remote) SignBytes(msg []byte) (keys.Signature, error) {
+	pubKey, err := r.PublicKey()
+	if err != nil {
+		return keys.Signature{}, errors.Wrap(err, "failed to sign bytes")
+	}
+
+	body, err := json.Marshal(hex.EncodeToString(msg))
+	if err != nil {
+		return keys.Signature{}, errors.Wrap(err, "failed to sign bytes")
+	}
+
+	path := fmt.Sprintf("/keys/%s", r.pkh)
+	if r.authentication != nil {
+		authentication, err := r.authenticate(msg)
+		if err != nil {
+			return keys.Signature{}, errors.Wrap(err, "failed to sign bytes")
+		}
+		path = fmt.Sprintf("%s?authentication=%s", path, authentication)
+	}
+
+	resp, err := r.do(http.MethodPost, path, body)
+	if err != nil {
+		return keys.Signature{}, errors.Wrap(err, "failed to sign bytes")
+	}
+
+	var signature struct {
+		Signature string `json:"signature"`
+	}
+	if err := json.Unmarshal(resp, &signature); err != nil {
+		return keys.Signature{}, errors.Wrap(err, "failed to unmarshal signature")
+	}
+
+	sig, err := keys.ParseSignature(signature.Signature)
+	if err != nil {
+		return keys.Signature{}, errors.Wrap(err, "failed to sign bytes")
+	}
+
+	if !pubKey.Verify(keys.VerifyInput{BytesData: msg, BytesSignature: sig.Bytes, Watermark: keys.WatermarkNone}) {
+		return keys.Signature{}, errors.New("failed to sign bytes: remote signer returned an invalid signature")
+	}
+
+	return sig, nil
+}
+
+/*
+AuthorizedKeys returns the public key hashes allowed to authenticate requests to the remote signer. It returns nil
+if the remote signer does not require authentication.
+
+Path:
+	/authorized_keys (GET)
+*/
+func (r *Remote) AuthorizedKeys() ([]string, error) {
+	resp, err := r.do(http.MethodGet, "/authorized_keys", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get authorized keys")
+	}
+
+	var authorizedKeys struct {
+		AuthorizedKeys []string `json:"authorized_keys"`
+	}
+	if err := json.Unmarshal(resp, &authorizedKeys); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal authorized keys")
+	}
+
+	return authorizedKeys.AuthorizedKeys, nil
+}
+
+// authenticate signs the authentication payload 0x04 || pkh || data expected by the remote signer.
+func (r *Remote) authenticate(msg []byte) (string, error) {
+	pkh, err := forgePublicKeyHash(r.pkh)
+	if err != nil {
+		return "", err
+	}
+
+	payload := append([]byte{4}, pkh...)
+	payload = append(payload, msg...)
+
+	sig, err := r.authentication.SignBytes(payload)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign authentication payload")
+	}
+
+	return sig.ToBase58(), nil
+}
+
+func (r *Remote) do(method string, path string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, fmt.Sprintf("%s%s", r.host, path), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to construct request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to complete request")
+	}
+	defer resp.Body.Close()
+
+	byts, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return byts, errors.Wrap(err, "could not read response body")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return byts, fmt.Errorf("response returned code %d with body %s", resp.StatusCode, string(byts))
+	}
+
+	return byts, nil
+}
+
+// forgePublicKeyHash returns the binary representation of a public key hash: a curve tag followed by the 20 byte hash.
+func forgePublicKeyHash(pkh string) ([]byte, error) {
+	addr, err := address.Parse(pkh)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "invalid public key hash")
+	}
+
+	return addr.PublicKeyHashBytes()
+}
+
+func cleanseHost(host string) string {
+	if len(host) == 0 {
+		return ""
+	}
+	if host[len(host)-1] == '/' {
+		host = host[:len(host)-1]
+	}
+	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		host = fmt.Sprintf("http://%s", host) //default to http
+	}
+	return host
+}
@@ -0,0 +1,150 @@
+package signer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v3/internal/testutils"
+	"github.com/goat-systems/go-tezos/v3/keys"
+	"github.com/stretchr/testify/assert"
+)
+
+func octezSignerMock(t *testing.T, key keys.Key, auth *keys.Key) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/authorized_keys":
+			if auth == nil {
+				w.Write([]byte(`{}`))
+				return
+			}
+			w.Write([]byte(fmt.Sprintf(`{"authorized_keys":["%s"]}`, auth.PubKey.GetPublicKeyHash())))
+		case r.URL.Path == fmt.Sprintf("/keys/%s", key.PubKey.GetPublicKeyHash()) && r.Method == http.MethodGet:
+			w.Write([]byte(fmt.Sprintf(`{"public_key":"%s"}`, key.PubKey.GetPublicKey())))
+		case r.URL.Path == fmt.Sprintf("/keys/%s", key.PubKey.GetPublicKeyHash()) && r.Method == http.MethodPost:
+			body, _ := ioutil.ReadAll(r.Body)
+			var data string
+			assert.Nil(t, json.Unmarshal(body, &data))
+			msg, _ := hex.DecodeString(data)
+
+			if auth != nil {
+				pkh, _ := forgePublicKeyHash(key.PubKey.GetPublicKeyHash())
+				payload := append(append([]byte{4}, pkh...), msg...)
+				if !auth.PubKey.Verify(keys.VerifyInput{Data: string(payload), Signature: r.URL.Query().Get("authentication"), Watermark: keys.WatermarkNone}) {
+					w.WriteHeader(http.StatusForbidden)
+					w.Write([]byte(`[{"kind":"temporary","id":"unauthorized_remote_signer_request"}]`))
+					return
+				}
+			}
+
+			sig, _ := key.SignBytes(msg)
+			w.Write([]byte(fmt.Sprintf(`{"signature":"%s"}`, sig.ToBase58())))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func Test_Remote(t *testing.T) {
+	key, err := keys.NewKey(keys.NewKeyInput{
+		EncodedString: "spsk2rBDDeUqakQ42nBHDGQTtP3GErb6AahHPwF9bhca3Q5KA5HESE",
+		Kind:          keys.Secp256k1,
+	})
+	assert.Nil(t, err)
+
+	auth, err := keys.NewKey(keys.NewKeyInput{
+		EncodedString: "edskRxB2DmoyZSyvhsqaJmw5CK6zYT7dbkUfEVSiQeWU1gw3ZMnC99QMMXru3imsbUrLhvuHktrymvNqhMxkhz7Y4LJAtevW5V",
+		Kind:          keys.Ed25519,
+	})
+	assert.Nil(t, err)
+
+	type want struct {
+		wantErr        bool
+		containsErr    string
+		authorizedKeys []string
+	}
+
+	cases := []struct {
+		name   string
+		server *keys.Key
+		input  NewRemoteInput
+		want   want
+	}{
+		{
+			"is successful without authentication",
+			nil,
+			NewRemoteInput{
+				PublicKeyHash: key.PubKey.GetPublicKeyHash(),
+			},
+			want{
+				false,
+				"",
+				nil,
+			},
+		},
+		{
+			"is successful with authentication",
+			&auth,
+			NewRemoteInput{
+				PublicKeyHash:  key.PubKey.GetPublicKeyHash(),
+				Authentication: &auth,
+			},
+			want{
+				false,
+				"",
+				[]string{auth.PubKey.GetPublicKeyHash()},
+			},
+		},
+		{
+			"handles missing authentication",
+			&auth,
+			NewRemoteInput{
+				PublicKeyHash: key.PubKey.GetPublicKeyHash(),
+			},
+			want{
+				true,
+				"response returned code 403",
+				[]string{auth.PubKey.GetPublicKeyHash()},
+			},
+		},
+		{
+			"handles unknown key",
+			nil,
+			NewRemoteInput{
+				PublicKeyHash: "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1",
+			},
+			want{
+				true,
+				"failed to get public key",
+				nil,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(octezSignerMock(t, key, tt.server))
+			defer server.Close()
+
+			tt.input.Host = server.URL
+			remote, err := NewRemote(tt.input)
+			assert.Nil(t, err)
+
+			authorizedKeys, err := remote.AuthorizedKeys()
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want.authorizedKeys, authorizedKeys)
+
+			msg := []byte{3, 0x12, 0x34}
+			sig, err := remote.SignBytes(msg)
+			testutils.CheckErr(t, tt.want.wantErr, tt.want.containsErr, err)
+			if !tt.want.wantErr {
+				assert.True(t, key.Verify(keys.VerifyInput{BytesData: msg, BytesSignature: sig.Bytes, Watermark: keys.WatermarkNone}))
+				assert.Equal(t, key.PubKey.GetPublicKeyHash(), remote.PublicKeyHash())
+			}
+		})
+	}
+}
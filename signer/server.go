@@ -0,0 +1,242 @@
+package signer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/goat-systems/go-tezos/v3/keys"
+	"github.com/pkg/errors"
+)
+
+var _ http.Handler = &Server{}
+
+const (
+	// maxSignedDataLength is the length of the largest data signed, an operation of the maximum size accepted
+	// by the node (32KiB) with its watermark.
+	maxSignedDataLength = 32*1024 + 5
+	// maxSignRequestLength is the length of the body of a sign request for the largest data signed, a JSON hex string.
+	maxSignRequestLength = 2*maxSignedDataLength + 64
+)
+
+/*
+NewServerInput is the input for the signer.NewServer function.
+
+Note:
+	If AuthorizedKeys is set, sign requests must be authenticated by one of them. If HighWatermark is set,
+	blocks, preendorsements and endorsements are only signed above the key's high watermark. If MagicBytes
+	is set, only data starting with one of them is signed (e.g. []byte{0x11, 0x12, 0x13} for a baker).
+
+Function:
+	func NewServer(input NewServerInput) (*Server, error) {}
+*/
+type NewServerInput struct {
+	KeyStore       KeyStore `validate:"required"`
+	AuthorizedKeys []keys.PubKey
+	HighWatermark  *HighWatermark
+	MagicBytes     []byte
+}
+
+/*
+Server is an http.Handler implementing the octez signer HTTP protocol.
+
+RPC:
+	/keys/<pkh> (GET)
+	/keys/<pkh> (POST)
+	/authorized_keys (GET)
+
+Link:
+	https://tezos.gitlab.io/user/key-management.html#signer
+*/
+type Server struct {
+	keyStore       KeyStore
+	authorizedKeys []keys.PubKey
+	highWatermark  *HighWatermark
+	magicBytes     []byte
+}
+
+/*
+NewServer returns a new Server.
+
+Parameters:
+
+	input:
+		Modifies the keys served, authentication, high watermark and magic bytes of the Server.
+*/
+func NewServer(input NewServerInput) (*Server, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	return &Server{
+		keyStore:       input.KeyStore,
+		authorizedKeys: input.AuthorizedKeys,
+		highWatermark:  input.HighWatermark,
+		magicBytes:     input.MagicBytes,
+	}, nil
+}
+
+// ServeHTTP serves the octez signer HTTP protocol.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/authorized_keys" && r.Method == http.MethodGet:
+		s.getAuthorizedKeys(w)
+	case strings.HasPrefix(r.URL.Path, "/keys/") && r.Method == http.MethodGet:
+		s.getPublicKey(w, strings.TrimPrefix(r.URL.Path, "/keys/"))
+	case strings.HasPrefix(r.URL.Path, "/keys/") && r.Method == http.MethodPost:
+		s.sign(w, r, strings.TrimPrefix(r.URL.Path, "/keys/"))
+	default:
+		writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) getAuthorizedKeys(w http.ResponseWriter) {
+	if len(s.authorizedKeys) == 0 {
+		writeJSON(w, struct{}{})
+		return
+	}
+
+	authorizedKeys := []string{}
+	for _, k := range s.authorizedKeys {
+		authorizedKeys = append(authorizedKeys, k.GetPublicKeyHash())
+	}
+
+	writeJSON(w, struct {
+		AuthorizedKeys []string `json:"authorized_keys"`
+	}{authorizedKeys})
+}
+
+func (s *Server) getPublicKey(w http.ResponseWriter, pkh string) {
+	signer, err := s.keyStore.Get(pkh)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "unknown_key", err.Error())
+		return
+	}
+
+	pubKey, err := signer.PublicKey()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "public_key", err.Error())
+		return
+	}
+
+	writeJSON(w, struct {
+		PublicKey string `json:"public_key"`
+	}{pubKey.GetPublicKey()})
+}
+
+func (s *Server) sign(w http.ResponseWriter, r *http.Request, pkh string) {
+	signer, err := s.keyStore.Get(pkh)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "unknown_key", err.Error())
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxSignRequestLength))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, "invalid_request", err.Error())
+		return
+	}
+
+	var input string
+	if err := json.Unmarshal(body, &input); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "body must be a json hex string")
+		return
+	}
+
+	data, err := hex.DecodeString(input)
+	if err != nil || len(data) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request", "body must be a json hex string")
+		return
+	}
+
+	if !isMagicByte(data[0], s.magicBytes) {
+		writeError(w, http.StatusForbidden, "unauthorized_magic_byte", fmt.Sprintf("magic byte 0x%02x is not allowed", data[0]))
+		return
+	}
+
+	if err := s.authenticate(pkh, data, r.URL.Query().Get("authentication")); err != nil {
+		writeError(w, http.StatusForbidden, "unauthorized_remote_signer_request", err.Error())
+		return
+	}
+
+	signFn := func() (string, error) {
+		sig, err := signer.SignBytes(data)
+		if err != nil {
+			return "", err
+		}
+		return sig.ToBase58(), nil
+	}
+
+	var signature string
+	if s.highWatermark != nil {
+		if _, tracked := watermarkFiles[data[0]]; tracked {
+			if _, _, _, err := parseWatermarked(data); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+				return
+			}
+		}
+
+		signature, err = s.highWatermark.sign(pkh, data, signFn)
+	} else {
+		signature, err = signFn()
+	}
+	if err != nil {
+		writeError(w, http.StatusForbidden, "signing_refused", err.Error())
+		return
+	}
+
+	writeJSON(w, struct {
+		Signature string `json:"signature"`
+	}{signature})
+}
+
+// authenticate checks the authentication signature of 0x04 || pkh || data against the authorized keys.
+func (s *Server) authenticate(pkh string, data []byte, authentication string) error {
+	if len(s.authorizedKeys) == 0 {
+		return nil
+	}
+
+	if authentication == "" {
+		return errors.New("missing authentication")
+	}
+
+	sig, err := keys.ParseSignature(authentication)
+	if err != nil {
+		return err
+	}
+
+	forgedPkh, err := forgePublicKeyHash(pkh)
+	if err != nil {
+		return err
+	}
+
+	payload := append([]byte{4}, forgedPkh...)
+	payload = append(payload, data...)
+
+	for _, k := range s.authorizedKeys {
+		if k.Verify(keys.VerifyInput{BytesData: payload, BytesSignature: sig.Bytes, Watermark: keys.WatermarkNone}) {
+			return nil
+		}
+	}
+
+	return errors.New("authentication signature does not match any authorized key")
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, kind string, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode([]struct {
+		Kind string `json:"kind"`
+		Err  string `json:"error"`
+	}{{kind, msg}})
+}
@@ -0,0 +1,279 @@
+package signer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v3/internal/testutils"
+	"github.com/goat-systems/go-tezos/v3/keys"
+	"github.com/stretchr/testify/assert"
+)
+
+// preendorsement returns watermarked Tenderbake preendorsement bytes for a level and round.
+func preendorsement(level, round uint32) []byte {
+	data := append([]byte{0x12, 0x7a, 0x06, 0xa7, 0x70}, make([]byte, 32)...)
+	data = append(data, 20, 0, 0)
+	data = append(data, make([]byte, 8)...)
+	binary.BigEndian.PutUint32(data[40:44], level)
+	binary.BigEndian.PutUint32(data[44:48], round)
+	return append(data, make([]byte, 32)...)
+}
+
+// tenderbakeBlock returns watermarked Tenderbake block bytes with a fitness length and fitness.
+func tenderbakeBlock(fitnessLength uint32, fitness []byte) []byte {
+	data := append([]byte{0x11, 0x7a, 0x06, 0xa7, 0x70}, make([]byte, 4+1+32+8+1+32+4)...)
+	binary.BigEndian.PutUint32(data[5:9], 10)
+	binary.BigEndian.PutUint32(data[83:87], fitnessLength)
+	return append(data, fitness...)
+}
+
+func Test_Server(t *testing.T) {
+	key, err := keys.NewKey(keys.NewKeyInput{
+		EncodedString: "p2sk2obfVMEuPUnadAConLWk7Tf4Dt3n4svSgJwrgpamRqJXvaYcg1",
+		Kind:          keys.NistP256,
+	})
+	assert.Nil(t, err)
+
+	auth, err := keys.NewKey(keys.NewKeyInput{
+		EncodedString: "edskRxB2DmoyZSyvhsqaJmw5CK6zYT7dbkUfEVSiQeWU1gw3ZMnC99QMMXru3imsbUrLhvuHktrymvNqhMxkhz7Y4LJAtevW5V",
+		Kind:          keys.Ed25519,
+	})
+	assert.Nil(t, err)
+
+	type want struct {
+		wantErr     bool
+		containsErr string
+	}
+
+	type request struct {
+		data []byte
+		want want
+	}
+
+	cases := []struct {
+		name     string
+		input    NewServerInput
+		remote   NewRemoteInput
+		requests []request
+	}{
+		{
+			"is successful",
+			NewServerInput{},
+			NewRemoteInput{},
+			[]request{
+				{[]byte{3, 0x12, 0x34}, want{false, ""}},
+			},
+		},
+		{
+			"is successful with authentication",
+			NewServerInput{
+				AuthorizedKeys: []keys.PubKey{auth.PubKey},
+			},
+			NewRemoteInput{
+				Authentication: &auth,
+			},
+			[]request{
+				{[]byte{3, 0x12, 0x34}, want{false, ""}},
+			},
+		},
+		{
+			"handles missing authentication",
+			NewServerInput{
+				AuthorizedKeys: []keys.PubKey{auth.PubKey},
+			},
+			NewRemoteInput{},
+			[]request{
+				{[]byte{3, 0x12, 0x34}, want{true, "missing authentication"}},
+			},
+		},
+		{
+			"handles unauthorized magic bytes",
+			NewServerInput{
+				MagicBytes: []byte{0x11, 0x12, 0x13},
+			},
+			NewRemoteInput{},
+			[]request{
+				{[]byte{3, 0x12, 0x34}, want{true, "magic byte 0x03 is not allowed"}},
+				{preendorsement(10, 0), want{false, ""}},
+			},
+		},
+		{
+			"handles high watermark",
+			NewServerInput{},
+			NewRemoteInput{},
+			[]request{
+				{preendorsement(10, 1), want{false, ""}},
+				{preendorsement(10, 1), want{false, ""}},
+				{preendorsement(10, 0), want{true, "not above high watermark"}},
+				{preendorsement(9, 5), want{true, "not above high watermark"}},
+				{preendorsement(10, 2), want{false, ""}},
+				{preendorsement(11, 0), want{false, ""}},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "watermarks")
+			assert.Nil(t, err)
+			defer os.RemoveAll(dir)
+
+			tt.input.KeyStore = NewMemoryKeyStore(&key)
+			tt.input.HighWatermark, err = NewHighWatermark(dir)
+			assert.Nil(t, err)
+
+			server, err := NewServer(tt.input)
+			assert.Nil(t, err)
+
+			ts := httptest.NewServer(server)
+			defer ts.Close()
+
+			tt.remote.Host = ts.URL
+			tt.remote.PublicKeyHash = key.PubKey.GetPublicKeyHash()
+			remote, err := NewRemote(tt.remote)
+			assert.Nil(t, err)
+
+			for _, req := range tt.requests {
+				_, err := remote.SignBytes(req.data)
+				testutils.CheckErr(t, req.want.wantErr, req.want.containsErr, err)
+			}
+		})
+	}
+}
+
+func Test_HighWatermark_Persistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watermarks")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	hw, err := NewHighWatermark(dir)
+	assert.Nil(t, err)
+
+	signFn := func() (string, error) { return "sig", nil }
+	_, err = hw.sign("tz3Lfm6CyfSTZ7EgMckptZZGiPxzs9GK59At", preendorsement(42, 3), signFn)
+	assert.Nil(t, err)
+
+	reloaded, err := NewHighWatermark(dir)
+	assert.Nil(t, err)
+
+	w, ok := reloaded.Get(0x12, "NetXdQprcVkpaWU", "tz3Lfm6CyfSTZ7EgMckptZZGiPxzs9GK59At")
+	assert.True(t, ok)
+	assert.Equal(t, int32(42), w.Level)
+	assert.Equal(t, int32(3), w.Round)
+
+	_, err = reloaded.sign("tz3Lfm6CyfSTZ7EgMckptZZGiPxzs9GK59At", preendorsement(42, 2), signFn)
+	testutils.CheckErr(t, true, "not above high watermark", err)
+}
+
+func Test_HighWatermark_SaveFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watermarks")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	hw, err := NewHighWatermark(dir)
+	assert.Nil(t, err)
+
+	var signed int
+	signFn := func() (string, error) {
+		signed++
+		return fmt.Sprintf("sig%d", signed), nil
+	}
+
+	// a directory in place of the temporary file makes saving the watermark fail
+	tmp := filepath.Join(dir, ".preendorsement_high_watermarks.tmp")
+	assert.Nil(t, os.Mkdir(tmp, 0700))
+
+	_, err = hw.sign("tz3Lfm6CyfSTZ7EgMckptZZGiPxzs9GK59At", preendorsement(42, 3), signFn)
+	testutils.CheckErr(t, true, "failed to write high watermarks", err)
+
+	_, ok := hw.Get(0x12, "NetXdQprcVkpaWU", "tz3Lfm6CyfSTZ7EgMckptZZGiPxzs9GK59At")
+	assert.False(t, ok)
+
+	assert.Nil(t, os.Remove(tmp))
+
+	signature, err := hw.sign("tz3Lfm6CyfSTZ7EgMckptZZGiPxzs9GK59At", preendorsement(42, 3), signFn)
+	assert.Nil(t, err)
+	assert.Equal(t, "sig2", signature)
+
+	reloaded, err := NewHighWatermark(dir)
+	assert.Nil(t, err)
+
+	w, ok := reloaded.Get(0x12, "NetXdQprcVkpaWU", "tz3Lfm6CyfSTZ7EgMckptZZGiPxzs9GK59At")
+	assert.True(t, ok)
+	assert.Equal(t, "sig2", w.Signature)
+}
+
+func Test_Server_RequestTooLarge(t *testing.T) {
+	key, err := keys.NewKey(keys.NewKeyInput{
+		EncodedString: "p2sk2obfVMEuPUnadAConLWk7Tf4Dt3n4svSgJwrgpamRqJXvaYcg1",
+		Kind:          keys.NistP256,
+	})
+	assert.Nil(t, err)
+
+	server, err := NewServer(NewServerInput{KeyStore: NewMemoryKeyStore(&key)})
+	assert.Nil(t, err)
+
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	body := fmt.Sprintf(`"03%s"`, strings.Repeat("00", maxSignRequestLength/2))
+	resp, err := http.Post(fmt.Sprintf("%s/keys/%s", ts.URL, key.PubKey.GetPublicKeyHash()), "application/json", strings.NewReader(body))
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+
+	body = fmt.Sprintf(`"03%s"`, strings.Repeat("00", maxSignedDataLength-1))
+	resp, err = http.Post(fmt.Sprintf("%s/keys/%s", ts.URL, key.PubKey.GetPublicKeyHash()), "application/json", strings.NewReader(body))
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func Test_Server_InvalidFitness(t *testing.T) {
+	key, err := keys.NewKey(keys.NewKeyInput{
+		EncodedString: "p2sk2obfVMEuPUnadAConLWk7Tf4Dt3n4svSgJwrgpamRqJXvaYcg1",
+		Kind:          keys.NistP256,
+	})
+	assert.Nil(t, err)
+
+	dir, err := ioutil.TempDir("", "watermarks")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	watermark, err := NewHighWatermark(dir)
+	assert.Nil(t, err)
+
+	server, err := NewServer(NewServerInput{KeyStore: NewMemoryKeyStore(&key), HighWatermark: watermark})
+	assert.Nil(t, err)
+
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	cases := []struct {
+		name   string
+		data   []byte
+		status int
+	}{
+		{"is successful with valid fitness", tenderbakeBlock(8, []byte{0, 0, 0, 1, 0, 0, 0, 2}), http.StatusOK},
+		{"handles negative fitness length", tenderbakeBlock(0xFFFFFF9C, make([]byte, 8)), http.StatusBadRequest},
+		{"handles oversized fitness length", tenderbakeBlock(1000, make([]byte, 8)), http.StatusBadRequest},
+		{"handles fitness length without round", tenderbakeBlock(2, make([]byte, 8)), http.StatusBadRequest},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			body := fmt.Sprintf(`"%x"`, tt.data)
+			resp, err := http.Post(fmt.Sprintf("%s/keys/%s", ts.URL, key.PubKey.GetPublicKeyHash()), "application/json", strings.NewReader(body))
+			assert.Nil(t, err)
+			resp.Body.Close()
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/goat-systems/go-tezos/v3/b58"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+// watermarked kinds of data tracked by a HighWatermark, identified by their magic byte.
+const (
+	magicBlock             byte = 0x01
+	magicEndorsement       byte = 0x02
+	magicTenderbakeBlock   byte = 0x11
+	magicPreendorsement    byte = 0x12
+	magicTenderbakeEndorse byte = 0x13
+)
+
+var watermarkFiles = map[byte]string{
+	magicBlock:             "block_high_watermarks",
+	magicEndorsement:       "endorsement_high_watermarks",
+	magicTenderbakeBlock:   "block_high_watermarks",
+	magicPreendorsement:    "preendorsement_high_watermarks",
+	magicTenderbakeEndorse: "endorsement_high_watermarks",
+}
+
+/*
+Watermark is the highest level and round signed by a key for a kind of data on a chain.
+
+Note:
+	Hash is the hex encoded blake2b hash of the signed bytes, watermark included, and not a base58 block or
+	operation hash, so the files are not interchangeable with the octez-signer high watermark files.
+*/
+type Watermark struct {
+	Level     int32  `json:"level"`
+	Round     int32  `json:"round"`
+	Hash      string `json:"hash"`
+	Signature string `json:"signature"`
+}
+
+// watermarks are indexed by chain id then public key hash.
+type watermarks map[string]map[string]Watermark
+
+/*
+HighWatermark tracks, per key, the highest block, preendorsement and endorsement level and round signed,
+and persists them to disk before returning a signature so a Server never double signs, even after a restart.
+*/
+type HighWatermark struct {
+	mu    sync.Mutex
+	dir   string
+	files map[string]watermarks
+}
+
+/*
+NewHighWatermark returns a HighWatermark persisted in dir, loading any watermarks already present.
+
+Parameters:
+
+	dir:
+		The directory the watermark files are read from and written to.
+*/
+func NewHighWatermark(dir string) (*HighWatermark, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "failed to create high watermark directory")
+	}
+
+	h := &HighWatermark{
+		dir:   dir,
+		files: map[string]watermarks{},
+	}
+
+	for _, file := range watermarkFiles {
+		if _, ok := h.files[file]; ok {
+			continue
+		}
+
+		w := watermarks{}
+		v, err := ioutil.ReadFile(filepath.Join(dir, file))
+		if err == nil {
+			if err := json.Unmarshal(v, &w); err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal high watermark file '%s'", file)
+			}
+		} else if !os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, "failed to read high watermark file '%s'", file)
+		}
+		h.files[file] = w
+	}
+
+	return h, nil
+}
+
+// Get returns the high watermark of a key for a kind of data (identified by its magic byte) on a chain.
+func (h *HighWatermark) Get(magic byte, chainID string, pkh string) (Watermark, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	file, ok := watermarkFiles[magic]
+	if !ok {
+		return Watermark{}, false
+	}
+
+	w, ok := h.files[file][chainID][pkh]
+	return w, ok
+}
+
+/*
+sign calls signFn if the data passed is above the key's high watermark and records the new watermark.
+Data that is not tracked (e.g. operations) is signed without checks. Signing the exact same data twice
+returns the previous signature. If the new watermark can't be saved, no signature is returned and the
+watermark is left unchanged.
+*/
+func (h *HighWatermark) sign(pkh string, data []byte, signFn func() (string, error)) (string, error) {
+	file, ok := watermarkFiles[data[0]]
+	if !ok {
+		return signFn()
+	}
+
+	chainID, level, round, err := parseWatermarked(data)
+	if err != nil {
+		return "", err
+	}
+
+	digest := blake2b.Sum256(data)
+	hash := hex.EncodeToString(digest[:])
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if previous, ok := h.files[file][chainID][pkh]; ok {
+		if previous.Level == level && previous.Round == round && previous.Hash == hash {
+			return previous.Signature, nil
+		}
+		if level < previous.Level || (level == previous.Level && round <= previous.Round) {
+			return "", fmt.Errorf("level %d and round %d are not above high watermark (level %d, round %d)", level, round, previous.Level, previous.Round)
+		}
+	}
+
+	signature, err := signFn()
+	if err != nil {
+		return "", err
+	}
+
+	if _, ok := h.files[file][chainID]; !ok {
+		h.files[file][chainID] = map[string]Watermark{}
+	}
+
+	previous, hadPrevious := h.files[file][chainID][pkh]
+	h.files[file][chainID][pkh] = Watermark{
+		Level:     level,
+		Round:     round,
+		Hash:      hash,
+		Signature: signature,
+	}
+
+	if err := h.save(file); err != nil {
+		// the signature must not be returned, or served from memory later, unless its watermark is on disk
+		if hadPrevious {
+			h.files[file][chainID][pkh] = previous
+		} else {
+			delete(h.files[file][chainID], pkh)
+		}
+		return "", err
+	}
+
+	return signature, nil
+}
+
+func (h *HighWatermark) save(file string) error {
+	v, err := json.MarshalIndent(h.files[file], "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal high watermarks")
+	}
+
+	tmp := filepath.Join(h.dir, fmt.Sprintf(".%s.tmp", file))
+	if err := ioutil.WriteFile(tmp, v, 0600); err != nil {
+		return errors.Wrap(err, "failed to write high watermarks")
+	}
+
+	if err := os.Rename(tmp, filepath.Join(h.dir, file)); err != nil {
+		return errors.Wrap(err, "failed to write high watermarks")
+	}
+
+	return nil
+}
+
+/*
+parseWatermarked extracts the chain id, level and round of watermarked block or consensus operation bytes.
+
+Layouts (after the magic byte and 4 byte chain id):
+	block (0x01):             level
+	block (0x11):             level, proto, predecessor, timestamp, validation pass, operations hash, fitness (round last)
+	endorsement (0x02):       branch, tag, level
+	(pre)endorsement (0x12, 0x13): branch, tag, slot, level, round
+*/
+func parseWatermarked(data []byte) (string, int32, int32, error) {
+	if len(data) < 9 {
+		return "", 0, 0, errors.New("invalid watermarked data: too short")
+	}
+
+	chainID := b58.MustEncode(b58.ChainID, data[1:5])
+	readInt32 := func(offset int) (int32, error) {
+		if offset < 0 || len(data) < offset+4 {
+			return 0, errors.New("invalid watermarked data: too short")
+		}
+		return int32(binary.BigEndian.Uint32(data[offset : offset+4])), nil
+	}
+
+	var (
+		level, round int32
+		err          error
+	)
+
+	switch data[0] {
+	case magicBlock:
+		level, err = readInt32(5)
+	case magicTenderbakeBlock:
+		if level, err = readInt32(5); err != nil {
+			break
+		}
+
+		fitnessOffset := 5 + 4 + 1 + 32 + 8 + 1 + 32
+		var fitnessLength int32
+		if fitnessLength, err = readInt32(fitnessOffset); err != nil {
+			break
+		}
+		// the round is the last 4 bytes of the fitness
+		fitnessEnd := fitnessOffset + 4 + int(fitnessLength)
+		if fitnessLength < 4 || fitnessEnd > len(data) {
+			err = fmt.Errorf("invalid watermarked data: invalid fitness length %d", fitnessLength)
+			break
+		}
+		round, err = readInt32(fitnessEnd - 4)
+	case magicEndorsement:
+		level, err = readInt32(5 + 32 + 1)
+	case magicPreendorsement, magicTenderbakeEndorse:
+		if level, err = readInt32(5 + 32 + 1 + 2); err != nil {
+			break
+		}
+		round, err = readInt32(5 + 32 + 1 + 2 + 4)
+	default:
+		err = fmt.Errorf("invalid watermarked data: unsupported magic byte %d", data[0])
+	}
+
+	if err != nil {
+		return "", 0, 0, err
+	}
+
+	return chainID, level, round, nil
+}
+
+// isMagicByte reports whether b is one of the allowed magic bytes. An empty allow list allows everything.
+func isMagicByte(b byte, allowed []byte) bool {
+	return len(allowed) == 0 || bytes.IndexByte(allowed, b) != -1
+}